@@ -27,6 +27,7 @@ import (
 	slibpath "github.com/teserakt-io/serverlib/path"
 
 	"github.com/teserakt-io/automation-engine/internal/api"
+	"github.com/teserakt-io/automation-engine/internal/clock"
 	"github.com/teserakt-io/automation-engine/internal/config"
 	"github.com/teserakt-io/automation-engine/internal/engine"
 	"github.com/teserakt-io/automation-engine/internal/engine/actions"
@@ -129,6 +130,7 @@ func main() {
 
 	ruleService := services.NewRuleService(db, validator)
 	triggerStateService := services.NewTriggerStateService(db)
+	executionService := services.NewExecutionService(db)
 
 	globalErrorChan := make(chan error)
 
@@ -145,20 +147,38 @@ func main() {
 	// command, ie: when a rule trigger.
 	c2client := services.NewC2(c2ClientFactory)
 
-	eventStreamer := events.NewStreamer(c2client, logger.WithField("type", "eventStreamer"))
+	// Every engine component reads the time and schedules from the same clock
+	engineClock := clock.New()
+
+	eventStreamer := events.NewStreamer(c2client, engineClock, logger.WithField("type", "eventStreamer"))
+	statusTracker := watchers.NewStatusTracker(engineClock)
 
 	triggerWatcherFactory := watchers.NewTriggerWatcherFactory(
 		events.NewStreamListenerFactory(eventStreamer),
 		triggerStateService,
 		validator,
+		statusTracker,
+		engineClock,
 		logger.WithField("type", "triggerWatcher"),
 	)
-	actionFactory := actions.NewActionFactory(c2client, globalErrorChan, logger.WithField("type", "ruleAction"))
+	actionFactory := actions.NewActionFactory(
+		c2client,
+		actions.NewTargetResolver(c2client, actions.DefaultResolverPageSize),
+		appConfig.Engine.ActionMaxTargets,
+		appConfig.Engine.DryRun,
+		engineClock,
+		globalErrorChan,
+		logger.WithField("type", "ruleAction"),
+	)
 
 	ruleWatcherFactory := watchers.NewRuleWatcherFactory(
 		ruleService,
+		executionService,
 		triggerWatcherFactory,
 		actionFactory,
+		appConfig.Engine.ResumePolicy,
+		engineClock,
+		statusTracker,
 		globalErrorChan,
 		logger.WithField("type", "ruleWatcher"),
 	)
@@ -166,13 +186,19 @@ func main() {
 	automationEngine := engine.NewAutomationEngine(
 		ruleService,
 		ruleWatcherFactory,
+		statusTracker,
+		eventStreamer,
 		logger.WithField("type", "automationEngine"),
 	)
 
 	server := api.NewServer(
 		appConfig.Server,
 		ruleService,
+		executionService,
+		actionFactory,
+		automationEngine,
 		converter,
+		engineClock,
 		logger.WithField("type", "apiServer"),
 	)
 
@@ -192,9 +218,8 @@ func main() {
 	}()
 
 	// Start automation engine. Will start a background routine for every rules
-	// and every rule's triggers until engineCtx or globalCtx get cancelled.
-	engineCtx, engineCancel := context.WithCancel(globalCtx)
-	if err := automationEngine.Start(engineCtx); err != nil {
+	// and every rule's triggers until globalCtx get cancelled.
+	if err := automationEngine.Start(globalCtx); err != nil {
 		logger.WithError(err).Error("error when starting automation engine")
 		exitCode = 1
 		return
@@ -225,26 +250,31 @@ func main() {
 		}
 	}()
 
-	// Listen for changes in the database and stop / restart the automation engine,
-	// creating a fresh engineCtx.
-	// Cancelling the globalCtx stop it from restarting indefinitely.
-	for {
-		select {
-		case <-server.RulesModifiedChan():
-			logger.Info("rules modified, restarting automation engine!")
-
-			engineCancel()
+	// Listen for rules modifications and reload their watchers only.
+	// Reloading waits for the stopped watchers to return, and they may be sending on
+	// the globalErrorChan, so it must not happen in the loop draining it.
+	go func() {
+		for {
+			select {
+			case <-server.RulesModifiedChan():
+				ruleIDs := server.ModifiedRules()
+				logger.WithField("rules", ruleIDs).Info("rules modified, reloading automation engine")
 
-			engineCtx, engineCancel = context.WithCancel(globalCtx)
-			if err := automationEngine.Start(engineCtx); err != nil {
-				logger.WithError(err).Error("failed to restart automation engine")
+				if err := automationEngine.Reload(globalCtx, ruleIDs...); err != nil {
+					logger.WithError(err).Error("failed to reload automation engine")
+				}
+			case <-globalCtx.Done():
+				return
 			}
-			logger.Info("restarted automation engine")
+		}
+	}()
+
+	for {
+		select {
 		case err := <-globalErrorChan:
 			logger.WithError(err).Error("a goroutine emitted an error")
 
 		case <-globalCtx.Done():
-			engineCancel()
 			return
 		}
 	}
@@ -19,14 +19,21 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"sort"
+	"sync"
+	"time"
 
+	"github.com/golang/protobuf/ptypes"
 	"github.com/grpc-ecosystem/grpc-gateway/runtime"
 	log "github.com/sirupsen/logrus"
 	"go.opencensus.io/trace"
 	grpc "google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 
+	"github.com/teserakt-io/automation-engine/internal/clock"
 	"github.com/teserakt-io/automation-engine/internal/config"
+	"github.com/teserakt-io/automation-engine/internal/engine"
+	"github.com/teserakt-io/automation-engine/internal/engine/actions"
 	"github.com/teserakt-io/automation-engine/internal/models"
 	"github.com/teserakt-io/automation-engine/internal/pb"
 	"github.com/teserakt-io/automation-engine/internal/services"
@@ -36,16 +43,26 @@ import (
 type Server interface {
 	pb.C2AutomationEngineServer
 	ListenAndServe(ctx context.Context) error
-	RulesModifiedChan() <-chan bool
+	// RulesModifiedChan receives a value when rules have been modified since the last
+	// call to ModifiedRules. Several modifications may be coalesced in a single notification.
+	RulesModifiedChan() <-chan struct{}
+	// ModifiedRules returns the IDs of the rules modified since its last call
+	ModifiedRules() []int
 }
 
 type apiServer struct {
-	cfg         config.ServerCfg
-	ruleService services.RuleService
-	converter   models.Converter
-	logger      log.FieldLogger
-
-	rulesModified chan bool
+	cfg              config.ServerCfg
+	ruleService      services.RuleService
+	executionService services.ExecutionService
+	actionFactory    actions.ActionFactory
+	automationEngine engine.AutomationEngine
+	converter        models.Converter
+	clock            clock.Clock
+	logger           log.FieldLogger
+
+	rulesModified     chan struct{}
+	modifiedRulesLock sync.Mutex
+	modifiedRuleIDs   map[int]struct{}
 }
 
 var _ pb.C2AutomationEngineServer = &apiServer{}
@@ -54,23 +71,47 @@ var _ pb.C2AutomationEngineServer = &apiServer{}
 func NewServer(
 	cfg config.ServerCfg,
 	ruleService services.RuleService,
+	executionService services.ExecutionService,
+	actionFactory actions.ActionFactory,
+	automationEngine engine.AutomationEngine,
 	converter models.Converter,
+	clock clock.Clock,
 	logger log.FieldLogger,
 ) Server {
 	return &apiServer{
-		cfg:         cfg,
-		ruleService: ruleService,
-		converter:   converter,
-		logger:      logger,
-
-		rulesModified: make(chan bool),
+		cfg:              cfg,
+		ruleService:      ruleService,
+		executionService: executionService,
+		actionFactory:    actionFactory,
+		automationEngine: automationEngine,
+		converter:        converter,
+		clock:            clock,
+		logger:           logger,
+
+		rulesModified:   make(chan struct{}, 1),
+		modifiedRuleIDs: make(map[int]struct{}),
 	}
 }
 
-func (s *apiServer) RulesModifiedChan() <-chan bool {
+func (s *apiServer) RulesModifiedChan() <-chan struct{} {
 	return s.rulesModified
 }
 
+func (s *apiServer) ModifiedRules() []int {
+	s.modifiedRulesLock.Lock()
+	defer s.modifiedRulesLock.Unlock()
+
+	ruleIDs := make([]int, 0, len(s.modifiedRuleIDs))
+	for ruleID := range s.modifiedRuleIDs {
+		ruleIDs = append(ruleIDs, ruleID)
+	}
+	sort.Ints(ruleIDs)
+
+	s.modifiedRuleIDs = make(map[int]struct{})
+
+	return ruleIDs
+}
+
 func (s *apiServer) ListenAndServe(ctx context.Context) error {
 	var lc net.ListenConfig
 	grpcLis, err := lc.Listen(ctx, "tcp", s.cfg.GRPCAddr)
@@ -212,11 +253,40 @@ func (s *apiServer) AddRule(ctx context.Context, req *pb.AddRuleRequest) (*pb.Ru
 		return nil, err
 	}
 
+	// Force creation of new actions
+	for i := 0; i < len(req.Actions); i++ {
+		req.Actions[i].Id = 0
+	}
+
+	actions, err := s.converter.PbToActions(req.Actions)
+	if err != nil {
+		return nil, err
+	}
+
+	cooldown, err := models.PbToDuration(req.Cooldown)
+	if err != nil {
+		return nil, err
+	}
+
+	executionPeriod, err := models.PbToDuration(req.ExecutionPeriod)
+	if err != nil {
+		return nil, err
+	}
+
 	rule := &models.Rule{
-		Description: req.Description,
-		ActionType:  req.Action,
-		Triggers:    triggers,
-		Targets:     targets,
+		Description:      req.Description,
+		ActionType:       req.Action,
+		ActionSettings:   req.ActionSettings,
+		Enabled:          true,
+		DryRun:           req.DryRun,
+		TriggerMode:      req.TriggerMode,
+		TriggerThreshold: int(req.TriggerThreshold),
+		Cooldown:         cooldown,
+		MaxExecutions:    int(req.MaxExecutions),
+		ExecutionPeriod:  executionPeriod,
+		Triggers:         triggers,
+		Targets:          targets,
+		Actions:          actions,
 	}
 
 	err = s.ruleService.Save(ctx, rule)
@@ -229,7 +299,7 @@ func (s *apiServer) AddRule(ctx context.Context, req *pb.AddRuleRequest) (*pb.Ru
 		return nil, err
 	}
 
-	s.notifyRulesModified()
+	s.notifyRulesModified(rule.ID)
 
 	return &pb.RuleResponse{
 		Rule: pbRule,
@@ -254,6 +324,21 @@ func (s *apiServer) UpdateRule(ctx context.Context, req *pb.UpdateRuleRequest) (
 		return nil, err
 	}
 
+	actions, err := s.converter.PbToActions(req.Actions)
+	if err != nil {
+		return nil, err
+	}
+
+	cooldown, err := models.PbToDuration(req.Cooldown)
+	if err != nil {
+		return nil, err
+	}
+
+	executionPeriod, err := models.PbToDuration(req.ExecutionPeriod)
+	if err != nil {
+		return nil, err
+	}
+
 	deletedTriggers := models.FilterNonExistingTriggers(rule.Triggers, triggers)
 	if len(deletedTriggers) > 0 {
 		s.logger.WithField("count", len(deletedTriggers)).Info("deleting removed triggers")
@@ -272,10 +357,33 @@ func (s *apiServer) UpdateRule(ctx context.Context, req *pb.UpdateRuleRequest) (
 		}
 	}
 
+	deletedActions := models.FilterNonExistingActions(rule.Actions, actions)
+	if len(deletedActions) > 0 {
+		s.logger.WithField("count", len(deletedActions)).Info("deleting removed actions")
+		err := s.ruleService.DeleteActions(ctx, deletedActions...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	stored := rule
+
 	rule.Description = req.Description
 	rule.ActionType = req.Action
+	rule.ActionSettings = req.ActionSettings
+	rule.DryRun = req.DryRun
+	rule.TriggerMode = req.TriggerMode
+	rule.TriggerThreshold = int(req.TriggerThreshold)
+	rule.Cooldown = cooldown
+	rule.MaxExecutions = int(req.MaxExecutions)
+	rule.ExecutionPeriod = executionPeriod
 	rule.Triggers = triggers
 	rule.Targets = targets
+	rule.Actions = actions
+
+	if err := rule.RestoreSecrets(stored); err != nil {
+		return nil, err
+	}
 
 	if err := s.ruleService.Save(ctx, &rule); err != nil {
 		return nil, err
@@ -286,7 +394,7 @@ func (s *apiServer) UpdateRule(ctx context.Context, req *pb.UpdateRuleRequest) (
 		return nil, err
 	}
 
-	s.notifyRulesModified()
+	s.notifyRulesModified(rule.ID)
 
 	return &pb.RuleResponse{
 		Rule: pbRule,
@@ -306,11 +414,196 @@ func (s *apiServer) DeleteRule(ctx context.Context, req *pb.DeleteRuleRequest) (
 		return nil, err
 	}
 
-	s.notifyRulesModified()
+	s.notifyRulesModified(rule.ID)
 
 	return &pb.DeleteRuleResponse{RuleId: int32(rule.ID)}, nil
 }
 
+func (s *apiServer) PauseRule(ctx context.Context, req *pb.PauseRuleRequest) (*pb.RuleResponse, error) {
+	ctx, span := trace.StartSpan(ctx, "PauseRule")
+	defer span.End()
+
+	return s.setRuleEnabled(ctx, int(req.RuleId), false)
+}
+
+func (s *apiServer) ResumeRule(ctx context.Context, req *pb.ResumeRuleRequest) (*pb.RuleResponse, error) {
+	ctx, span := trace.StartSpan(ctx, "ResumeRule")
+	defer span.End()
+
+	return s.setRuleEnabled(ctx, int(req.RuleId), true)
+}
+
+// setRuleEnabled pauses or resumes the rule identified by ruleID.
+// Nothing is saved when the rule is already in the requested state.
+func (s *apiServer) setRuleEnabled(ctx context.Context, ruleID int, enabled bool) (*pb.RuleResponse, error) {
+	rule, err := s.ruleService.ByID(ctx, ruleID)
+	if err != nil {
+		return nil, err
+	}
+
+	if rule.Enabled != enabled {
+		rule.Enabled = enabled
+		if enabled {
+			rule.ResumedAt = s.clock.Now()
+		}
+
+		if err := s.ruleService.SaveEnabled(ctx, rule); err != nil {
+			return nil, err
+		}
+
+		s.notifyRulesModified(rule.ID)
+	}
+
+	pbRule, err := s.converter.RuleToPb(rule)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.RuleResponse{
+		Rule: pbRule,
+	}, nil
+}
+
+func (s *apiServer) ExecuteRule(ctx context.Context, req *pb.ExecuteRuleRequest) (*pb.ExecutionResponse, error) {
+	ctx, span := trace.StartSpan(ctx, "ExecuteRule")
+	defer span.End()
+
+	rule, err := s.ruleService.ByID(ctx, int(req.RuleId))
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.clock.Now()
+
+	if req.DryRun {
+		rule.DryRun = true
+	}
+
+	// Manual executions are not caused by any trigger
+	action, err := s.actionFactory.Create(rule, actions.TriggerContext{Time: now})
+	if err != nil {
+		return nil, err
+	}
+
+	execution := &models.Execution{
+		RuleID:      rule.ID,
+		TriggeredAt: now,
+		DryRun:      s.actionFactory.DryRun(rule),
+	}
+
+	if !execution.DryRun {
+		// Like scheduled executions, LastExecuted is updated before executing the action,
+		// and the rule watchers get reloaded to schedule their next runs from it.
+		// The armed triggers are reset, as on executions caused by a trigger combination.
+		rule.LastExecuted = now
+		rule.ArmedTriggers = nil
+		if err := s.ruleService.SaveExecutionState(ctx, rule); err != nil {
+			return nil, err
+		}
+
+		s.notifyRulesModified(rule.ID)
+	}
+
+	s.logger.WithFields(log.Fields{
+		"rule":   rule.ID,
+		"dryRun": execution.DryRun,
+	}).Info("rule manually executed")
+
+	execution.Outcomes, err = action.Execute(ctx)
+	execution.Duration = s.clock.Now().Sub(now)
+	if err != nil {
+		s.logger.WithError(err).WithField("rule", rule.ID).Error("rule action failed")
+		execution.Error = err.Error()
+	}
+
+	if err := s.executionService.Save(ctx, execution); err != nil {
+		return nil, err
+	}
+
+	// The pending outcomes complete after the request, until the rule is paused or deleted
+	if _, ok := action.(actions.PendingAction); ok {
+		actions.CompletePending(s.automationEngine.PendingContext(rule.ID), action, *execution, s.executionService, s.logger)
+	}
+
+	pbExecution, err := s.converter.ExecutionToPb(*execution)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ExecutionResponse{
+		Execution: pbExecution,
+	}, nil
+}
+
+func (s *apiServer) ListExecutions(ctx context.Context, req *pb.ListExecutionsRequest) (*pb.ExecutionsResponse, error) {
+	ctx, span := trace.StartSpan(ctx, "ListExecutions")
+	defer span.End()
+
+	var since time.Time
+	if req.Since != nil {
+		var err error
+		since, err = ptypes.Timestamp(req.Since)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	executions, err := s.executionService.List(ctx, int(req.RuleId), since, int(req.Limit))
+	if err != nil {
+		return nil, err
+	}
+
+	pbExecutions, err := s.converter.ExecutionsToPb(executions)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ExecutionsResponse{
+		Executions: pbExecutions,
+	}, nil
+}
+
+func (s *apiServer) GetRuleStatus(ctx context.Context, req *pb.GetRuleStatusRequest) (*pb.RuleStatusResponse, error) {
+	ctx, span := trace.StartSpan(ctx, "GetRuleStatus")
+	defer span.End()
+
+	rule, err := s.ruleService.ByID(ctx, int(req.RuleId))
+	if err != nil {
+		return nil, err
+	}
+
+	pbStatus, err := s.converter.RuleStatusToPb(s.automationEngine.RuleStatus(rule.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.RuleStatusResponse{
+		Status: pbStatus,
+	}, nil
+}
+
+func (s *apiServer) GetEngineStatus(ctx context.Context, req *pb.GetEngineStatusRequest) (*pb.EngineStatusResponse, error) {
+	_, span := trace.StartSpan(ctx, "GetEngineStatus")
+	defer span.End()
+
+	status := s.automationEngine.Status()
+
+	pbRules, err := s.converter.RuleStatusesToPb(status.Rules)
+	if err != nil {
+		return nil, err
+	}
+
+	pbEventStream, err := s.converter.EventStreamStatusToPb(status.EventStream)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.EngineStatusResponse{
+		Rules:       pbRules,
+		EventStream: pbEventStream,
+	}, nil
+}
+
 func (s *apiServer) HealthCheck(ctx context.Context, req *pb.HealthCheckRequest) (*pb.HealthCheckResponse, error) {
 	return &pb.HealthCheckResponse{
 		Code:   0,
@@ -318,10 +611,17 @@ func (s *apiServer) HealthCheck(ctx context.Context, req *pb.HealthCheckRequest)
 	}, nil
 }
 
-func (s *apiServer) notifyRulesModified() {
+// notifyRulesModified records the given rules as modified, and signals it on the rulesModified channel.
+// When a notification is already pending, the rules will be returned along with it by ModifiedRules.
+func (s *apiServer) notifyRulesModified(ruleIDs ...int) {
+	s.modifiedRulesLock.Lock()
+	for _, ruleID := range ruleIDs {
+		s.modifiedRuleIDs[ruleID] = struct{}{}
+	}
+	s.modifiedRulesLock.Unlock()
+
 	select {
-	case s.rulesModified <- true:
+	case s.rulesModified <- struct{}{}:
 	default:
-		s.logger.Warn("skipped writting ruleModified event, channel is busy")
 	}
 }
@@ -18,6 +18,7 @@ import (
 	"bytes"
 	"context"
 	"crypto/tls"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net"
@@ -29,20 +30,35 @@ import (
 	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/ptypes"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 
+	"github.com/teserakt-io/automation-engine/internal/clock"
 	"github.com/teserakt-io/automation-engine/internal/config"
+	"github.com/teserakt-io/automation-engine/internal/engine"
+	"github.com/teserakt-io/automation-engine/internal/engine/actions"
 	"github.com/teserakt-io/automation-engine/internal/models"
 	"github.com/teserakt-io/automation-engine/internal/pb"
 	"github.com/teserakt-io/automation-engine/internal/services"
 )
 
-func assertRulesModified(t *testing.T, rulesModifiedChan <-chan bool, expectedModified bool) {
-	rulesModified := <-rulesModifiedChan
-	if rulesModified != expectedModified {
-		t.Errorf("Expected rulesModified to be %t, got %t", expectedModified, rulesModified)
+func assertRulesModified(t *testing.T, server Server, expectedRuleIDs ...int) {
+	select {
+	case <-server.RulesModifiedChan():
+		if len(expectedRuleIDs) == 0 {
+			t.Errorf("Expected no rules modified notification")
+		}
+	default:
+		if len(expectedRuleIDs) > 0 {
+			t.Errorf("Expected a rules modified notification")
+		}
+	}
+
+	ruleIDs := server.ModifiedRules()
+	if len(ruleIDs) != len(expectedRuleIDs) || (len(ruleIDs) > 0 && reflect.DeepEqual(ruleIDs, expectedRuleIDs) == false) {
+		t.Errorf("Expected modified rules to be %v, got %v", expectedRuleIDs, ruleIDs)
 	}
 }
 
@@ -58,6 +74,9 @@ func TestServer(t *testing.T) {
 
 	mockConverter := models.NewMockConverter(mockCtrl)
 	mockRuleService := services.NewMockRuleService(mockCtrl)
+	mockExecutionService := services.NewMockExecutionService(mockCtrl)
+	mockActionFactory := actions.NewMockActionFactory(mockCtrl)
+	mockAutomationEngine := engine.NewMockAutomationEngine(mockCtrl)
 
 	grpcLis, err := net.Listen("tcp", "127.0.0.1:0")
 	if err != nil {
@@ -87,19 +106,9 @@ func TestServer(t *testing.T) {
 	logger := log.New()
 	logger.SetOutput(ioutil.Discard)
 
-	server := NewServer(serverCfg, mockRuleService, mockConverter, logger)
+	fakeClock := clock.NewFake(time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC))
 
-	rulesModifiedChan := make(chan bool)
-	go func() {
-		for {
-			select {
-			case modified := <-server.RulesModifiedChan():
-				rulesModifiedChan <- modified
-			case <-time.After(100 * time.Millisecond):
-				rulesModifiedChan <- false
-			}
-		}
-	}()
+	server := NewServer(serverCfg, mockRuleService, mockExecutionService, mockActionFactory, mockAutomationEngine, mockConverter, fakeClock, logger)
 
 	t.Run("ListRules returns all the rules", func(t *testing.T) {
 		rules := []models.Rule{
@@ -122,7 +131,7 @@ func TestServer(t *testing.T) {
 			t.Errorf("Expected err to be nil, got %s", err)
 		}
 
-		assertRulesModified(t, rulesModifiedChan, false)
+		assertRulesModified(t, server)
 
 		if reflect.DeepEqual(resp.Rules, pbRules) == false {
 			t.Errorf("Expected rules to be %#v, got %#v", pbRules, resp.Rules)
@@ -140,17 +149,38 @@ func TestServer(t *testing.T) {
 			&pb.Trigger{Id: 2},
 		}
 
+		pbActions := []*pb.Action{
+			&pb.Action{Id: 1},
+		}
+
 		req := &pb.AddRuleRequest{
-			Action:      pb.ActionType_KEY_ROTATION,
-			Description: "description",
-			Targets:     pbTargets,
-			Triggers:    pbTriggers,
+			Action:           pb.ActionType_KEY_ROTATION,
+			Description:      "description",
+			Targets:          pbTargets,
+			Triggers:         pbTriggers,
+			Actions:          pbActions,
+			TriggerMode:      pb.TriggerMode_THRESHOLD_TRIGGERS,
+			TriggerThreshold: 2,
+			Cooldown:         ptypes.DurationProto(time.Minute),
 		}
 
 		mockConverter.EXPECT().PbToTriggers(pbTriggers).Times(1)
 		mockConverter.EXPECT().PbToTargets(pbTargets).Times(1)
-
-		mockRuleService.EXPECT().Save(gomock.Any(), gomock.Any()).Times(1)
+		mockConverter.EXPECT().PbToActions([]*pb.Action{&pb.Action{Id: 0}}).Times(1)
+
+		mockRuleService.EXPECT().Save(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+			func(ctx context.Context, rule *models.Rule) error {
+				if rule.TriggerMode != pb.TriggerMode_THRESHOLD_TRIGGERS || rule.TriggerThreshold != 2 {
+					t.Errorf("Expected rule trigger mode to be THRESHOLD_TRIGGERS with threshold 2, got %s with %d", rule.TriggerMode, rule.TriggerThreshold)
+				}
+				if rule.Cooldown != time.Minute {
+					t.Errorf("Expected rule cooldown to be %s, got %s", time.Minute, rule.Cooldown)
+				}
+				rule.ID = 1
+
+				return nil
+			},
+		)
 
 		pbRule := &pb.Rule{Id: 1}
 		mockConverter.EXPECT().RuleToPb(gomock.Any()).Times(1).Return(pbRule, nil)
@@ -160,7 +190,7 @@ func TestServer(t *testing.T) {
 			t.Errorf("Expected err to be nil, got %s", err)
 		}
 
-		assertRulesModified(t, rulesModifiedChan, true)
+		assertRulesModified(t, server, 1)
 
 		if reflect.DeepEqual(resp.Rule, pbRule) == false {
 			t.Errorf("Expected rule to be %#v, got %#v", pbRule, resp.Rule)
@@ -188,12 +218,21 @@ func TestServer(t *testing.T) {
 			&pb.Trigger{Id: 3},
 		}
 
+		actions := []models.Action{
+			models.Action{ID: 2},
+		}
+
+		pbActions := []*pb.Action{
+			&pb.Action{Id: 2},
+		}
+
 		req := &pb.UpdateRuleRequest{
 			RuleId:      1,
 			Action:      pb.ActionType_KEY_ROTATION,
 			Description: "new description",
 			Targets:     pbTargets,
 			Triggers:    pbTriggers,
+			Actions:     pbActions,
 		}
 
 		ruleBefore := models.Rule{
@@ -207,6 +246,10 @@ func TestServer(t *testing.T) {
 				models.Target{ID: 1},
 				models.Target{ID: 2},
 			},
+			Actions: []models.Action{
+				models.Action{ID: 1},
+				models.Action{ID: 2},
+			},
 		}
 
 		updatedRule := models.Rule{
@@ -215,6 +258,7 @@ func TestServer(t *testing.T) {
 			Description: "new description",
 			Triggers:    triggers,
 			Targets:     targets,
+			Actions:     actions,
 		}
 
 		updatedPbRule := &pb.Rule{
@@ -225,9 +269,11 @@ func TestServer(t *testing.T) {
 
 		mockConverter.EXPECT().PbToTriggers(pbTriggers).Times(1).Return(triggers, nil)
 		mockConverter.EXPECT().PbToTargets(pbTargets).Times(1).Return(targets, nil)
+		mockConverter.EXPECT().PbToActions(pbActions).Times(1).Return(actions, nil)
 
 		mockRuleService.EXPECT().DeleteTriggers(gomock.Any(), []models.Trigger{models.Trigger{ID: 1}}).Times(1)
 		mockRuleService.EXPECT().DeleteTargets(gomock.Any(), []models.Target{models.Target{ID: 1}}).Times(1)
+		mockRuleService.EXPECT().DeleteActions(gomock.Any(), []models.Action{models.Action{ID: 1}}).Times(1)
 
 		mockRuleService.EXPECT().Save(gomock.Any(), gomock.Any()).Times(1)
 
@@ -238,7 +284,7 @@ func TestServer(t *testing.T) {
 			t.Errorf("Expected err to be nil, got %s", err)
 		}
 
-		assertRulesModified(t, rulesModifiedChan, true)
+		assertRulesModified(t, server, 1)
 
 		if reflect.DeepEqual(updatedPbRule, resp.Rule) == false {
 			t.Errorf("Expected rule to be %#v, got %#v", updatedPbRule, resp.Rule)
@@ -260,13 +306,94 @@ func TestServer(t *testing.T) {
 			t.Errorf("Expected err to be nil, got %s", err)
 		}
 
-		assertRulesModified(t, rulesModifiedChan, true)
+		assertRulesModified(t, server, 1)
 
 		if resp.RuleId != req.RuleId {
 			t.Errorf("Expected ruleId to be %d, got %d", req.RuleId, resp.RuleId)
 		}
 	})
 
+	t.Run("PauseRule disables given rule", func(t *testing.T) {
+		req := &pb.PauseRuleRequest{
+			RuleId: 1,
+		}
+
+		rule := models.Rule{ID: 1, Enabled: true}
+		pausedRule := models.Rule{ID: 1, Enabled: false}
+		pbRule := &pb.Rule{Id: 1}
+
+		mockRuleService.EXPECT().ByID(gomock.Any(), 1).Times(1).Return(rule, nil)
+		mockRuleService.EXPECT().SaveEnabled(gomock.Any(), pausedRule).Times(1)
+		mockConverter.EXPECT().RuleToPb(pausedRule).Times(1).Return(pbRule, nil)
+
+		resp, err := server.PauseRule(context.Background(), req)
+		if err != nil {
+			t.Errorf("Expected err to be nil, got %s", err)
+		}
+
+		assertRulesModified(t, server, 1)
+
+		if reflect.DeepEqual(resp.Rule, pbRule) == false {
+			t.Errorf("Expected rule to be %#v, got %#v", pbRule, resp.Rule)
+		}
+	})
+
+	t.Run("PauseRule does nothing on already paused rule", func(t *testing.T) {
+		req := &pb.PauseRuleRequest{
+			RuleId: 1,
+		}
+
+		rule := models.Rule{ID: 1, Enabled: false}
+		pbRule := &pb.Rule{Id: 1}
+
+		mockRuleService.EXPECT().ByID(gomock.Any(), 1).Times(1).Return(rule, nil)
+		mockConverter.EXPECT().RuleToPb(rule).Times(1).Return(pbRule, nil)
+
+		if _, err := server.PauseRule(context.Background(), req); err != nil {
+			t.Errorf("Expected err to be nil, got %s", err)
+		}
+
+		assertRulesModified(t, server)
+	})
+
+	t.Run("ResumeRule enables given rule and records when it was resumed", func(t *testing.T) {
+		req := &pb.ResumeRuleRequest{
+			RuleId: 1,
+		}
+
+		rule := models.Rule{ID: 1, Enabled: false}
+		pbRule := &pb.Rule{Id: 1}
+
+		now := fakeClock.Now()
+
+		mockRuleService.EXPECT().ByID(gomock.Any(), 1).Times(1).Return(rule, nil)
+		mockRuleService.EXPECT().SaveEnabled(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+			func(ctx context.Context, savedRule models.Rule) error {
+				if !savedRule.Enabled {
+					t.Errorf("Expected saved rule to be enabled")
+				}
+
+				if !savedRule.ResumedAt.Equal(now) {
+					t.Errorf("Expected resumedAt to be %s, got %s", now, savedRule.ResumedAt)
+				}
+
+				return nil
+			},
+		)
+		mockConverter.EXPECT().RuleToPb(gomock.Any()).Times(1).Return(pbRule, nil)
+
+		resp, err := server.ResumeRule(context.Background(), req)
+		if err != nil {
+			t.Errorf("Expected err to be nil, got %s", err)
+		}
+
+		assertRulesModified(t, server, 1)
+
+		if reflect.DeepEqual(resp.Rule, pbRule) == false {
+			t.Errorf("Expected rule to be %#v, got %#v", pbRule, resp.Rule)
+		}
+	})
+
 	t.Run("GetRule returns expected rule", func(t *testing.T) {
 		req := &pb.GetRuleRequest{
 			RuleId: 1,
@@ -283,13 +410,396 @@ func TestServer(t *testing.T) {
 			t.Errorf("Expected err to be nil, got %s", err)
 		}
 
-		assertRulesModified(t, rulesModifiedChan, false)
+		assertRulesModified(t, server)
 
 		if reflect.DeepEqual(resp.Rule, pbRule) == false {
 			t.Errorf("Expected rule to be %#v, got %#v", pbRule, resp.Rule)
 		}
 	})
 
+	t.Run("ExecuteRule executes the rule action and records the execution", func(t *testing.T) {
+		req := &pb.ExecuteRuleRequest{
+			RuleId: 1,
+		}
+
+		rule := models.Rule{ID: 1, ActionType: pb.ActionType_KEY_ROTATION}
+		outcomes := []models.ExecutionOutcome{
+			models.ExecutionOutcome{ActionType: pb.ActionType_KEY_ROTATION, TargetType: pb.TargetType_CLIENT, Target: "client1"},
+		}
+		pbExecution := &pb.Execution{Id: 1, RuleId: 1}
+
+		now := fakeClock.Now()
+
+		mockAction := actions.NewMockAction(mockCtrl)
+
+		mockRuleService.EXPECT().ByID(gomock.Any(), 1).Times(1).Return(rule, nil)
+		mockActionFactory.EXPECT().Create(rule, gomock.Any()).Times(1).DoAndReturn(
+			func(rule models.Rule, triggerCtx actions.TriggerContext) (actions.Action, error) {
+				if triggerCtx.Trigger.ID != 0 || triggerCtx.Event != nil {
+					t.Errorf("Expected manual execution to have no trigger, got %#v", triggerCtx)
+				}
+
+				return mockAction, nil
+			},
+		)
+		mockActionFactory.EXPECT().DryRun(rule).Times(1).Return(false)
+		mockRuleService.EXPECT().SaveExecutionState(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+			func(ctx context.Context, savedRule models.Rule) error {
+				if !savedRule.LastExecuted.Equal(now) {
+					t.Errorf("Expected lastExecuted to be %s, got %s", now, savedRule.LastExecuted)
+				}
+
+				return nil
+			},
+		)
+		mockAction.EXPECT().Execute(gomock.Any()).Times(1).DoAndReturn(
+			func(ctx context.Context) ([]models.ExecutionOutcome, error) {
+				fakeClock.Advance(2 * time.Second)
+
+				return outcomes, nil
+			},
+		)
+		mockExecutionService.EXPECT().Save(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+			func(ctx context.Context, execution *models.Execution) error {
+				if execution.RuleID != rule.ID || execution.TriggerID != 0 {
+					t.Errorf("Expected execution of rule %d without trigger, got %#v", rule.ID, execution)
+				}
+
+				if !execution.TriggeredAt.Equal(now) {
+					t.Errorf("Expected execution to be triggered at %s, got %s", now, execution.TriggeredAt)
+				}
+
+				if execution.Duration != 2*time.Second {
+					t.Errorf("Expected execution duration to be %v, got %v", 2*time.Second, execution.Duration)
+				}
+
+				if reflect.DeepEqual(execution.Outcomes, outcomes) == false {
+					t.Errorf("Expected outcomes to be %#v, got %#v", outcomes, execution.Outcomes)
+				}
+
+				execution.ID = 1
+
+				return nil
+			},
+		)
+		mockConverter.EXPECT().ExecutionToPb(gomock.Any()).Times(1).Return(pbExecution, nil)
+
+		resp, err := server.ExecuteRule(context.Background(), req)
+		if err != nil {
+			t.Errorf("Expected err to be nil, got %s", err)
+		}
+
+		assertRulesModified(t, server, 1)
+
+		if reflect.DeepEqual(resp.Execution, pbExecution) == false {
+			t.Errorf("Expected execution to be %#v, got %#v", pbExecution, resp.Execution)
+		}
+	})
+
+	t.Run("ExecuteRule records failed executions", func(t *testing.T) {
+		req := &pb.ExecuteRuleRequest{
+			RuleId: 1,
+		}
+
+		rule := models.Rule{ID: 1}
+		actionErr := errors.New("action error")
+
+		mockAction := actions.NewMockAction(mockCtrl)
+
+		mockRuleService.EXPECT().ByID(gomock.Any(), 1).Times(1).Return(rule, nil)
+		mockActionFactory.EXPECT().Create(rule, gomock.Any()).Times(1).Return(mockAction, nil)
+		mockActionFactory.EXPECT().DryRun(rule).Times(1).Return(false)
+		mockRuleService.EXPECT().SaveExecutionState(gomock.Any(), gomock.Any()).Times(1)
+		mockAction.EXPECT().Execute(gomock.Any()).Times(1).Return(nil, actionErr)
+		mockExecutionService.EXPECT().Save(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+			func(ctx context.Context, execution *models.Execution) error {
+				if execution.Error != actionErr.Error() {
+					t.Errorf("Expected execution error to be %s, got %s", actionErr, execution.Error)
+				}
+
+				return nil
+			},
+		)
+		mockConverter.EXPECT().ExecutionToPb(gomock.Any()).Times(1).Return(&pb.Execution{}, nil)
+
+		if _, err := server.ExecuteRule(context.Background(), req); err != nil {
+			t.Errorf("Expected err to be nil, got %s", err)
+		}
+
+		assertRulesModified(t, server, 1)
+	})
+
+	t.Run("ExecuteRule completes the pending outcomes with the rule pending context", func(t *testing.T) {
+		req := &pb.ExecuteRuleRequest{
+			RuleId: 1,
+		}
+
+		rule := models.Rule{ID: 1}
+		outcomes := []models.ExecutionOutcome{
+			models.ExecutionOutcome{ActionType: pb.ActionType_KEY_ROTATION, TargetType: pb.TargetType_CLIENT, Target: "client1", Pending: true},
+		}
+
+		pendingCtx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		mockAction := actions.NewMockPendingAction(mockCtrl)
+
+		mockRuleService.EXPECT().ByID(gomock.Any(), 1).Times(1).Return(rule, nil)
+		mockActionFactory.EXPECT().Create(rule, gomock.Any()).Times(1).Return(mockAction, nil)
+		mockActionFactory.EXPECT().DryRun(rule).Times(1).Return(false)
+		mockRuleService.EXPECT().SaveExecutionState(gomock.Any(), gomock.Any()).Times(1)
+		mockAction.EXPECT().Execute(gomock.Any()).Times(1).Return(outcomes, nil)
+		mockExecutionService.EXPECT().Save(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+			func(ctx context.Context, execution *models.Execution) error {
+				execution.ID = 1
+				execution.Outcomes[0].ID = 1
+
+				return nil
+			},
+		)
+		mockAutomationEngine.EXPECT().PendingContext(1).Times(1).Return(pendingCtx)
+		mockAction.EXPECT().Complete(pendingCtx, gomock.Any()).Times(1).DoAndReturn(
+			func(ctx context.Context, done func(int, error)) {
+				done(0, nil)
+			},
+		)
+
+		updated := make(chan models.ExecutionOutcome, 1)
+		mockExecutionService.EXPECT().UpdateOutcome(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+			func(ctx context.Context, outcome models.ExecutionOutcome) error {
+				updated <- outcome
+				return nil
+			},
+		)
+		mockConverter.EXPECT().ExecutionToPb(gomock.Any()).Times(1)
+
+		if _, err := server.ExecuteRule(context.Background(), req); err != nil {
+			t.Errorf("Expected err to be nil, got %s", err)
+		}
+
+		assertRulesModified(t, server, 1)
+
+		select {
+		case outcome := <-updated:
+			if outcome.ID != 1 || outcome.Pending {
+				t.Errorf("Expected outcome #1 to be completed, got %#v", outcome)
+			}
+		case <-time.After(time.Second):
+			t.Errorf("Expected the pending outcome to be updated")
+		}
+	})
+
+	t.Run("ExecuteRule in dry run records the execution without updating the rule", func(t *testing.T) {
+		req := &pb.ExecuteRuleRequest{
+			RuleId: 1,
+			DryRun: true,
+		}
+
+		rule := models.Rule{ID: 1}
+		dryRunRule := models.Rule{ID: 1, DryRun: true}
+		outcomes := []models.ExecutionOutcome{
+			models.ExecutionOutcome{ActionType: pb.ActionType_KEY_ROTATION, TargetType: pb.TargetType_CLIENT, Target: "client1", DryRunCalls: "NewClientKey(client1)"},
+		}
+		pbExecution := &pb.Execution{RuleId: 1, DryRun: true}
+
+		mockAction := actions.NewMockAction(mockCtrl)
+
+		mockRuleService.EXPECT().ByID(gomock.Any(), 1).Times(1).Return(rule, nil)
+		mockActionFactory.EXPECT().Create(dryRunRule, gomock.Any()).Times(1).Return(mockAction, nil)
+		mockActionFactory.EXPECT().DryRun(dryRunRule).Times(1).Return(true)
+		mockAction.EXPECT().Execute(gomock.Any()).Times(1).Return(outcomes, nil)
+		mockExecutionService.EXPECT().Save(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+			func(ctx context.Context, execution *models.Execution) error {
+				if !execution.DryRun {
+					t.Errorf("Expected execution to be recorded as a dry run")
+				}
+
+				if reflect.DeepEqual(execution.Outcomes, outcomes) == false {
+					t.Errorf("Expected outcomes to be %#v, got %#v", outcomes, execution.Outcomes)
+				}
+
+				return nil
+			},
+		)
+		mockConverter.EXPECT().ExecutionToPb(gomock.Any()).Times(1).Return(pbExecution, nil)
+
+		resp, err := server.ExecuteRule(context.Background(), req)
+		if err != nil {
+			t.Errorf("Expected err to be nil, got %s", err)
+		}
+
+		assertRulesModified(t, server)
+
+		if reflect.DeepEqual(resp.Execution, pbExecution) == false {
+			t.Errorf("Expected execution to be %#v, got %#v", pbExecution, resp.Execution)
+		}
+	})
+
+	t.Run("ExecuteRule of a dry-run rule does not update the rule", func(t *testing.T) {
+		req := &pb.ExecuteRuleRequest{
+			RuleId: 1,
+		}
+
+		rule := models.Rule{ID: 1, DryRun: true}
+		pbExecution := &pb.Execution{RuleId: 1, DryRun: true}
+
+		mockAction := actions.NewMockAction(mockCtrl)
+
+		mockRuleService.EXPECT().ByID(gomock.Any(), 1).Times(1).Return(rule, nil)
+		mockActionFactory.EXPECT().Create(rule, gomock.Any()).Times(1).Return(mockAction, nil)
+		mockActionFactory.EXPECT().DryRun(rule).Times(1).Return(true)
+		mockAction.EXPECT().Execute(gomock.Any()).Times(1).Return(nil, nil)
+		mockExecutionService.EXPECT().Save(gomock.Any(), gomock.Any()).Times(1)
+		mockConverter.EXPECT().ExecutionToPb(gomock.Any()).Times(1).Return(pbExecution, nil)
+
+		if _, err := server.ExecuteRule(context.Background(), req); err != nil {
+			t.Errorf("Expected err to be nil, got %s", err)
+		}
+
+		assertRulesModified(t, server)
+	})
+
+	t.Run("ExecuteRule returns action creation errors", func(t *testing.T) {
+		req := &pb.ExecuteRuleRequest{
+			RuleId: 1,
+		}
+
+		rule := models.Rule{ID: 1}
+		createErr := errors.New("create error")
+
+		mockRuleService.EXPECT().ByID(gomock.Any(), 1).Times(1).Return(rule, nil)
+		mockActionFactory.EXPECT().Create(rule, gomock.Any()).Times(1).Return(nil, createErr)
+
+		if _, err := server.ExecuteRule(context.Background(), req); err != createErr {
+			t.Errorf("Expected err to be %s, got %s", createErr, err)
+		}
+
+		assertRulesModified(t, server)
+	})
+
+	t.Run("ListExecutions returns the rule executions", func(t *testing.T) {
+		since := time.Now().Add(-time.Hour)
+		pbSince, err := ptypes.TimestampProto(since)
+		if err != nil {
+			t.Fatalf("Failed to convert since to timestamp: %v", err)
+		}
+
+		req := &pb.ListExecutionsRequest{
+			RuleId: 1,
+			Since:  pbSince,
+			Limit:  10,
+		}
+
+		executions := []models.Execution{models.Execution{ID: 1, RuleID: 1}}
+		pbExecutions := []*pb.Execution{&pb.Execution{Id: 1, RuleId: 1}}
+
+		mockExecutionService.EXPECT().List(gomock.Any(), 1, gomock.Any(), 10).Times(1).DoAndReturn(
+			func(ctx context.Context, ruleID int, gotSince time.Time, limit int) ([]models.Execution, error) {
+				if !gotSince.Equal(since) {
+					t.Errorf("Expected since to be %v, got %v", since, gotSince)
+				}
+
+				return executions, nil
+			},
+		)
+		mockConverter.EXPECT().ExecutionsToPb(executions).Times(1).Return(pbExecutions, nil)
+
+		resp, err := server.ListExecutions(context.Background(), req)
+		if err != nil {
+			t.Errorf("Expected err to be nil, got %s", err)
+		}
+
+		assertRulesModified(t, server)
+
+		if reflect.DeepEqual(resp.Executions, pbExecutions) == false {
+			t.Errorf("Expected executions to be %#v, got %#v", pbExecutions, resp.Executions)
+		}
+	})
+
+	t.Run("GetRuleStatus returns the rule status from the engine", func(t *testing.T) {
+		rule := models.Rule{ID: 1}
+		status := models.RuleStatus{
+			RuleID: 1,
+			State:  pb.RuleWatcherState_RULE_RUNNING,
+			Triggers: []models.TriggerStatus{
+				models.TriggerStatus{TriggerID: 2, State: pb.TriggerWatcherState_TRIGGER_INVALID, LastError: "invalid"},
+			},
+		}
+		pbStatus := &pb.RuleStatus{
+			RuleId: 1,
+			State:  pb.RuleWatcherState_RULE_RUNNING,
+			Triggers: []*pb.TriggerStatus{
+				&pb.TriggerStatus{TriggerId: 2, State: pb.TriggerWatcherState_TRIGGER_INVALID, LastError: "invalid"},
+			},
+		}
+
+		mockRuleService.EXPECT().ByID(gomock.Any(), rule.ID).Times(1).Return(rule, nil)
+		mockAutomationEngine.EXPECT().RuleStatus(rule.ID).Times(1).Return(status)
+		mockConverter.EXPECT().RuleStatusToPb(status).Times(1).Return(pbStatus, nil)
+
+		resp, err := server.GetRuleStatus(context.Background(), &pb.GetRuleStatusRequest{RuleId: 1})
+		if err != nil {
+			t.Errorf("Expected err to be nil, got %s", err)
+		}
+
+		if reflect.DeepEqual(resp.Status, pbStatus) == false {
+			t.Errorf("Expected status to be %#v, got %#v", pbStatus, resp.Status)
+		}
+	})
+
+	t.Run("GetRuleStatus returns an error when the rule does not exist", func(t *testing.T) {
+		expectedError := errors.New("record not found")
+		mockRuleService.EXPECT().ByID(gomock.Any(), 2).Times(1).Return(models.Rule{}, expectedError)
+
+		_, err := server.GetRuleStatus(context.Background(), &pb.GetRuleStatusRequest{RuleId: 2})
+		if err != expectedError {
+			t.Errorf("Expected err to be %s, got %s", expectedError, err)
+		}
+	})
+
+	t.Run("GetEngineStatus returns the engine status", func(t *testing.T) {
+		status := models.EngineStatus{
+			Rules:       []models.RuleStatus{models.RuleStatus{RuleID: 1, State: pb.RuleWatcherState_RULE_RUNNING}},
+			EventStream: models.EventStreamStatus{Connected: true, Listeners: 2},
+		}
+		pbRules := []*pb.RuleStatus{&pb.RuleStatus{RuleId: 1, State: pb.RuleWatcherState_RULE_RUNNING}}
+		pbEventStream := &pb.EventStreamStatus{Connected: true, Listeners: 2}
+
+		mockAutomationEngine.EXPECT().Status().Times(1).Return(status)
+		mockConverter.EXPECT().RuleStatusesToPb(status.Rules).Times(1).Return(pbRules, nil)
+		mockConverter.EXPECT().EventStreamStatusToPb(status.EventStream).Times(1).Return(pbEventStream, nil)
+
+		resp, err := server.GetEngineStatus(context.Background(), &pb.GetEngineStatusRequest{})
+		if err != nil {
+			t.Errorf("Expected err to be nil, got %s", err)
+		}
+
+		if reflect.DeepEqual(resp.Rules, pbRules) == false {
+			t.Errorf("Expected rules status to be %#v, got %#v", pbRules, resp.Rules)
+		}
+
+		if reflect.DeepEqual(resp.EventStream, pbEventStream) == false {
+			t.Errorf("Expected event stream status to be %#v, got %#v", pbEventStream, resp.EventStream)
+		}
+	})
+
+	t.Run("Rules modifications are coalesced until ModifiedRules is called", func(t *testing.T) {
+		rule1 := models.Rule{ID: 1}
+		rule2 := models.Rule{ID: 2}
+
+		mockRuleService.EXPECT().ByID(gomock.Any(), 2).Times(2).Return(rule2, nil)
+		mockRuleService.EXPECT().ByID(gomock.Any(), 1).Times(1).Return(rule1, nil)
+		mockRuleService.EXPECT().Delete(gomock.Any(), gomock.Any()).Times(3)
+
+		for _, ruleID := range []int32{2, 1, 2} {
+			if _, err := server.DeleteRule(context.Background(), &pb.DeleteRuleRequest{RuleId: ruleID}); err != nil {
+				t.Errorf("Expected err to be nil, got %s", err)
+			}
+		}
+
+		assertRulesModified(t, server, 1, 2)
+	})
+
 	t.Run("ListenAndServe listen for grpc or http requests", func(t *testing.T) {
 		ctx, cancel := context.WithCancel(context.Background())
 		defer cancel()
@@ -0,0 +1,262 @@
+// Copyright 2020 Teserakt AG
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/spf13/cobra"
+
+	"github.com/teserakt-io/automation-engine/internal/cli"
+	"github.com/teserakt-io/automation-engine/internal/pb"
+)
+
+type addActionCommand struct {
+	cobraCmd          *cobra.Command
+	c2aeClientFactory cli.APIClientFactory
+	flags             addActionCommandFlags
+}
+
+type addActionCommandFlags struct {
+	RuleID             int32
+	Type               string
+	Settings           map[string]string
+	OnFailure          string
+	CompensateType     string
+	CompensateSettings map[string]string
+}
+
+var _ Command = &addActionCommand{}
+
+// NewAddActionCommand creates a new command to append an action on a rule
+func NewAddActionCommand(c2aeClientFactory cli.APIClientFactory) Command {
+	addActionCmd := &addActionCommand{
+		c2aeClientFactory: c2aeClientFactory,
+	}
+
+	cobraCmd := &cobra.Command{
+		Use:   "add-action",
+		Short: "Append a new action on a rule",
+		Long: `Append a new action on a rule, executed after the rule existing actions.
+When the rule only has a single action, it is converted to a list holding this action first.`,
+		RunE: addActionCmd.run,
+	}
+
+	cobraCmd.Flags().Int32Var(&addActionCmd.flags.RuleID, "rule", 0, "The ruleID to add the action on")
+	cobraCmd.Flags().StringVar(&addActionCmd.flags.Type, "type", "", "The action type")
+	cobraCmd.Flags().StringToStringVar(
+		&addActionCmd.flags.Settings,
+		"setting",
+		nil,
+		"Used to set action settings",
+	)
+	cobraCmd.Flags().StringVar(
+		&addActionCmd.flags.OnFailure,
+		"on-failure",
+		pb.ActionFailurePolicy_ABORT.String(),
+		"What to do when the action fails",
+	)
+	cobraCmd.Flags().StringVar(
+		&addActionCmd.flags.CompensateType,
+		"compensate-type",
+		"",
+		"The action type to execute when the action fails, with the COMPENSATE failure policy",
+	)
+	cobraCmd.Flags().StringToStringVar(
+		&addActionCmd.flags.CompensateSettings,
+		"compensate-setting",
+		nil,
+		"Used to set the compensating action settings",
+	)
+
+	cobraCmd.MarkFlagCustom("type", CompletionFuncNameAction)
+	cobraCmd.MarkFlagCustom("on-failure", CompletionFuncNameFailurePolicy)
+	cobraCmd.MarkFlagCustom("compensate-type", CompletionFuncNameAction)
+
+	cobraCmd.MarkFlagRequired("rule")
+	cobraCmd.MarkFlagRequired("type")
+
+	addActionCmd.cobraCmd = cobraCmd
+
+	return addActionCmd
+}
+
+func (c *addActionCommand) CobraCmd() *cobra.Command {
+	return c.cobraCmd
+}
+
+func (c *addActionCommand) run(cmd *cobra.Command, args []string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	action, ok := pb.LookupActionName(c.flags.Type)
+	if !ok {
+		return fmt.Errorf("unknown action type %s", c.flags.Type)
+	}
+
+	onFailure, ok := pb.ActionFailurePolicy_value[c.flags.OnFailure]
+	if !ok {
+		return fmt.Errorf("unknown failure policy %s", c.flags.OnFailure)
+	}
+
+	encodedSettings, err := encodeActionSettings(c.flags.Settings, action)
+	if err != nil {
+		return err
+	}
+
+	newAction := &pb.Action{
+		Type:      action.Type,
+		Settings:  encodedSettings,
+		OnFailure: pb.ActionFailurePolicy(onFailure),
+	}
+
+	if len(c.flags.CompensateType) > 0 {
+		compensateAction, ok := pb.LookupActionName(c.flags.CompensateType)
+		if !ok {
+			return fmt.Errorf("unknown compensate action type %s", c.flags.CompensateType)
+		}
+
+		encodedCompensateSettings, err := encodeActionSettings(c.flags.CompensateSettings, compensateAction)
+		if err != nil {
+			return err
+		}
+
+		newAction.CompensateType = compensateAction.Type
+		newAction.CompensateSettings = encodedCompensateSettings
+	}
+
+	client, err := c.c2aeClientFactory.NewClient(cmd)
+	if err != nil {
+		return fmt.Errorf("cannot create api client: %s", err)
+	}
+	defer client.Close()
+
+	resp, err := client.GetRule(ctx, &pb.GetRuleRequest{RuleId: c.flags.RuleID})
+	if err != nil {
+		return fmt.Errorf("cannot retrieve rule #%d: %s", c.flags.RuleID, err)
+	}
+
+	actions := resp.Rule.Actions
+	if len(actions) == 0 && resp.Rule.Action != pb.ActionType_UNDEFINED_ACTION {
+		actions = append(actions, &pb.Action{
+			Type:     resp.Rule.Action,
+			Settings: resp.Rule.ActionSettings,
+		})
+	}
+
+	// The legacy single action, when any, is moved to the actions list
+	updateReq := updateRequestFromRule(resp.Rule)
+	updateReq.Action = pb.ActionType_UNDEFINED_ACTION
+	updateReq.ActionSettings = nil
+	updateReq.Actions = append(actions, newAction)
+
+	_, err = client.UpdateRule(ctx, updateReq)
+	if err != nil {
+		return fmt.Errorf("cannot update rule #%d: %s", c.flags.RuleID, err)
+	}
+
+	fmt.Printf("New action successfully added on rule #%d\n", c.flags.RuleID)
+
+	return nil
+}
+
+// updateRequestFromRule returns a request updating the rule with its current values,
+// for commands to modify only the ones they change.
+func updateRequestFromRule(rule *pb.Rule) *pb.UpdateRuleRequest {
+	return &pb.UpdateRuleRequest{
+		RuleId:           rule.Id,
+		Description:      rule.Description,
+		Action:           rule.Action,
+		ActionSettings:   rule.ActionSettings,
+		Actions:          rule.Actions,
+		Targets:          rule.Targets,
+		Triggers:         rule.Triggers,
+		DryRun:           rule.DryRun,
+		TriggerMode:      rule.TriggerMode,
+		TriggerThreshold: rule.TriggerThreshold,
+		Cooldown:         rule.Cooldown,
+		MaxExecutions:    rule.MaxExecutions,
+		ExecutionPeriod:  rule.ExecutionPeriod,
+	}
+}
+
+// encodeActionSettings validates and encodes the user settings for given action type.
+// It returns nil when the action type doesn't have settings and none are provided.
+func encodeActionSettings(userSettings map[string]string, action pb.ActionDefinition) ([]byte, error) {
+	if action.NewSettings == nil {
+		if len(userSettings) > 0 {
+			return nil, fmt.Errorf("action %s does not support settings", action.Name)
+		}
+
+		return nil, nil
+	}
+
+	actionSettings, err := mapToActionSettings(userSettings, action.NewSettings())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := actionSettings.Validate(); err != nil {
+		return nil, fmt.Errorf("action settings validation error: %s", err)
+	}
+
+	return actionSettings.Encode()
+}
+
+// mapToActionSettings decodes the user settings into actionSettings.
+// Keys holding a dot are decoded as map entries, such as headers.Authorization=value.
+func mapToActionSettings(userSettings map[string]string, actionSettings pb.ActionSettings) (pb.ActionSettings, error) {
+	decoderConfig := &mapstructure.DecoderConfig{
+		Result:           actionSettings,
+		WeaklyTypedInput: true,
+		Metadata:         &mapstructure.Metadata{},
+	}
+
+	decoder, err := mapstructure.NewDecoder(decoderConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	input := make(map[string]interface{})
+	for key, value := range userSettings {
+		parts := strings.SplitN(key, ".", 2)
+		if len(parts) == 1 {
+			input[key] = value
+
+			continue
+		}
+
+		entries, ok := input[parts[0]].(map[string]string)
+		if !ok {
+			entries = make(map[string]string)
+			input[parts[0]] = entries
+		}
+		entries[parts[1]] = value
+	}
+
+	if err := decoder.Decode(input); err != nil {
+		return nil, err
+	}
+
+	for _, unused := range decoderConfig.Metadata.Unused {
+		fmt.Printf("WARN: setting %s is provided, but was ignored.\n", unused)
+	}
+
+	return actionSettings, nil
+}
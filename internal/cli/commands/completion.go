@@ -31,6 +31,12 @@ const (
 	CompletionFuncNameTriggerType = "__c2ae_autocomplete_trigger_types"
 	// CompletionFuncNameTargetType holds the name of the bash function used to autocomplete target type flag
 	CompletionFuncNameTargetType = "__c2ae_autocomplete_target_types"
+	// CompletionFuncNameFailurePolicy holds the name of the bash function used to autocomplete action failure policy flag
+	CompletionFuncNameFailurePolicy = "__c2ae_autocomplete_failure_policies"
+	// CompletionFuncNameTriggerSetting holds the name of the bash function used to autocomplete trigger setting flag
+	CompletionFuncNameTriggerSetting = "__c2ae_autocomplete_trigger_settings"
+	// CompletionFuncNameTriggerMode holds the name of the bash function used to autocomplete trigger mode flag
+	CompletionFuncNameTriggerMode = "__c2ae_autocomplete_trigger_modes"
 )
 
 // CompletionCommand defines a custom Command to deal with auto completion
@@ -100,13 +106,15 @@ func (c *CompletionCommand) GenerateCustomCompletionFuncs() string {
 	var out string
 
 	var actionNames []string
-	for _, name := range pb.ActionType_name {
-		actionNames = append(actionNames, name)
+	for _, action := range pb.RegisteredActions() {
+		actionNames = append(actionNames, action.Name)
 	}
 
 	var triggerTypes []string
-	for _, t := range pb.TriggerType_name {
-		triggerTypes = append(triggerTypes, t)
+	var triggerSettings []string
+	for _, trigger := range pb.RegisteredTriggers() {
+		triggerTypes = append(triggerTypes, trigger.Name)
+		triggerSettings = append(triggerSettings, trigger.SettingSuggestions...)
 	}
 
 	var targetTypes []string
@@ -114,9 +122,22 @@ func (c *CompletionCommand) GenerateCustomCompletionFuncs() string {
 		targetTypes = append(targetTypes, t)
 	}
 
+	var failurePolicies []string
+	for _, p := range pb.ActionFailurePolicy_name {
+		failurePolicies = append(failurePolicies, p)
+	}
+
+	var triggerModes []string
+	for _, m := range pb.TriggerMode_name {
+		triggerModes = append(triggerModes, m)
+	}
+
 	out += c.generateCompletionFunc(CompletionFuncNameAction, actionNames)
 	out += c.generateCompletionFunc(CompletionFuncNameTriggerType, triggerTypes)
 	out += c.generateCompletionFunc(CompletionFuncNameTargetType, targetTypes)
+	out += c.generateCompletionFunc(CompletionFuncNameFailurePolicy, failurePolicies)
+	out += c.generateCompletionFunc(CompletionFuncNameTriggerSetting, triggerSettings)
+	out += c.generateCompletionFunc(CompletionFuncNameTriggerMode, triggerModes)
 
 	return out
 }
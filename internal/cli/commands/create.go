@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/golang/protobuf/ptypes"
 	"github.com/spf13/cobra"
 
 	"github.com/teserakt-io/automation-engine/internal/cli"
@@ -32,8 +33,15 @@ type createCommand struct {
 }
 
 type createCommandFlags struct {
-	Description string
-	Action      string
+	Description     string
+	Action          string
+	Settings        map[string]string
+	DryRun          bool
+	TriggerMode     string
+	Threshold       int32
+	Cooldown        time.Duration
+	MaxExecutions   int32
+	ExecutionPeriod time.Duration
 }
 
 var _ Command = &createCommand{}
@@ -52,8 +60,18 @@ func NewCreateCommand(c2aeClientFactory cli.APIClientFactory) Command {
 
 	cobraCmd.Flags().StringVar(&createCmd.flags.Description, "description", "", "short description of the rule")
 	cobraCmd.Flags().StringVar(&createCmd.flags.Action, "action", "", "action to be performed when the rule will trigger")
+	cobraCmd.Flags().StringToStringVar(&createCmd.flags.Settings, "setting", nil, "Used to set action settings")
+	cobraCmd.Flags().BoolVar(&createCmd.flags.DryRun, "dry-run", false, "record the C2 calls the rule actions would make instead of issuing them")
+
+	cobraCmd.Flags().StringVar(&createCmd.flags.TriggerMode, "trigger-mode", pb.TriggerMode_ANY_TRIGGER.String(), "how the rule triggers are combined")
+	cobraCmd.Flags().Int32Var(&createCmd.flags.Threshold, "trigger-threshold", 0, "number of triggers which must have fired, with the THRESHOLD_TRIGGERS mode")
+
+	cobraCmd.Flags().DurationVar(&createCmd.flags.Cooldown, "cooldown", 0, "minimum duration between two executions of the rule from its triggers")
+	cobraCmd.Flags().Int32Var(&createCmd.flags.MaxExecutions, "max-executions", 0, "maximum number of executions of the rule during the execution period, unlimited when 0")
+	cobraCmd.Flags().DurationVar(&createCmd.flags.ExecutionPeriod, "execution-period", 0, "period over which the max executions are counted")
 
 	cobraCmd.MarkFlagCustom("action", CompletionFuncNameAction)
+	cobraCmd.MarkFlagCustom("trigger-mode", CompletionFuncNameTriggerMode)
 
 	cobraCmd.MarkFlagRequired("description")
 	cobraCmd.MarkFlagRequired("action")
@@ -71,14 +89,31 @@ func (c *createCommand) run(cmd *cobra.Command, args []string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
 
-	action, ok := pb.ActionType_value[c.flags.Action]
+	action, ok := pb.LookupActionName(c.flags.Action)
 	if !ok {
 		return fmt.Errorf("unknown action %s", c.flags.Action)
 	}
 
+	triggerMode, ok := pb.TriggerMode_value[c.flags.TriggerMode]
+	if !ok {
+		return fmt.Errorf("unknown trigger mode %s", c.flags.TriggerMode)
+	}
+
+	encodedSettings, err := encodeActionSettings(c.flags.Settings, action)
+	if err != nil {
+		return err
+	}
+
 	req := &pb.AddRuleRequest{
-		Description: c.flags.Description,
-		Action:      pb.ActionType(action),
+		Description:      c.flags.Description,
+		Action:           action.Type,
+		ActionSettings:   encodedSettings,
+		DryRun:           c.flags.DryRun,
+		TriggerMode:      pb.TriggerMode(triggerMode),
+		TriggerThreshold: c.flags.Threshold,
+		Cooldown:         ptypes.DurationProto(c.flags.Cooldown),
+		MaxExecutions:    c.flags.MaxExecutions,
+		ExecutionPeriod:  ptypes.DurationProto(c.flags.ExecutionPeriod),
 	}
 
 	client, err := c.c2aeClientFactory.NewClient(cmd)
@@ -0,0 +1,97 @@
+// Copyright 2020 Teserakt AG
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/teserakt-io/automation-engine/internal/cli"
+	"github.com/teserakt-io/automation-engine/internal/pb"
+)
+
+type setDryRunCommand struct {
+	cobraCmd          *cobra.Command
+	c2aeClientFactory cli.APIClientFactory
+	flags             setDryRunCommandFlags
+}
+
+type setDryRunCommandFlags struct {
+	RuleID  int32
+	Enabled bool
+}
+
+var _ Command = &setDryRunCommand{}
+
+// NewSetDryRunCommand creates a new command to enable or disable the dry-run mode of a rule
+func NewSetDryRunCommand(c2aeClientFactory cli.APIClientFactory) Command {
+	setDryRunCmd := &setDryRunCommand{
+		c2aeClientFactory: c2aeClientFactory,
+	}
+
+	cobraCmd := &cobra.Command{
+		Use:   "set-dry-run",
+		Short: "Enable or disable the dry-run mode of a rule",
+		RunE:  setDryRunCmd.run,
+	}
+
+	cobraCmd.Flags().Int32Var(&setDryRunCmd.flags.RuleID, "rule", 0, "The ruleID to update")
+	cobraCmd.Flags().BoolVar(&setDryRunCmd.flags.Enabled, "enabled", true, "whether the rule actions must only record the C2 calls they would make")
+
+	cobraCmd.MarkFlagRequired("rule")
+
+	setDryRunCmd.cobraCmd = cobraCmd
+
+	return setDryRunCmd
+}
+
+func (c *setDryRunCommand) CobraCmd() *cobra.Command {
+	return c.cobraCmd
+}
+
+func (c *setDryRunCommand) run(cmd *cobra.Command, args []string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	client, err := c.c2aeClientFactory.NewClient(cmd)
+	if err != nil {
+		return fmt.Errorf("cannot create api client: %s", err)
+	}
+	defer client.Close()
+
+	resp, err := client.GetRule(ctx, &pb.GetRuleRequest{RuleId: c.flags.RuleID})
+	if err != nil {
+		return fmt.Errorf("cannot retrieve rule #%d: %s", c.flags.RuleID, err)
+	}
+
+	updateReq := updateRequestFromRule(resp.Rule)
+	updateReq.DryRun = c.flags.Enabled
+
+	_, err = client.UpdateRule(ctx, updateReq)
+	if err != nil {
+		return fmt.Errorf("cannot update rule #%d: %s", c.flags.RuleID, err)
+	}
+
+	if c.flags.Enabled {
+		fmt.Printf("Dry-run mode enabled on rule #%d\n", c.flags.RuleID)
+	} else {
+		fmt.Printf("Dry-run mode disabled on rule #%d\n", c.flags.RuleID)
+	}
+
+	return nil
+}
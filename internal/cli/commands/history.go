@@ -0,0 +1,207 @@
+// Copyright 2020 Teserakt AG
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/spf13/cobra"
+
+	"github.com/teserakt-io/automation-engine/internal/cli"
+	"github.com/teserakt-io/automation-engine/internal/pb"
+)
+
+type historyCommand struct {
+	cobraCmd          *cobra.Command
+	c2aeClientFactory cli.APIClientFactory
+	flags             historyCommandFlags
+}
+
+type historyCommandFlags struct {
+	RuleID   int32
+	Since    string
+	Limit    int32
+	Outcomes bool
+}
+
+var _ Command = &historyCommand{}
+
+// NewHistoryCommand creates a new command to list the executions of a given rule
+func NewHistoryCommand(c2aeClientFactory cli.APIClientFactory) Command {
+	historyCmd := &historyCommand{
+		c2aeClientFactory: c2aeClientFactory,
+	}
+
+	cobraCmd := &cobra.Command{
+		Use:   "history",
+		Short: "List the most recent executions of a given rule",
+		RunE:  historyCmd.run,
+	}
+
+	cobraCmd.Flags().Int32Var(&historyCmd.flags.RuleID, "rule", 0, "The ruleID to list executions of")
+	cobraCmd.Flags().StringVar(
+		&historyCmd.flags.Since,
+		"since",
+		"",
+		"Only list executions triggered after this date (RFC3339) or duration ago (ie: 24h)",
+	)
+	cobraCmd.Flags().Int32Var(&historyCmd.flags.Limit, "limit", 0, "Maximum number of executions to list (defaults to 100)")
+	cobraCmd.Flags().BoolVar(&historyCmd.flags.Outcomes, "outcomes", false, "Also list the outcome of each action target")
+
+	cobraCmd.MarkFlagRequired("rule")
+
+	historyCmd.cobraCmd = cobraCmd
+
+	return historyCmd
+}
+
+func (c *historyCommand) CobraCmd() *cobra.Command {
+	return c.cobraCmd
+}
+
+func (c *historyCommand) run(cmd *cobra.Command, args []string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	req := &pb.ListExecutionsRequest{
+		RuleId: c.flags.RuleID,
+		Limit:  c.flags.Limit,
+	}
+
+	if len(c.flags.Since) > 0 {
+		since, err := parseSince(c.flags.Since, time.Now())
+		if err != nil {
+			return err
+		}
+
+		req.Since, err = ptypes.TimestampProto(since)
+		if err != nil {
+			return err
+		}
+	}
+
+	client, err := c.c2aeClientFactory.NewClient(cmd)
+	if err != nil {
+		return fmt.Errorf("cannot create api client: %s", err)
+	}
+	defer client.Close()
+
+	resp, err := client.ListExecutions(ctx, req)
+	if err != nil {
+		return fmt.Errorf("cannot retrieve rule #%d executions: %s", c.flags.RuleID, err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', tabwriter.Debug)
+	defer w.Flush()
+
+	if len(resp.Executions) == 0 {
+		fmt.Fprintf(w, "No executions recorded for rule #%d.\n", c.flags.RuleID)
+
+		return nil
+	}
+
+	fmt.Fprintln(w, " #ID\t Triggered at\t Trigger\t Event\t Duration\t Succeeded\t Failed\t Pending\t Error")
+	fmt.Fprintln(w, " ---\t ------------\t -------\t -----\t --------\t ---------\t ------\t -------\t -----")
+
+	for _, execution := range resp.Executions {
+		triggeredAt, err := ptypes.Timestamp(execution.TriggeredAt)
+		if err != nil {
+			return err
+		}
+
+		duration, err := ptypes.Duration(execution.Duration)
+		if err != nil {
+			return err
+		}
+
+		trigger := "manual"
+		if execution.TriggerId != 0 {
+			trigger = fmt.Sprintf("%d", execution.TriggerId)
+		}
+		if execution.DryRun {
+			trigger += " (dry run)"
+		}
+
+		event := "-"
+		if execution.Event != nil {
+			event = fmt.Sprintf("%s %s -> %s", execution.Event.Type, execution.Event.Source, execution.Event.Target)
+		}
+
+		var failed, pending int
+		for _, outcome := range execution.Outcomes {
+			switch {
+			case len(outcome.Error) > 0:
+				failed++
+			case outcome.Pending:
+				pending++
+			}
+		}
+
+		fmt.Fprintf(
+			w,
+			" %d\t %s\t %s\t %s\t %s\t %d\t %d\t %d\t %s\n",
+			execution.Id,
+			triggeredAt.Local().Format(time.RFC3339),
+			trigger,
+			event,
+			duration,
+			len(execution.Outcomes)-failed-pending,
+			failed,
+			pending,
+			execution.Error,
+		)
+
+		if c.flags.Outcomes {
+			for _, outcome := range execution.Outcomes {
+				fmt.Fprintf(w, " \t \t \t \t \t \t \t \t   %s %s %s: %s\n", outcome.Action, outcome.TargetType, outcome.Target, outcomeStatus(outcome))
+			}
+		}
+	}
+
+	return nil
+}
+
+// outcomeStatus describes the result of an execution outcome
+func outcomeStatus(outcome *pb.ExecutionOutcome) string {
+	switch {
+	case len(outcome.Error) > 0:
+		return outcome.Error
+	case outcome.Pending:
+		return "PENDING"
+	case len(outcome.DryRunCalls) > 0:
+		return "DRY RUN " + outcome.DryRunCalls
+	default:
+		return "OK"
+	}
+}
+
+// parseSince parses value either as an RFC3339 date, or as a duration before now
+func parseSince(value string, now time.Time) (time.Time, error) {
+	if since, err := time.Parse(time.RFC3339, value); err == nil {
+		return since, nil
+	}
+
+	ago, err := time.ParseDuration(value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid since value %q, expected an RFC3339 date or a duration", value)
+	}
+
+	return now.Add(-ago), nil
+}
@@ -82,8 +82,8 @@ func (c *listCommand) run(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	fmt.Fprintln(w, " #ID\t Description\t Triggers\t Targets\t Last executed")
-	fmt.Fprintln(w, " ---\t -----------\t --------\t -------\t -------------")
+	fmt.Fprintln(w, " #ID\t Description\t State\t Triggers\t Targets\t Last executed")
+	fmt.Fprintln(w, " ---\t -----------\t -----\t --------\t -------\t -------------")
 
 	for _, rule := range resp.Rules {
 		t, err := ptypes.Timestamp(rule.LastExecuted)
@@ -91,11 +91,20 @@ func (c *listCommand) run(cmd *cobra.Command, args []string) error {
 			log.Fatal(err)
 		}
 
+		state := "enabled"
+		if !rule.Enabled {
+			state = "paused"
+		}
+		if rule.DryRun {
+			state += ", dry run"
+		}
+
 		fmt.Fprintf(
 			w,
-			" %d\t %s\t %d\t %d\t %s\n",
+			" %d\t %s\t %s\t %d\t %d\t %s\n",
 			rule.Id,
 			rule.Description,
+			state,
 			len(rule.Triggers),
 			len(rule.Targets),
 			elapsed.Time(t),
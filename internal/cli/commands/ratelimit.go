@@ -0,0 +1,100 @@
+// Copyright 2020 Teserakt AG
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/spf13/cobra"
+
+	"github.com/teserakt-io/automation-engine/internal/cli"
+	"github.com/teserakt-io/automation-engine/internal/pb"
+)
+
+type setRateLimitCommand struct {
+	cobraCmd          *cobra.Command
+	c2aeClientFactory cli.APIClientFactory
+	flags             setRateLimitCommandFlags
+}
+
+type setRateLimitCommandFlags struct {
+	RuleID          int32
+	Cooldown        time.Duration
+	MaxExecutions   int32
+	ExecutionPeriod time.Duration
+}
+
+var _ Command = &setRateLimitCommand{}
+
+// NewSetRateLimitCommand creates a new command to change the cooldown and executions budget of a rule
+func NewSetRateLimitCommand(c2aeClientFactory cli.APIClientFactory) Command {
+	setRateLimitCmd := &setRateLimitCommand{
+		c2aeClientFactory: c2aeClientFactory,
+	}
+
+	cobraCmd := &cobra.Command{
+		Use:   "set-rate-limit",
+		Short: "Change the cooldown and executions budget of a rule",
+		RunE:  setRateLimitCmd.run,
+	}
+
+	cobraCmd.Flags().Int32Var(&setRateLimitCmd.flags.RuleID, "rule", 0, "The ruleID to update")
+	cobraCmd.Flags().DurationVar(&setRateLimitCmd.flags.Cooldown, "cooldown", 0, "minimum duration between two executions of the rule from its triggers")
+	cobraCmd.Flags().Int32Var(&setRateLimitCmd.flags.MaxExecutions, "max-executions", 0, "maximum number of executions of the rule during the execution period, unlimited when 0")
+	cobraCmd.Flags().DurationVar(&setRateLimitCmd.flags.ExecutionPeriod, "execution-period", 0, "period over which the max executions are counted")
+
+	cobraCmd.MarkFlagRequired("rule")
+
+	setRateLimitCmd.cobraCmd = cobraCmd
+
+	return setRateLimitCmd
+}
+
+func (c *setRateLimitCommand) CobraCmd() *cobra.Command {
+	return c.cobraCmd
+}
+
+func (c *setRateLimitCommand) run(cmd *cobra.Command, args []string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	client, err := c.c2aeClientFactory.NewClient(cmd)
+	if err != nil {
+		return fmt.Errorf("cannot create api client: %s", err)
+	}
+	defer client.Close()
+
+	resp, err := client.GetRule(ctx, &pb.GetRuleRequest{RuleId: c.flags.RuleID})
+	if err != nil {
+		return fmt.Errorf("cannot retrieve rule #%d: %s", c.flags.RuleID, err)
+	}
+
+	updateReq := updateRequestFromRule(resp.Rule)
+	updateReq.Cooldown = ptypes.DurationProto(c.flags.Cooldown)
+	updateReq.MaxExecutions = c.flags.MaxExecutions
+	updateReq.ExecutionPeriod = ptypes.DurationProto(c.flags.ExecutionPeriod)
+
+	_, err = client.UpdateRule(ctx, updateReq)
+	if err != nil {
+		return fmt.Errorf("cannot update rule #%d: %s", c.flags.RuleID, err)
+	}
+
+	fmt.Printf("Rate limit of rule #%d updated\n", c.flags.RuleID)
+
+	return nil
+}
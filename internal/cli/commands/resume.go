@@ -0,0 +1,87 @@
+// Copyright 2020 Teserakt AG
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/teserakt-io/automation-engine/internal/cli"
+	"github.com/teserakt-io/automation-engine/internal/pb"
+)
+
+type resumeCommand struct {
+	cobraCmd          *cobra.Command
+	c2aeClientFactory cli.APIClientFactory
+	flags             resumeCommandFlags
+}
+
+type resumeCommandFlags struct {
+	RuleID int32
+}
+
+var _ Command = &resumeCommand{}
+
+// NewResumeCommand creates a new command to resume rules
+func NewResumeCommand(c2aeClientFactory cli.APIClientFactory) Command {
+	resumeCmd := &resumeCommand{
+		c2aeClientFactory: c2aeClientFactory,
+	}
+
+	cobraCmd := &cobra.Command{
+		Use:   "resume",
+		Short: "resume a paused rule",
+		RunE:  resumeCmd.run,
+	}
+
+	cobraCmd.Flags().Int32Var(&resumeCmd.flags.RuleID, "rule", 0, "The ruleID to resume")
+
+	cobraCmd.MarkFlagRequired("rule")
+
+	resumeCmd.cobraCmd = cobraCmd
+
+	return resumeCmd
+}
+
+func (c *resumeCommand) CobraCmd() *cobra.Command {
+	return c.cobraCmd
+}
+
+func (c *resumeCommand) run(cmd *cobra.Command, args []string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	client, err := c.c2aeClientFactory.NewClient(cmd)
+	if err != nil {
+		return fmt.Errorf("cannot create api client: %s", err)
+	}
+	defer client.Close()
+
+	req := &pb.ResumeRuleRequest{
+		RuleId: c.flags.RuleID,
+	}
+
+	resp, err := client.ResumeRule(ctx, req)
+	if err != nil {
+		return fmt.Errorf("cannot resume rule #%d: %s", c.flags.RuleID, err)
+	}
+
+	fmt.Printf("Rule #%d resumed!\n", resp.Rule.Id)
+
+	return nil
+}
@@ -45,8 +45,17 @@ func NewRootCommand(c2aeClientFactory cli.APIClientFactory, version string) Comm
 	createCmd := NewCreateCommand(c2aeClientFactory)
 	addTriggerCmd := NewAddTriggerCommand(c2aeClientFactory)
 	addTargetCmd := NewAddTargetCommand(c2aeClientFactory)
+	addActionCmd := NewAddActionCommand(c2aeClientFactory)
 	showCmd := NewShowCommand(c2aeClientFactory)
 	deleteCmd := NewDeleteCommand(c2aeClientFactory)
+	pauseCmd := NewPauseCommand(c2aeClientFactory)
+	resumeCmd := NewResumeCommand(c2aeClientFactory)
+	runCmd := NewRunCommand(c2aeClientFactory)
+	setDryRunCmd := NewSetDryRunCommand(c2aeClientFactory)
+	setTriggerModeCmd := NewSetTriggerModeCommand(c2aeClientFactory)
+	setRateLimitCmd := NewSetRateLimitCommand(c2aeClientFactory)
+	historyCmd := NewHistoryCommand(c2aeClientFactory)
+	statusCmd := NewStatusCommand(c2aeClientFactory)
 
 	completionCmd := NewCompletionCommand(rootCmd)
 
@@ -77,8 +86,17 @@ func NewRootCommand(c2aeClientFactory cli.APIClientFactory, version string) Comm
 		createCmd.CobraCmd(),
 		addTriggerCmd.CobraCmd(),
 		addTargetCmd.CobraCmd(),
+		addActionCmd.CobraCmd(),
 		showCmd.CobraCmd(),
 		deleteCmd.CobraCmd(),
+		pauseCmd.CobraCmd(),
+		resumeCmd.CobraCmd(),
+		runCmd.CobraCmd(),
+		setDryRunCmd.CobraCmd(),
+		setTriggerModeCmd.CobraCmd(),
+		setRateLimitCmd.CobraCmd(),
+		historyCmd.CobraCmd(),
+		statusCmd.CobraCmd(),
 
 		// Autocompletion script generation command
 		completionCmd.CobraCmd(),
@@ -0,0 +1,112 @@
+// Copyright 2020 Teserakt AG
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/spf13/cobra"
+
+	"github.com/teserakt-io/automation-engine/internal/cli"
+	"github.com/teserakt-io/automation-engine/internal/pb"
+)
+
+type runCommand struct {
+	cobraCmd          *cobra.Command
+	c2aeClientFactory cli.APIClientFactory
+	flags             runCommandFlags
+}
+
+type runCommandFlags struct {
+	RuleID  int32
+	DryRun  bool
+	Timeout time.Duration
+}
+
+var _ Command = &runCommand{}
+
+// NewRunCommand creates a new command to execute a rule immediately
+func NewRunCommand(c2aeClientFactory cli.APIClientFactory) Command {
+	runCmd := &runCommand{
+		c2aeClientFactory: c2aeClientFactory,
+	}
+
+	cobraCmd := &cobra.Command{
+		Use:   "run",
+		Short: "execute a rule action now, on the rule targets",
+		RunE:  runCmd.run,
+	}
+
+	cobraCmd.Flags().Int32Var(&runCmd.flags.RuleID, "rule", 0, "The ruleID to execute")
+	cobraCmd.Flags().BoolVar(&runCmd.flags.DryRun, "dry-run", false, "Record the C2 calls the rule actions would make instead of issuing them")
+	cobraCmd.Flags().DurationVar(&runCmd.flags.Timeout, "timeout", 30*time.Second, "How long to wait for the rule execution")
+
+	cobraCmd.MarkFlagRequired("rule")
+
+	runCmd.cobraCmd = cobraCmd
+
+	return runCmd
+}
+
+func (c *runCommand) CobraCmd() *cobra.Command {
+	return c.cobraCmd
+}
+
+func (c *runCommand) run(cmd *cobra.Command, args []string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.flags.Timeout)
+	defer cancel()
+
+	client, err := c.c2aeClientFactory.NewClient(cmd)
+	if err != nil {
+		return fmt.Errorf("cannot create api client: %s", err)
+	}
+	defer client.Close()
+
+	req := &pb.ExecuteRuleRequest{
+		RuleId: c.flags.RuleID,
+		DryRun: c.flags.DryRun,
+	}
+
+	resp, err := client.ExecuteRule(ctx, req)
+	if err != nil {
+		return fmt.Errorf("cannot execute rule #%d: %s", c.flags.RuleID, err)
+	}
+
+	execution := resp.Execution
+
+	duration, err := ptypes.Duration(execution.Duration)
+	if err != nil {
+		return err
+	}
+
+	mode := ""
+	if execution.DryRun {
+		mode = " (dry run)"
+	}
+
+	fmt.Printf("Rule #%d executed in %s%s\n", execution.RuleId, duration, mode)
+	for _, outcome := range execution.Outcomes {
+		fmt.Printf("  %s %s %s: %s\n", outcome.Action, outcome.TargetType, outcome.Target, outcomeStatus(outcome))
+	}
+
+	if len(execution.Error) > 0 {
+		return fmt.Errorf("rule #%d execution failed: %s", execution.RuleId, execution.Error)
+	}
+
+	return nil
+}
@@ -19,8 +19,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"text/tabwriter"
 	"time"
 
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/spf13/cobra"
 
 	"github.com/teserakt-io/automation-engine/internal/cli"
@@ -47,7 +50,7 @@ func NewShowCommand(c2aeClientFactory cli.APIClientFactory) Command {
 
 	cobraCmd := &cobra.Command{
 		Use:   "show",
-		Short: "Show a given rule",
+		Short: "Show a given rule, and its state in the automation engine",
 		RunE:  showCmd.run,
 	}
 
@@ -86,5 +89,84 @@ func (c *showCommand) run(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("cannot json encode rule: %s", err)
 	}
 
+	statusResp, err := client.GetRuleStatus(ctx, &pb.GetRuleStatusRequest{RuleId: c.flags.RuleID})
+	if err != nil {
+		return fmt.Errorf("cannot retrieve rule #%d status: %s", c.flags.RuleID, err)
+	}
+
+	return printRuleStatus(statusResp.Status)
+}
+
+// printRuleStatus prints the state of a rule and its triggers, along with their last errors
+func printRuleStatus(status *pb.RuleStatus) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', tabwriter.Debug)
+	defer w.Flush()
+
+	lastErrorTime, err := formatStatusTime(status.LastErrorTime)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "\nStatus: %s\n", status.State)
+	if status.ErrorCount > 0 {
+		fmt.Fprintf(w, "Errors: %d, last at %s: %s\n", status.ErrorCount, lastErrorTime, status.LastError)
+	}
+
+	if len(status.Triggers) == 0 {
+		return nil
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, " Trigger\t State\t Last triggered\t Next fire\t Counter\t Errors\t Last error at\t Last error")
+	fmt.Fprintln(w, " -------\t -----\t --------------\t ---------\t -------\t ------\t -------------\t ----------")
+
+	for _, triggerStatus := range status.Triggers {
+		lastTriggered, err := formatStatusTime(triggerStatus.LastTriggered)
+		if err != nil {
+			return err
+		}
+
+		nextFire, err := formatStatusTime(triggerStatus.NextFire)
+		if err != nil {
+			return err
+		}
+
+		lastErrorTime, err := formatStatusTime(triggerStatus.LastErrorTime)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(
+			w,
+			" %d\t %s\t %s\t %s\t %d\t %d\t %s\t %s\n",
+			triggerStatus.TriggerId,
+			triggerStatus.State,
+			lastTriggered,
+			nextFire,
+			triggerStatus.Counter,
+			triggerStatus.ErrorCount,
+			lastErrorTime,
+			triggerStatus.LastError,
+		)
+	}
+
 	return nil
 }
+
+// formatStatusTime formats ts as a local RFC3339 date, or - when it is not set
+func formatStatusTime(ts *timestamp.Timestamp) (string, error) {
+	if ts == nil {
+		return "-", nil
+	}
+
+	t, err := ptypes.Timestamp(ts)
+	if err != nil {
+		return "", err
+	}
+
+	if t.IsZero() {
+		return "-", nil
+	}
+
+	return t.Local().Format(time.RFC3339), nil
+}
@@ -0,0 +1,169 @@
+// Copyright 2020 Teserakt AG
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/teserakt-io/automation-engine/internal/cli"
+	"github.com/teserakt-io/automation-engine/internal/pb"
+)
+
+type statusCommand struct {
+	cobraCmd          *cobra.Command
+	c2aeClientFactory cli.APIClientFactory
+}
+
+var _ Command = &statusCommand{}
+
+// NewStatusCommand creates a new command to display the automation engine status
+func NewStatusCommand(c2aeClientFactory cli.APIClientFactory) Command {
+	statusCmd := &statusCommand{
+		c2aeClientFactory: c2aeClientFactory,
+	}
+
+	cobraCmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show the state of the automation engine, its rules and event stream",
+		RunE:  statusCmd.run,
+	}
+
+	statusCmd.cobraCmd = cobraCmd
+
+	return statusCmd
+}
+
+func (c *statusCommand) CobraCmd() *cobra.Command {
+	return c.cobraCmd
+}
+
+func (c *statusCommand) run(cmd *cobra.Command, args []string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	client, err := c.c2aeClientFactory.NewClient(cmd)
+	if err != nil {
+		return fmt.Errorf("cannot create api client: %s", err)
+	}
+	defer client.Close()
+
+	resp, err := client.GetEngineStatus(ctx, &pb.GetEngineStatusRequest{})
+	if err != nil {
+		return fmt.Errorf("api client error: %s", err)
+	}
+
+	if err := printEventStreamStatus(resp.EventStream); err != nil {
+		return err
+	}
+
+	fmt.Println()
+
+	return printRuleStatuses(resp.Rules)
+}
+
+func printEventStreamStatus(status *pb.EventStreamStatus) error {
+	if status == nil {
+		status = &pb.EventStreamStatus{}
+	}
+
+	since, err := formatStatusTime(status.Since)
+	if err != nil {
+		return err
+	}
+
+	state := "disconnected"
+	if status.Connected {
+		state = "connected"
+	}
+
+	fmt.Printf("Event stream: %s since %s\n", state, since)
+	fmt.Printf("Listeners: %d\n", status.Listeners)
+
+	if len(status.LastError) > 0 {
+		fmt.Printf("Last error: %s\n", status.LastError)
+	}
+
+	if status.LastEvent == nil {
+		fmt.Println("Last event: -")
+
+		return nil
+	}
+
+	received, err := formatStatusTime(status.LastEventReceived)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf(
+		"Last event: %s from %s to %s, received at %s\n",
+		status.LastEvent.Type,
+		status.LastEvent.Source,
+		status.LastEvent.Target,
+		received,
+	)
+
+	return nil
+}
+
+func printRuleStatuses(statuses []*pb.RuleStatus) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', tabwriter.Debug)
+	defer w.Flush()
+
+	if len(statuses) == 0 {
+		fmt.Fprintln(w, "No rules are watched yet.")
+
+		return nil
+	}
+
+	fmt.Fprintln(w, " Rule\t Trigger\t State\t Next fire\t Counter\t Errors\t Last error")
+	fmt.Fprintln(w, " ----\t -------\t -----\t ---------\t -------\t ------\t ----------")
+
+	for _, status := range statuses {
+		fmt.Fprintf(
+			w,
+			" %d\t -\t %s\t -\t -\t %d\t %s\n",
+			status.RuleId,
+			status.State,
+			status.ErrorCount,
+			status.LastError,
+		)
+
+		for _, triggerStatus := range status.Triggers {
+			nextFire, err := formatStatusTime(triggerStatus.NextFire)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(
+				w,
+				" \t %d\t %s\t %s\t %d\t %d\t %s\n",
+				triggerStatus.TriggerId,
+				triggerStatus.State,
+				nextFire,
+				triggerStatus.Counter,
+				triggerStatus.ErrorCount,
+				triggerStatus.LastError,
+			)
+		}
+	}
+
+	return nil
+}
@@ -106,13 +106,8 @@ func (c *addTargetCommand) run(cmd *cobra.Command, args []string) error {
 		Expr: c.flags.Expr,
 	}
 
-	updateReq := &pb.UpdateRuleRequest{
-		RuleId:      c.flags.RuleID,
-		Action:      resp.Rule.Action,
-		Description: resp.Rule.Description,
-		Targets:     append(resp.Rule.Targets, target),
-		Triggers:    resp.Rule.Triggers,
-	}
+	updateReq := updateRequestFromRule(resp.Rule)
+	updateReq.Targets = append(resp.Rule.Targets, target)
 
 	_, err = client.UpdateRule(ctx, updateReq)
 	if err != nil {
@@ -0,0 +1,104 @@
+// Copyright 2020 Teserakt AG
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/teserakt-io/automation-engine/internal/cli"
+	"github.com/teserakt-io/automation-engine/internal/pb"
+)
+
+type setTriggerModeCommand struct {
+	cobraCmd          *cobra.Command
+	c2aeClientFactory cli.APIClientFactory
+	flags             setTriggerModeCommandFlags
+}
+
+type setTriggerModeCommandFlags struct {
+	RuleID    int32
+	Mode      string
+	Threshold int32
+}
+
+var _ Command = &setTriggerModeCommand{}
+
+// NewSetTriggerModeCommand creates a new command to change how the triggers of a rule are combined
+func NewSetTriggerModeCommand(c2aeClientFactory cli.APIClientFactory) Command {
+	setTriggerModeCmd := &setTriggerModeCommand{
+		c2aeClientFactory: c2aeClientFactory,
+	}
+
+	cobraCmd := &cobra.Command{
+		Use:   "set-trigger-mode",
+		Short: "Change how the triggers of a rule are combined",
+		RunE:  setTriggerModeCmd.run,
+	}
+
+	cobraCmd.Flags().Int32Var(&setTriggerModeCmd.flags.RuleID, "rule", 0, "The ruleID to update")
+	cobraCmd.Flags().StringVar(&setTriggerModeCmd.flags.Mode, "mode", "", "how the rule triggers are combined")
+	cobraCmd.Flags().Int32Var(&setTriggerModeCmd.flags.Threshold, "threshold", 0, "number of triggers which must have fired, with the THRESHOLD_TRIGGERS mode")
+
+	cobraCmd.MarkFlagCustom("mode", CompletionFuncNameTriggerMode)
+
+	cobraCmd.MarkFlagRequired("rule")
+	cobraCmd.MarkFlagRequired("mode")
+
+	setTriggerModeCmd.cobraCmd = cobraCmd
+
+	return setTriggerModeCmd
+}
+
+func (c *setTriggerModeCommand) CobraCmd() *cobra.Command {
+	return c.cobraCmd
+}
+
+func (c *setTriggerModeCommand) run(cmd *cobra.Command, args []string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	mode, ok := pb.TriggerMode_value[c.flags.Mode]
+	if !ok {
+		return fmt.Errorf("unknown trigger mode %s", c.flags.Mode)
+	}
+
+	client, err := c.c2aeClientFactory.NewClient(cmd)
+	if err != nil {
+		return fmt.Errorf("cannot create api client: %s", err)
+	}
+	defer client.Close()
+
+	resp, err := client.GetRule(ctx, &pb.GetRuleRequest{RuleId: c.flags.RuleID})
+	if err != nil {
+		return fmt.Errorf("cannot retrieve rule #%d: %s", c.flags.RuleID, err)
+	}
+
+	updateReq := updateRequestFromRule(resp.Rule)
+	updateReq.TriggerMode = pb.TriggerMode(mode)
+	updateReq.TriggerThreshold = c.flags.Threshold
+
+	_, err = client.UpdateRule(ctx, updateReq)
+	if err != nil {
+		return fmt.Errorf("cannot update rule #%d: %s", c.flags.RuleID, err)
+	}
+
+	fmt.Printf("Trigger mode of rule #%d set to %s\n", c.flags.RuleID, pb.TriggerMode(mode))
+
+	return nil
+}
@@ -16,7 +16,10 @@ package commands
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"reflect"
+	"strings"
 	"time"
 
 	"github.com/mitchellh/mapstructure"
@@ -58,10 +61,11 @@ func NewAddTriggerCommand(c2aeClientFactory cli.APIClientFactory) Command {
 		&addTriggerCmd.flags.Settings,
 		"setting",
 		nil,
-		"Used to set trigger settings",
+		"Used to set trigger settings, list settings accepting comma separated values",
 	)
 
 	cobraCmd.MarkFlagCustom("type", CompletionFuncNameTriggerType)
+	cobraCmd.MarkFlagCustom("setting", CompletionFuncNameTriggerSetting)
 
 	cobraCmd.MarkFlagRequired("rule")
 	cobraCmd.MarkFlagRequired("type")
@@ -80,7 +84,7 @@ func (c *addTriggerCommand) run(cmd *cobra.Command, args []string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
 
-	triggerType, ok := pb.TriggerType_value[c.flags.Type]
+	trigger, ok := pb.LookupTriggerName(c.flags.Type)
 	if !ok {
 		return fmt.Errorf("unknown trigger type %s", c.flags.Type)
 	}
@@ -96,7 +100,7 @@ func (c *addTriggerCommand) run(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("cannot retrieve rule #%d: %s", c.flags.RuleID, err)
 	}
 
-	triggerSettings, err := mapToTriggerSettings(c.flags.Settings, pb.TriggerType(triggerType))
+	triggerSettings, err := mapToTriggerSettings(c.flags.Settings, trigger.NewSettings())
 	if err != nil {
 		return err
 	}
@@ -111,17 +115,12 @@ func (c *addTriggerCommand) run(cmd *cobra.Command, args []string) error {
 	}
 
 	newTrigger := &pb.Trigger{
-		Type:     pb.TriggerType(triggerType),
+		Type:     trigger.Type,
 		Settings: encodedSettings,
 	}
 
-	updateReq := &pb.UpdateRuleRequest{
-		RuleId:      c.flags.RuleID,
-		Action:      resp.Rule.Action,
-		Description: resp.Rule.Description,
-		Targets:     resp.Rule.Targets,
-		Triggers:    append(resp.Rule.Triggers, newTrigger),
-	}
+	updateReq := updateRequestFromRule(resp.Rule)
+	updateReq.Triggers = append(resp.Rule.Triggers, newTrigger)
 
 	_, err = client.UpdateRule(ctx, updateReq)
 	if err != nil {
@@ -133,23 +132,20 @@ func (c *addTriggerCommand) run(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func mapToTriggerSettings(userSettings map[string]string, triggerType pb.TriggerType) (pb.TriggerSettings, error) {
-	var decoderConfig *mapstructure.DecoderConfig
-
-	switch triggerType {
-	case pb.TriggerType_TIME_INTERVAL:
-		decoderConfig = &mapstructure.DecoderConfig{
-			Result: &pb.TriggerSettingsTimeInterval{},
-		}
-	case pb.TriggerType_EVENT:
-		decoderConfig = &mapstructure.DecoderConfig{
-			Result: &pb.TriggerSettingsEvent{},
-		}
+// mapToTriggerSettings decodes the user settings into triggerSettings
+func mapToTriggerSettings(userSettings map[string]string, triggerSettings pb.TriggerSettings) (pb.TriggerSettings, error) {
+	decoderConfig := &mapstructure.DecoderConfig{
+		Result:           triggerSettings,
+		WeaklyTypedInput: true,
+		Metadata:         &mapstructure.Metadata{},
+		// Structured settings, such as event filters, are given as JSON,
+		// and list settings, such as eventTypes, as comma separated values
+		DecodeHook: mapstructure.ComposeDecodeHookFunc(
+			jsonSettingHookFunc,
+			mapstructure.StringToSliceHookFunc(","),
+		),
 	}
 
-	decoderConfig.WeaklyTypedInput = true
-	decoderConfig.Metadata = &mapstructure.Metadata{}
-
 	decoder, err := mapstructure.NewDecoder(decoderConfig)
 	if err != nil {
 		return nil, err
@@ -163,5 +159,24 @@ func mapToTriggerSettings(userSettings map[string]string, triggerType pb.Trigger
 		fmt.Printf("WARN: setting %s is provided, but was ignored.\n", unused)
 	}
 
-	return decoderConfig.Result.(pb.TriggerSettings), nil
+	return triggerSettings, nil
+}
+
+// jsonSettingHookFunc decodes a setting value starting as a JSON array or object
+func jsonSettingHookFunc(from reflect.Type, to reflect.Type, data interface{}) (interface{}, error) {
+	if from.Kind() != reflect.String {
+		return data, nil
+	}
+
+	value := strings.TrimSpace(data.(string))
+	if !strings.HasPrefix(value, "[") && !strings.HasPrefix(value, "{") {
+		return data, nil
+	}
+
+	var decoded interface{}
+	if err := json.Unmarshal([]byte(value), &decoded); err != nil {
+		return nil, fmt.Errorf("invalid JSON setting value %s: %v", value, err)
+	}
+
+	return decoded, nil
 }
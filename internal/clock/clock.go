@@ -0,0 +1,164 @@
+// Copyright 2020 Teserakt AG
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clock
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock defines methods to read the current time and to wait for durations.
+// It allows time dependent components to run on a fake time in tests.
+type Clock interface {
+	Now() time.Time
+	After(time.Duration) <-chan time.Time
+}
+
+type realClock struct{}
+
+var _ Clock = realClock{}
+
+// New creates a Clock using the system time
+func New() Clock {
+	return realClock{}
+}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// Fake is a Clock whose time only changes when it is set or advanced, firing the
+// channels returned by After once their duration has elapsed on the fake time.
+type Fake struct {
+	lock    sync.Mutex
+	cond    *sync.Cond
+	now     time.Time
+	waiters []waiter
+}
+
+type waiter struct {
+	until time.Time
+	c     chan time.Time
+}
+
+var _ Clock = &Fake{}
+
+// NewFake creates a new Fake clock set to now
+func NewFake(now time.Time) *Fake {
+	f := &Fake{now: now}
+	f.cond = sync.NewCond(&f.lock)
+
+	return f
+}
+
+// Now returns the fake current time
+func (f *Fake) Now() time.Time {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	return f.now
+}
+
+// After returns a channel receiving the fake current time once it has been advanced by d
+func (f *Fake) After(d time.Duration) <-chan time.Time {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	c := make(chan time.Time, 1)
+	if d <= 0 {
+		c <- f.now
+
+		return c
+	}
+
+	f.waiters = append(f.waiters, waiter{until: f.now.Add(d), c: c})
+	f.cond.Broadcast()
+
+	return c
+}
+
+// Advance moves the fake current time forward by d
+func (f *Fake) Advance(d time.Duration) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.set(f.now.Add(d))
+}
+
+// Set changes the fake current time to now
+func (f *Fake) Set(now time.Time) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.set(now)
+}
+
+// NextWaiter returns the earliest time a channel returned by After is waiting for,
+// and false when none are waiting.
+func (f *Fake) NextWaiter() (time.Time, bool) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if len(f.waiters) == 0 {
+		return time.Time{}, false
+	}
+
+	next := f.waiters[0].until
+	for _, w := range f.waiters[1:] {
+		if w.until.Before(next) {
+			next = w.until
+		}
+	}
+
+	return next, true
+}
+
+// BlockUntil blocks until at least n channels returned by After are waiting
+func (f *Fake) BlockUntil(n int) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	for len(f.waiters) < n {
+		f.cond.Wait()
+	}
+}
+
+// set must be called with the lock held
+func (f *Fake) set(now time.Time) {
+	f.now = now
+
+	sort.SliceStable(f.waiters, func(i, j int) bool {
+		return f.waiters[i].until.Before(f.waiters[j].until)
+	})
+
+	var remaining []waiter
+	for _, w := range f.waiters {
+		if w.until.After(now) {
+			remaining = append(remaining, w)
+
+			continue
+		}
+
+		w.c <- now
+	}
+
+	f.waiters = remaining
+	f.cond.Broadcast()
+}
@@ -0,0 +1,105 @@
+// Copyright 2020 Teserakt AG
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFake(t *testing.T) {
+	start := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Now returns the fake time", func(t *testing.T) {
+		f := NewFake(start)
+		if now := f.Now(); !now.Equal(start) {
+			t.Errorf("Expected now to be %v, got %v", start, now)
+		}
+
+		f.Advance(time.Hour)
+		if now := f.Now(); !now.Equal(start.Add(time.Hour)) {
+			t.Errorf("Expected now to be %v, got %v", start.Add(time.Hour), now)
+		}
+
+		f.Set(start)
+		if now := f.Now(); !now.Equal(start) {
+			t.Errorf("Expected now to be %v, got %v", start, now)
+		}
+	})
+
+	t.Run("After fires once the fake time is advanced", func(t *testing.T) {
+		f := NewFake(start)
+
+		c := f.After(time.Minute)
+		if next, ok := f.NextWaiter(); !ok || !next.Equal(start.Add(time.Minute)) {
+			t.Errorf("Expected next waiter to be %v, got %v, %v", start.Add(time.Minute), next, ok)
+		}
+
+		f.Advance(30 * time.Second)
+		select {
+		case <-c:
+			t.Errorf("Expected After to not fire before its duration elapsed")
+		default:
+		}
+
+		f.Advance(30 * time.Second)
+		select {
+		case now := <-c:
+			if !now.Equal(start.Add(time.Minute)) {
+				t.Errorf("Expected After to receive %v, got %v", start.Add(time.Minute), now)
+			}
+		default:
+			t.Errorf("Expected After to fire once its duration elapsed")
+		}
+
+		if _, ok := f.NextWaiter(); ok {
+			t.Errorf("Expected no more waiters")
+		}
+	})
+
+	t.Run("After fires immediately on non positive durations", func(t *testing.T) {
+		f := NewFake(start)
+
+		select {
+		case <-f.After(0):
+		default:
+			t.Errorf("Expected After to fire immediately")
+		}
+	})
+
+	t.Run("BlockUntil waits for After calls", func(t *testing.T) {
+		f := NewFake(start)
+
+		done := make(chan struct{})
+		go func() {
+			f.BlockUntil(2)
+			close(done)
+		}()
+
+		f.After(time.Minute)
+		select {
+		case <-done:
+			t.Fatalf("Expected BlockUntil to wait for a second waiter")
+		case <-time.After(10 * time.Millisecond):
+		}
+
+		f.After(time.Hour)
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Errorf("Expected BlockUntil to return")
+		}
+	})
+}
@@ -27,6 +27,7 @@ import (
 type API struct {
 	Server              ServerCfg
 	DB                  DBCfg
+	Engine              EngineCfg
 	C2Endpoint          string
 	C2Certificate       string
 	OpencensusSampleAll bool
@@ -45,6 +46,23 @@ type ServerCfg struct {
 	HTTPKey      string
 }
 
+// EngineCfg holds configuration for the automation engine
+type EngineCfg struct {
+	ActionMaxTargets int
+	// ResumePolicy defines how TIME_INTERVAL triggers handle the runs missed while their rule was paused
+	ResumePolicy string
+	// DryRun forces every rule action to record the C2 calls it would make instead of issuing them
+	DryRun bool
+}
+
+// List of available resume policies
+const (
+	// ResumePolicyFireOnce executes the rule once on resume when some runs have been missed
+	ResumePolicyFireOnce = "fire_once"
+	// ResumePolicySkip ignores the runs missed while the rule was paused
+	ResumePolicySkip = "skip"
+)
+
 // DBCfg holds configuration for databases
 type DBCfg struct {
 	Logging          bool
@@ -81,6 +99,8 @@ var (
 	ErrHTTPCertRequired        = errors.New("http certificate path is required")
 	ErrHTTPKeyRequired         = errors.New("http key path is required")
 	ErrHTTPGRPCAddrRequired    = errors.New("http-grpc address is required")
+	ErrInvalidActionMaxTargets = errors.New("action max targets must be positive, or 0 to disable the limit")
+	ErrInvalidResumePolicy     = errors.New("resume policy must be one of fire_once or skip")
 )
 
 // NewAPI creates a new configuration struct for the C2AE api
@@ -113,6 +133,10 @@ func (c *API) ViperCfgFields() []slibcfg.ViperCfgField {
 		{&c.C2Endpoint, "c2-host-port", slibcfg.ViperString, "localhost:5555", "C2AE_C2_ENDPOINT"},
 		{&c.C2Certificate, "c2-cert", slibcfg.ViperRelativePath, "", "C2AE_C2CERT_PATH"},
 
+		{&c.Engine.ActionMaxTargets, "action-max-targets", slibcfg.ViperInt, 100, "C2AE_ACTION_MAX_TARGETS"},
+		{&c.Engine.ResumePolicy, "resume-policy", slibcfg.ViperString, ResumePolicyFireOnce, "C2AE_RESUME_POLICY"},
+		{&c.Engine.DryRun, "action-dry-run", slibcfg.ViperBool, false, "C2AE_ACTION_DRY_RUN"},
+
 		{&c.OpencensusSampleAll, "oc-sample-all", slibcfg.ViperBool, true, ""},
 		{&c.OpencensusAddress, "oc-agent-addr", slibcfg.ViperString, "localhost:55678", "C2AE_OC_ENDPOINT"},
 
@@ -130,6 +154,10 @@ func (c API) Validate() error {
 		return err
 	}
 
+	if err := c.Engine.Validate(); err != nil {
+		return err
+	}
+
 	if len(c.C2Endpoint) == 0 {
 		return ErrC2EndpointRequired
 	}
@@ -178,6 +206,21 @@ func (c ServerCfg) Validate() error {
 	return nil
 }
 
+// Validate checks EngineCfg and returns an error if anything is invalid
+func (c EngineCfg) Validate() error {
+	if c.ActionMaxTargets < 0 {
+		return ErrInvalidActionMaxTargets
+	}
+
+	switch c.ResumePolicy {
+	case "", ResumePolicyFireOnce, ResumePolicySkip:
+	default:
+		return ErrInvalidResumePolicy
+	}
+
+	return nil
+}
+
 // Validate checks DBCfg and returns an error if anything is invalid
 func (c DBCfg) Validate() error {
 	if len(c.Passphrase) == 0 {
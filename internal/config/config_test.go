@@ -120,6 +120,40 @@ func TestConfig(t *testing.T) {
 				},
 				expectedErr: ErrNoDBFile,
 			},
+			{
+				cfg: API{
+					Server: ServerCfg{
+						GRPCAddr: "127.0.0.1:5556", GRPCCert: "c2ae-cert.pem", GRPCKey: "c2ae-key.pem",
+						HTTPAddr: "127.0.0.1:8886", HTTPGRPCAddr: "127.0.0.1:5556", HTTPCert: "c2ae-cert.pem", HTTPKey: "c2ae-key.pem",
+					},
+					DB: DBCfg{
+						Passphrase: "something",
+						Type:       slibcfg.DBTypeSQLite,
+						File:       "/some/file",
+					},
+					Engine: EngineCfg{
+						ActionMaxTargets: -1,
+					},
+				},
+				expectedErr: ErrInvalidActionMaxTargets,
+			},
+			{
+				cfg: API{
+					Server: ServerCfg{
+						GRPCAddr: "127.0.0.1:5556", GRPCCert: "c2ae-cert.pem", GRPCKey: "c2ae-key.pem",
+						HTTPAddr: "127.0.0.1:8886", HTTPGRPCAddr: "127.0.0.1:5556", HTTPCert: "c2ae-cert.pem", HTTPKey: "c2ae-key.pem",
+					},
+					DB: DBCfg{
+						Passphrase: "something",
+						Type:       slibcfg.DBTypeSQLite,
+						File:       "/some/file",
+					},
+					Engine: EngineCfg{
+						ResumePolicy: "fire_all",
+					},
+				},
+				expectedErr: ErrInvalidResumePolicy,
+			},
 			{
 				cfg: API{
 					Server: ServerCfg{
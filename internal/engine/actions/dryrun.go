@@ -0,0 +1,108 @@
+// Copyright 2020 Teserakt AG
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/teserakt-io/automation-engine/internal/models"
+	"github.com/teserakt-io/automation-engine/internal/services"
+)
+
+// dryRunC2 is a C2 client recording the calls modifying the C2 state instead of issuing them.
+// Read only calls are forwarded to the wrapped client, so targets still get resolved.
+type dryRunC2 struct {
+	services.C2
+	logger log.FieldLogger
+
+	lock  sync.Mutex
+	calls []string
+}
+
+var _ services.C2 = &dryRunC2{}
+
+func newDryRunC2(c2Client services.C2, logger log.FieldLogger) *dryRunC2 {
+	return &dryRunC2{
+		C2:     c2Client,
+		logger: logger,
+	}
+}
+
+func (c *dryRunC2) NewClientKey(ctx context.Context, clientName string) error {
+	c.record("NewClientKey(%s)", clientName)
+
+	return nil
+}
+
+func (c *dryRunC2) NewTopicKey(ctx context.Context, topic string) error {
+	c.record("NewTopicKey(%s)", topic)
+
+	return nil
+}
+
+func (c *dryRunC2) RemoveClient(ctx context.Context, clientName string) error {
+	c.record("RemoveClient(%s)", clientName)
+
+	return nil
+}
+
+func (c *dryRunC2) RemoveTopicClient(ctx context.Context, clientName string, topic string) error {
+	c.record("RemoveTopicClient(%s, %s)", clientName, topic)
+
+	return nil
+}
+
+func (c *dryRunC2) RemoveTopic(ctx context.Context, topic string) error {
+	c.record("RemoveTopic(%s)", topic)
+
+	return nil
+}
+
+func (c *dryRunC2) record(format string, args ...interface{}) {
+	call := fmt.Sprintf(format, args...)
+	c.logger.WithField("call", call).Info("dry run, C2 call not issued")
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.calls = append(c.calls, call)
+}
+
+// flush returns the calls recorded since the last flush
+func (c *dryRunC2) flush() []string {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	calls := c.calls
+	c.calls = nil
+
+	return calls
+}
+
+// withDryRunCalls sets on the outcome the C2 calls recorded since the previous outcome,
+// when c2Client is running in dry-run mode. Actions must pass every outcome
+// through it right after issuing the C2 calls of the outcome target.
+func withDryRunCalls(c2Client services.C2, outcome models.ExecutionOutcome) models.ExecutionOutcome {
+	if recorder, ok := c2Client.(*dryRunC2); ok {
+		outcome.DryRunCalls = strings.Join(recorder.flush(), ", ")
+	}
+
+	return outcome
+}
@@ -0,0 +1,139 @@
+// Copyright 2020 Teserakt AG
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"context"
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/teserakt-io/automation-engine/internal/clock"
+	"github.com/teserakt-io/automation-engine/internal/models"
+	"github.com/teserakt-io/automation-engine/internal/pb"
+	"github.com/teserakt-io/automation-engine/internal/services"
+)
+
+func TestDryRun(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockC2Client := services.NewMockC2(mockCtrl)
+	mockTargetResolver := NewMockTargetResolver(mockCtrl)
+
+	errorChan := make(chan error, 10)
+
+	logger := log.New()
+	logger.SetOutput(ioutil.Discard)
+
+	t.Run("Factory enables dry-run per rule or globally", func(t *testing.T) {
+		factory := NewActionFactory(mockC2Client, mockTargetResolver, 0, false, clock.New(), errorChan, logger)
+		if factory.DryRun(models.Rule{}) {
+			t.Errorf("Expected rule to not be in dry-run mode")
+		}
+
+		if !factory.DryRun(models.Rule{DryRun: true}) {
+			t.Errorf("Expected rule to be in dry-run mode")
+		}
+
+		globalFactory := NewActionFactory(mockC2Client, mockTargetResolver, 0, true, clock.New(), errorChan, logger)
+		if !globalFactory.DryRun(models.Rule{}) {
+			t.Errorf("Expected every rules to be in dry-run mode")
+		}
+	})
+
+	t.Run("Dry-run actions record the C2 calls instead of issuing them", func(t *testing.T) {
+		factory := NewActionFactory(mockC2Client, mockTargetResolver, 0, false, clock.New(), errorChan, logger)
+
+		targets := []models.Target{
+			models.Target{Type: pb.TargetType_CLIENT, Expr: "client.*"},
+			models.Target{Type: pb.TargetType_TOPIC, Expr: "topic1"},
+		}
+		rule := models.Rule{
+			ActionType: pb.ActionType_KEY_ROTATION,
+			Targets:    targets,
+			DryRun:     true,
+		}
+
+		// Targets are still resolved, but no C2 key is rotated
+		mockTargetResolver.EXPECT().Resolve(gomock.Any(), targets[0]).Return([]string{"client1", "client2"}, nil)
+		mockTargetResolver.EXPECT().Resolve(gomock.Any(), targets[1]).Return([]string{"topic1"}, nil)
+
+		action, err := factory.Create(rule, TriggerContext{})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		outcomes, err := action.Execute(context.Background())
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		expectedOutcomes := []models.ExecutionOutcome{
+			models.ExecutionOutcome{ActionType: pb.ActionType_KEY_ROTATION, TargetType: pb.TargetType_CLIENT, Target: "client1", DryRunCalls: "NewClientKey(client1)"},
+			models.ExecutionOutcome{ActionType: pb.ActionType_KEY_ROTATION, TargetType: pb.TargetType_CLIENT, Target: "client2", DryRunCalls: "NewClientKey(client2)"},
+			models.ExecutionOutcome{ActionType: pb.ActionType_KEY_ROTATION, TargetType: pb.TargetType_TOPIC, Target: "topic1", DryRunCalls: "NewTopicKey(topic1)"},
+		}
+		if reflect.DeepEqual(outcomes, expectedOutcomes) == false {
+			t.Errorf("Expected outcomes to be %#v, got %#v", expectedOutcomes, outcomes)
+		}
+	})
+
+	t.Run("Dry-run outcomes hold every call made on their target", func(t *testing.T) {
+		recorder := newDryRunC2(mockC2Client, logger)
+
+		action := &resetTopicAction{
+			targets:        []models.Target{models.Target{Type: pb.TargetType_TOPIC, Expr: "topic1"}},
+			c2Client:       recorder,
+			targetResolver: mockTargetResolver,
+			errorChan:      errorChan,
+			logger:         logger,
+		}
+
+		mockTargetResolver.EXPECT().Resolve(gomock.Any(), gomock.Any()).Return([]string{"topic1"}, nil)
+
+		outcomes, err := action.Execute(context.Background())
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		expectedCalls := "RemoveTopic(topic1), NewTopicKey(topic1)"
+		if len(outcomes) != 1 || outcomes[0].DryRunCalls != expectedCalls {
+			t.Errorf("Expected a single outcome with calls %q, got %#v", expectedCalls, outcomes)
+		}
+
+		if calls := recorder.flush(); len(calls) != 0 {
+			t.Errorf("Expected recorded calls to be flushed, got %v", calls)
+		}
+	})
+
+	t.Run("Read only calls are forwarded", func(t *testing.T) {
+		recorder := newDryRunC2(mockC2Client, logger)
+
+		mockC2Client.EXPECT().GetClients(gomock.Any(), int64(0), int64(10)).Return([]string{"client1"}, nil)
+
+		clients, err := recorder.GetClients(context.Background(), 0, 10)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if reflect.DeepEqual(clients, []string{"client1"}) == false {
+			t.Errorf("Expected clients to be [client1], got %v", clients)
+		}
+	})
+}
@@ -0,0 +1,93 @@
+// Copyright 2020 Teserakt AG
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/teserakt-io/automation-engine/internal/models"
+	"github.com/teserakt-io/automation-engine/internal/services"
+)
+
+// PendingAction is implemented by actions which may leave some of their outcomes pending
+// when Execute returns, like key rotations spread over a splay duration.
+// Complete finishes the pending work once the execution holding the outcomes has been saved,
+// calling done with the index of each pending outcome, within the ones returned by Execute,
+// and its result, possibly concurrently. It fails the remaining outcomes when ctx is done,
+// and returns once all are done.
+type PendingAction interface {
+	Action
+	Complete(ctx context.Context, done func(index int, err error))
+}
+
+type pendingContextKey struct{}
+
+// WithPendingContext returns a copy of ctx holding pendingCtx, the context the work left pending
+// by the actions executed with ctx runs with. It allows this work to outlive ctx.
+func WithPendingContext(ctx context.Context, pendingCtx context.Context) context.Context {
+	return context.WithValue(ctx, pendingContextKey{}, pendingCtx)
+}
+
+// PendingContext returns the context held by ctx for the pending work of actions, or ctx itself
+func PendingContext(ctx context.Context) context.Context {
+	if pendingCtx, ok := ctx.Value(pendingContextKey{}).(context.Context); ok {
+		return pendingCtx
+	}
+
+	return ctx
+}
+
+// CompletePending completes in the background the outcomes the action left pending in the saved execution,
+// updating each of them with executionWriter once done. It does nothing when no outcome is pending.
+func CompletePending(
+	ctx context.Context,
+	action Action,
+	execution models.Execution,
+	executionWriter services.ExecutionWriter,
+	logger log.FieldLogger,
+) {
+	pendingAction, ok := action.(PendingAction)
+	if !ok || !hasPendingOutcomes(execution.Outcomes) {
+		return
+	}
+
+	go pendingAction.Complete(ctx, func(index int, err error) {
+		outcome := execution.Outcomes[index]
+		outcome.Pending = false
+		if err != nil {
+			outcome.Error = err.Error()
+		}
+
+		// The outcome is still recorded when ctx is done, as failed
+		if err := executionWriter.UpdateOutcome(context.Background(), outcome); err != nil {
+			logger.WithError(err).WithFields(log.Fields{
+				"execution": execution.ID,
+				"outcome":   outcome.ID,
+			}).Error("failed to update pending execution outcome")
+		}
+	})
+}
+
+func hasPendingOutcomes(outcomes []models.ExecutionOutcome) bool {
+	for _, outcome := range outcomes {
+		if outcome.Pending {
+			return true
+		}
+	}
+
+	return false
+}
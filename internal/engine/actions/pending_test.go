@@ -0,0 +1,121 @@
+// Copyright 2020 Teserakt AG
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/teserakt-io/automation-engine/internal/models"
+	"github.com/teserakt-io/automation-engine/internal/pb"
+	"github.com/teserakt-io/automation-engine/internal/services"
+)
+
+func TestCompletePending(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	logger := log.New()
+	logger.SetOutput(ioutil.Discard)
+
+	t.Run("CompletePending updates the pending outcomes once completed", func(t *testing.T) {
+		mockAction := NewMockPendingAction(mockCtrl)
+		mockExecutionWriter := services.NewMockExecutionService(mockCtrl)
+
+		execution := models.Execution{
+			ID: 1,
+			Outcomes: []models.ExecutionOutcome{
+				models.ExecutionOutcome{ID: 1, ActionType: pb.ActionType_KEY_ROTATION, Target: "client1"},
+				models.ExecutionOutcome{ID: 2, ActionType: pb.ActionType_KEY_ROTATION, Target: "client2", Pending: true},
+				models.ExecutionOutcome{ID: 3, ActionType: pb.ActionType_KEY_ROTATION, Target: "client3", Pending: true},
+			},
+		}
+
+		rotationErr := errors.New("rotation failed")
+		mockAction.EXPECT().Complete(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, done func(int, error)) {
+				done(1, nil)
+				done(2, rotationErr)
+			},
+		)
+
+		updated := make(chan models.ExecutionOutcome, 2)
+		mockExecutionWriter.EXPECT().UpdateOutcome(gomock.Any(), gomock.Any()).Times(2).DoAndReturn(
+			func(ctx context.Context, outcome models.ExecutionOutcome) error {
+				updated <- outcome
+				return nil
+			},
+		)
+
+		CompletePending(context.Background(), mockAction, execution, mockExecutionWriter, logger)
+
+		expected := []models.ExecutionOutcome{
+			models.ExecutionOutcome{ID: 2, ActionType: pb.ActionType_KEY_ROTATION, Target: "client2"},
+			models.ExecutionOutcome{ID: 3, ActionType: pb.ActionType_KEY_ROTATION, Target: "client3", Error: rotationErr.Error()},
+		}
+		for _, expectedOutcome := range expected {
+			select {
+			case outcome := <-updated:
+				if reflect.DeepEqual(outcome, expectedOutcome) == false {
+					t.Errorf("Expected updated outcome to be %#v, got %#v", expectedOutcome, outcome)
+				}
+			case <-time.After(time.Second):
+				t.Fatalf("Expected outcome %d to be updated", expectedOutcome.ID)
+			}
+		}
+	})
+
+	t.Run("CompletePending does nothing without pending outcomes", func(t *testing.T) {
+		mockAction := NewMockPendingAction(mockCtrl)
+		mockExecutionWriter := services.NewMockExecutionService(mockCtrl)
+
+		execution := models.Execution{
+			ID: 1,
+			Outcomes: []models.ExecutionOutcome{
+				models.ExecutionOutcome{ID: 1, ActionType: pb.ActionType_KEY_ROTATION, Target: "client1"},
+			},
+		}
+
+		CompletePending(context.Background(), mockAction, execution, mockExecutionWriter, logger)
+	})
+
+	t.Run("PendingContext returns the context held by ctx, or ctx itself", func(t *testing.T) {
+		ctx := context.Background()
+		if PendingContext(ctx) != ctx {
+			t.Errorf("Expected PendingContext to default to ctx")
+		}
+
+		pendingCtx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		watcherCtx, cancelWatcher := context.WithCancel(WithPendingContext(ctx, pendingCtx))
+		cancelWatcher()
+
+		if PendingContext(watcherCtx) != pendingCtx {
+			t.Errorf("Expected PendingContext to return the pending context")
+		}
+
+		if err := PendingContext(watcherCtx).Err(); err != nil {
+			t.Errorf("Expected pending context to outlive ctx, got %v", err)
+		}
+	})
+}
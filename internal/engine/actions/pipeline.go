@@ -0,0 +1,151 @@
+// Copyright 2020 Teserakt AG
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+	"go.opencensus.io/trace"
+
+	"github.com/teserakt-io/automation-engine/internal/models"
+	"github.com/teserakt-io/automation-engine/internal/pb"
+)
+
+// PipelineFailed is an error returned when some of the pipeline actions failed.
+// Aborted is true when the following actions have not been executed.
+type PipelineFailed struct {
+	Step    int
+	Err     error
+	Failed  int
+	Aborted bool
+}
+
+func (e PipelineFailed) Error() string {
+	if e.Aborted {
+		return fmt.Sprintf("ERROR: pipeline aborted on action #%d: %v", e.Step, e.Err)
+	}
+
+	return fmt.Sprintf("ERROR: %d pipeline actions failed, first on action #%d: %v", e.Failed, e.Step, e.Err)
+}
+
+type pipelineStep struct {
+	actionType pb.ActionType
+	action     Action
+	onFailure  pb.ActionFailurePolicy
+	compensate Action
+}
+
+// pipelineAction executes a list of actions in sequence, applying each
+// action failure policy when it fails.
+type pipelineAction struct {
+	steps  []pipelineStep
+	logger log.FieldLogger
+
+	// pending holds the executed actions which left some of their outcomes pending
+	pending []pendingStep
+}
+
+// pendingStep is an executed action which left some of its outcomes pending,
+// and the offset of its outcomes in the ones returned by the pipeline
+type pendingStep struct {
+	offset int
+	action PendingAction
+}
+
+var _ PendingAction = &pipelineAction{}
+
+// Execute returns the outcomes of all the executed actions, including compensating ones.
+func (a *pipelineAction) Execute(ctx context.Context) ([]models.ExecutionOutcome, error) {
+	ctx, span := trace.StartSpan(ctx, "PipelineAction.Execute")
+	defer span.End()
+
+	var outcomes []models.ExecutionOutcome
+	var pipelineErr *PipelineFailed
+	for i, step := range a.steps {
+		logger := a.logger.WithFields(log.Fields{
+			"step":       i,
+			"actionType": step.actionType,
+		})
+
+		stepOutcomes, err := step.action.Execute(ctx)
+		outcomes = a.appendOutcomes(outcomes, step.action, stepOutcomes)
+		if err == nil {
+			continue
+		}
+
+		if pipelineErr == nil {
+			pipelineErr = &PipelineFailed{Step: i, Err: err}
+		}
+		pipelineErr.Failed++
+
+		switch step.onFailure {
+		case pb.ActionFailurePolicy_CONTINUE:
+			logger.WithError(err).Warn("pipeline action failed, continuing")
+
+			continue
+		case pb.ActionFailurePolicy_COMPENSATE:
+			logger.WithError(err).Warn("pipeline action failed, executing compensating action")
+			compensateOutcomes, compensateErr := step.compensate.Execute(ctx)
+			outcomes = a.appendOutcomes(outcomes, step.compensate, compensateOutcomes)
+			if compensateErr != nil {
+				logger.WithError(compensateErr).Error("compensating action failed")
+			}
+		}
+
+		logger.WithError(err).Error("pipeline aborted")
+
+		pipelineErr.Step = i
+		pipelineErr.Err = err
+		pipelineErr.Aborted = true
+
+		return outcomes, *pipelineErr
+	}
+
+	if pipelineErr != nil {
+		return outcomes, *pipelineErr
+	}
+
+	return outcomes, nil
+}
+
+// Complete completes the pending outcomes of every executed action, concurrently.
+// Their failures do not apply the failure policies, which only apply to Execute errors.
+func (a *pipelineAction) Complete(ctx context.Context, done func(index int, err error)) {
+	var wg sync.WaitGroup
+	for _, pending := range a.pending {
+		wg.Add(1)
+		go func(pending pendingStep) {
+			defer wg.Done()
+			pending.action.Complete(ctx, func(index int, err error) {
+				done(pending.offset+index, err)
+			})
+		}(pending)
+	}
+
+	wg.Wait()
+}
+
+// appendOutcomes appends the outcomes of the executed action to the pipeline ones,
+// recording the action when some of them are pending.
+func (a *pipelineAction) appendOutcomes(outcomes []models.ExecutionOutcome, action Action, actionOutcomes []models.ExecutionOutcome) []models.ExecutionOutcome {
+	if pendingAction, ok := action.(PendingAction); ok && hasPendingOutcomes(actionOutcomes) {
+		a.pending = append(a.pending, pendingStep{offset: len(outcomes), action: pendingAction})
+	}
+
+	return append(outcomes, actionOutcomes...)
+}
@@ -0,0 +1,198 @@
+// Copyright 2020 Teserakt AG
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/teserakt-io/automation-engine/internal/models"
+	"github.com/teserakt-io/automation-engine/internal/pb"
+)
+
+func TestPipelineAction(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	logger := log.New()
+	logger.SetOutput(ioutil.Discard)
+
+	ctx := context.Background()
+	actionErr := errors.New("action failed")
+
+	t.Run("Execute runs all actions in order", func(t *testing.T) {
+		mockAction1 := NewMockAction(mockCtrl)
+		mockAction2 := NewMockAction(mockCtrl)
+
+		outcome1 := models.ExecutionOutcome{ActionType: pb.ActionType_KEY_ROTATION, Target: "client1"}
+		outcome2 := models.ExecutionOutcome{ActionType: pb.ActionType_WEBHOOK, Target: "http://localhost"}
+
+		gomock.InOrder(
+			mockAction1.EXPECT().Execute(gomock.Any()).Return([]models.ExecutionOutcome{outcome1}, nil),
+			mockAction2.EXPECT().Execute(gomock.Any()).Return([]models.ExecutionOutcome{outcome2}, nil),
+		)
+
+		pipeline := &pipelineAction{
+			steps: []pipelineStep{
+				pipelineStep{action: mockAction1},
+				pipelineStep{action: mockAction2},
+			},
+			logger: logger,
+		}
+
+		outcomes, err := pipeline.Execute(ctx)
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+
+		expectedOutcomes := []models.ExecutionOutcome{outcome1, outcome2}
+		if !reflect.DeepEqual(outcomes, expectedOutcomes) {
+			t.Errorf("Expected outcomes to be %#v, got %#v", expectedOutcomes, outcomes)
+		}
+	})
+
+	t.Run("Execute stops on failure with the ABORT policy", func(t *testing.T) {
+		mockAction1 := NewMockAction(mockCtrl)
+		mockAction2 := NewMockAction(mockCtrl)
+
+		mockAction1.EXPECT().Execute(gomock.Any()).Return(nil, actionErr)
+
+		pipeline := &pipelineAction{
+			steps: []pipelineStep{
+				pipelineStep{action: mockAction1, onFailure: pb.ActionFailurePolicy_ABORT},
+				pipelineStep{action: mockAction2},
+			},
+			logger: logger,
+		}
+
+		_, err := pipeline.Execute(ctx)
+		expectedErr := PipelineFailed{Step: 0, Err: actionErr, Failed: 1, Aborted: true}
+		if err != expectedErr {
+			t.Errorf("Expected error to be %#v, got %#v", expectedErr, err)
+		}
+	})
+
+	t.Run("Execute runs following actions on failure with the CONTINUE policy", func(t *testing.T) {
+		mockAction1 := NewMockAction(mockCtrl)
+		mockAction2 := NewMockAction(mockCtrl)
+		mockAction3 := NewMockAction(mockCtrl)
+
+		gomock.InOrder(
+			mockAction1.EXPECT().Execute(gomock.Any()),
+			mockAction2.EXPECT().Execute(gomock.Any()).Return(nil, actionErr),
+			mockAction3.EXPECT().Execute(gomock.Any()),
+		)
+
+		pipeline := &pipelineAction{
+			steps: []pipelineStep{
+				pipelineStep{action: mockAction1},
+				pipelineStep{action: mockAction2, onFailure: pb.ActionFailurePolicy_CONTINUE},
+				pipelineStep{action: mockAction3},
+			},
+			logger: logger,
+		}
+
+		_, err := pipeline.Execute(ctx)
+		expectedErr := PipelineFailed{Step: 1, Err: actionErr, Failed: 1}
+		if err != expectedErr {
+			t.Errorf("Expected error to be %#v, got %#v", expectedErr, err)
+		}
+	})
+
+	t.Run("Execute runs the compensating action then stops with the COMPENSATE policy", func(t *testing.T) {
+		mockAction1 := NewMockAction(mockCtrl)
+		mockAction2 := NewMockAction(mockCtrl)
+		mockCompensate := NewMockAction(mockCtrl)
+
+		failedOutcome := models.ExecutionOutcome{ActionType: pb.ActionType_KEY_ROTATION, Target: "client1", Error: "failed"}
+		compensateOutcome := models.ExecutionOutcome{ActionType: pb.ActionType_WEBHOOK, Target: "http://localhost"}
+
+		gomock.InOrder(
+			mockAction1.EXPECT().Execute(gomock.Any()).Return([]models.ExecutionOutcome{failedOutcome}, actionErr),
+			mockCompensate.EXPECT().Execute(gomock.Any()).Return([]models.ExecutionOutcome{compensateOutcome}, nil),
+		)
+
+		pipeline := &pipelineAction{
+			steps: []pipelineStep{
+				pipelineStep{action: mockAction1, onFailure: pb.ActionFailurePolicy_COMPENSATE, compensate: mockCompensate},
+				pipelineStep{action: mockAction2},
+			},
+			logger: logger,
+		}
+
+		outcomes, err := pipeline.Execute(ctx)
+		expectedErr := PipelineFailed{Step: 0, Err: actionErr, Failed: 1, Aborted: true}
+		if err != expectedErr {
+			t.Errorf("Expected error to be %#v, got %#v", expectedErr, err)
+		}
+
+		expectedOutcomes := []models.ExecutionOutcome{failedOutcome, compensateOutcome}
+		if !reflect.DeepEqual(outcomes, expectedOutcomes) {
+			t.Errorf("Expected outcomes to be %#v, got %#v", expectedOutcomes, outcomes)
+		}
+	})
+
+	t.Run("Complete forwards the pending outcomes of the executed actions", func(t *testing.T) {
+		mockAction1 := NewMockAction(mockCtrl)
+		mockAction2 := NewMockPendingAction(mockCtrl)
+		mockAction3 := NewMockPendingAction(mockCtrl)
+
+		outcome1 := models.ExecutionOutcome{ActionType: pb.ActionType_WEBHOOK, Target: "http://localhost"}
+		outcome2 := models.ExecutionOutcome{ActionType: pb.ActionType_KEY_ROTATION, Target: "client1", Pending: true}
+		outcome3 := models.ExecutionOutcome{ActionType: pb.ActionType_KEY_ROTATION, Target: "client2"}
+
+		gomock.InOrder(
+			mockAction1.EXPECT().Execute(gomock.Any()).Return([]models.ExecutionOutcome{outcome1}, nil),
+			mockAction2.EXPECT().Execute(gomock.Any()).Return([]models.ExecutionOutcome{outcome2}, nil),
+			mockAction3.EXPECT().Execute(gomock.Any()).Return([]models.ExecutionOutcome{outcome3}, nil),
+		)
+
+		pipeline := &pipelineAction{
+			steps: []pipelineStep{
+				pipelineStep{action: mockAction1},
+				pipelineStep{action: mockAction2},
+				pipelineStep{action: mockAction3},
+			},
+			logger: logger,
+		}
+
+		if _, err := pipeline.Execute(ctx); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// mockAction3 left no outcome pending, and is not completed
+		mockAction2.EXPECT().Complete(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, done func(int, error)) {
+				done(0, actionErr)
+			},
+		)
+
+		completed := make(map[int]error)
+		pipeline.Complete(ctx, func(index int, err error) {
+			completed[index] = err
+		})
+
+		expected := map[int]error{1: actionErr}
+		if reflect.DeepEqual(completed, expected) == false {
+			t.Errorf("Expected completed outcomes to be %#v, got %#v", expected, completed)
+		}
+	})
+}
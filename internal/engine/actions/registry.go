@@ -0,0 +1,157 @@
+// Copyright 2020 Teserakt AG
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/teserakt-io/automation-engine/internal/clock"
+	"github.com/teserakt-io/automation-engine/internal/models"
+	"github.com/teserakt-io/automation-engine/internal/pb"
+	"github.com/teserakt-io/automation-engine/internal/services"
+)
+
+// Constructor creates an action of a registered action type
+type Constructor func(ActionParams) (Action, error)
+
+// ActionParams holds what a Constructor needs to create an action of a rule
+type ActionParams struct {
+	Rule    models.Rule
+	Trigger TriggerContext
+	// Settings holds the decoded action settings, nil when the action type doesn't support any
+	Settings pb.ActionSettings
+	// C2Client records the calls instead of issuing them when DryRun is true
+	C2Client       services.C2
+	TargetResolver TargetResolver
+	// MaxTargets limits how many clients or topics the action can affect, 0 meaning no limit
+	MaxTargets int
+	DryRun     bool
+	Clock      clock.Clock
+	ErrorChan  chan<- error
+	Logger     log.FieldLogger
+}
+
+var constructors = struct {
+	sync.RWMutex
+	byType map[pb.ActionType]Constructor
+}{
+	byType: make(map[pb.ActionType]Constructor),
+}
+
+func init() {
+	registerConstructor(pb.ActionType_KEY_ROTATION, newKeyRotationAction)
+	registerConstructor(pb.ActionType_REMOVE_CLIENT, newRemoveClientAction)
+	registerConstructor(pb.ActionType_UNLINK_CLIENT_TOPIC, newUnlinkClientTopicAction)
+	registerConstructor(pb.ActionType_RESET_TOPIC, newResetTopicAction)
+	registerConstructor(pb.ActionType_WEBHOOK, newWebhookAction)
+}
+
+// Register registers a new action type, along with the constructor used by the ActionFactory
+// to create its actions. See pb.RegisterAction for the definition requirements.
+// It panics when the action type is already registered, and is meant to be called from init functions.
+func Register(definition pb.ActionDefinition, constructor Constructor) {
+	pb.RegisterAction(definition)
+	registerConstructor(definition.Type, constructor)
+}
+
+func registerConstructor(actionType pb.ActionType, constructor Constructor) {
+	constructors.Lock()
+	defer constructors.Unlock()
+
+	if _, ok := constructors.byType[actionType]; ok {
+		panic(fmt.Sprintf("actions: constructor of action type %s is already registered", actionType))
+	}
+
+	constructors.byType[actionType] = constructor
+}
+
+func lookupConstructor(actionType pb.ActionType) (Constructor, bool) {
+	constructors.RLock()
+	defer constructors.RUnlock()
+
+	constructor, ok := constructors.byType[actionType]
+
+	return constructor, ok
+}
+
+func newKeyRotationAction(params ActionParams) (Action, error) {
+	return &keyRotationAction{
+		targets:        params.Rule.Targets,
+		c2Client:       params.C2Client,
+		targetResolver: params.TargetResolver,
+		maxTargets:     params.MaxTargets,
+		splay:          triggerSplay(params.Trigger.Trigger),
+		dryRun:         params.DryRun,
+		clock:          params.Clock,
+		errorChan:      params.ErrorChan,
+		logger:         params.Logger,
+	}, nil
+}
+
+func newRemoveClientAction(params ActionParams) (Action, error) {
+	return &removeClientAction{
+		targets:        params.Rule.Targets,
+		c2Client:       params.C2Client,
+		targetResolver: params.TargetResolver,
+		maxTargets:     params.MaxTargets,
+		errorChan:      params.ErrorChan,
+		logger:         params.Logger,
+	}, nil
+}
+
+func newUnlinkClientTopicAction(params ActionParams) (Action, error) {
+	return &unlinkClientTopicAction{
+		targets:        params.Rule.Targets,
+		c2Client:       params.C2Client,
+		targetResolver: params.TargetResolver,
+		maxTargets:     params.MaxTargets,
+		errorChan:      params.ErrorChan,
+		logger:         params.Logger,
+	}, nil
+}
+
+func newResetTopicAction(params ActionParams) (Action, error) {
+	return &resetTopicAction{
+		targets:        params.Rule.Targets,
+		c2Client:       params.C2Client,
+		targetResolver: params.TargetResolver,
+		maxTargets:     params.MaxTargets,
+		errorChan:      params.ErrorChan,
+		logger:         params.Logger,
+	}, nil
+}
+
+func newWebhookAction(params ActionParams) (Action, error) {
+	settings, ok := params.Settings.(*pb.ActionSettingsWebhook)
+	if !ok {
+		return nil, fmt.Errorf("invalid webhook action settings type %T", params.Settings)
+	}
+
+	return &webhookAction{
+		rule:       params.Rule,
+		triggerCtx: params.Trigger,
+		settings:   settings,
+		httpClient: &http.Client{},
+		retryDelay: DefaultWebhookRetryDelay,
+		clock:      params.Clock,
+		dryRun:     params.DryRun,
+		errorChan:  params.ErrorChan,
+		logger:     params.Logger,
+	}, nil
+}
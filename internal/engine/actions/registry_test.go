@@ -0,0 +1,123 @@
+// Copyright 2020 Teserakt AG
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"context"
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/teserakt-io/automation-engine/internal/clock"
+	"github.com/teserakt-io/automation-engine/internal/models"
+	"github.com/teserakt-io/automation-engine/internal/pb"
+	"github.com/teserakt-io/automation-engine/internal/services"
+)
+
+type customAction struct {
+	params ActionParams
+}
+
+func (a *customAction) Execute(ctx context.Context) ([]models.ExecutionOutcome, error) {
+	return nil, nil
+}
+
+func TestRegister(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	logger := log.New()
+	logger.SetOutput(ioutil.Discard)
+
+	customType := pb.ActionType(1100)
+	Register(
+		pb.ActionDefinition{
+			Type:        customType,
+			Name:        "CUSTOM_ACTION_TEST",
+			NewSettings: func() pb.ActionSettings { return &pb.ActionSettingsWebhook{} },
+			TargetTypes: []pb.TargetType{pb.TargetType_ANY, pb.TargetType_CLIENT},
+		},
+		func(params ActionParams) (Action, error) {
+			return &customAction{params: params}, nil
+		},
+	)
+
+	mockC2Client := services.NewMockC2(mockCtrl)
+	mockTargetResolver := NewMockTargetResolver(mockCtrl)
+	factory := NewActionFactory(mockC2Client, mockTargetResolver, 10, false, clock.New(), make(chan error), logger)
+
+	t.Run("Create returns the action from the registered constructor", func(t *testing.T) {
+		rule := models.Rule{
+			ID:             1,
+			ActionType:     customType,
+			ActionSettings: []byte(`{"url":"http://example.com"}`),
+			Targets:        []models.Target{models.Target{ID: 1, Type: pb.TargetType_CLIENT, Expr: "client1"}},
+		}
+
+		action, err := factory.Create(rule, TriggerContext{})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		typedAction, ok := action.(*customAction)
+		if !ok {
+			t.Fatalf("Expected action to be a *customAction, got %T", action)
+		}
+
+		if reflect.DeepEqual(typedAction.params.Rule, rule) == false {
+			t.Errorf("Expected action rule to be %#v, got %#v", rule, typedAction.params.Rule)
+		}
+
+		expectedSettings := &pb.ActionSettingsWebhook{URL: "http://example.com"}
+		if reflect.DeepEqual(typedAction.params.Settings, expectedSettings) == false {
+			t.Errorf("Expected action settings to be %#v, got %#v", expectedSettings, typedAction.params.Settings)
+		}
+
+		if typedAction.params.C2Client != mockC2Client || typedAction.params.MaxTargets != 10 || typedAction.params.DryRun {
+			t.Errorf("Expected action to receive the factory dependencies, got %#v", typedAction.params)
+		}
+	})
+
+	t.Run("Create passes a dry-run C2 client to registered constructors", func(t *testing.T) {
+		rule := models.Rule{
+			ActionType:     customType,
+			ActionSettings: []byte(`{"url":"http://example.com"}`),
+			DryRun:         true,
+		}
+
+		action, err := factory.Create(rule, TriggerContext{})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		params := action.(*customAction).params
+		if _, ok := params.C2Client.(*dryRunC2); !ok || !params.DryRun {
+			t.Errorf("Expected action to be created in dry-run mode, got %#v", params)
+		}
+	})
+
+	t.Run("Register panics when the action type is already registered", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("Expected Register to panic")
+			}
+		}()
+
+		Register(pb.ActionDefinition{Type: pb.ActionType_WEBHOOK, Name: "WEBHOOK"}, newWebhookAction)
+	})
+}
@@ -14,15 +14,20 @@
 
 package actions
 
-//go:generate mockgen -copyright_file ../../../doc/COPYRIGHT_TEMPLATE.txt -destination=rules_mocks.go -package actions -self_package github.com/teserakt-io/automation-engine/internal/engine/actions github.com/teserakt-io/automation-engine/internal/engine/actions ActionFactory,Action
+//go:generate mockgen -copyright_file ../../../doc/COPYRIGHT_TEMPLATE.txt -destination=rules_mocks.go -package actions -self_package github.com/teserakt-io/automation-engine/internal/engine/actions github.com/teserakt-io/automation-engine/internal/engine/actions ActionFactory,Action,PendingAction
 
 import (
 	"context"
 	"fmt"
+	"hash/fnv"
+	"sort"
+	"time"
 
 	log "github.com/sirupsen/logrus"
+	c2pb "github.com/teserakt-io/c2/pkg/pb"
 	"go.opencensus.io/trace"
 
+	"github.com/teserakt-io/automation-engine/internal/clock"
 	"github.com/teserakt-io/automation-engine/internal/models"
 	"github.com/teserakt-io/automation-engine/internal/pb"
 	"github.com/teserakt-io/automation-engine/internal/services"
@@ -30,47 +35,139 @@ import (
 
 // ActionFactory is responsible of Aciton creation
 type ActionFactory interface {
-	Create(models.Rule) (Action, error)
+	Create(models.Rule, TriggerContext) (Action, error)
+	// DryRun returns true when the actions of the rule are created in dry-run mode,
+	// recording the C2 calls they would make instead of issuing them.
+	DryRun(models.Rule) bool
 }
 
-// Action describe rule's Action methods
+// TriggerContext holds informations about the trigger which caused a rule action to be executed
+type TriggerContext struct {
+	Trigger models.Trigger
+	Time    time.Time
+	// Event is the C2 event which caused the trigger to fire, nil when not triggered by an event
+	Event *c2pb.Event
+}
+
+// Action describe rule's Action methods.
+// Execute returns the outcome of the action on each of its targets.
 type Action interface {
-	Execute(context.Context)
+	Execute(context.Context) ([]models.ExecutionOutcome, error)
 }
 
 type actionFactory struct {
-	c2Client  services.C2
-	errorChan chan<- error
-	logger    log.FieldLogger
+	c2Client       services.C2
+	targetResolver TargetResolver
+	maxTargets     int
+	dryRun         bool
+	clock          clock.Clock
+	errorChan      chan<- error
+	logger         log.FieldLogger
 }
 
 var _ ActionFactory = &actionFactory{}
 
-// NewActionFactory creates a new ActionFactory
-func NewActionFactory(c2Client services.C2, errorChan chan<- error, logger log.FieldLogger) ActionFactory {
+// NewActionFactory creates a new ActionFactory. maxTargets limits how many clients or topics
+// a single action execution can affect once the rule targets have been resolved, 0 meaning no limit.
+// When dryRun is true, every action is created in dry-run mode, whatever the rule setting.
+func NewActionFactory(
+	c2Client services.C2,
+	targetResolver TargetResolver,
+	maxTargets int,
+	dryRun bool,
+	clock clock.Clock,
+	errorChan chan<- error,
+	logger log.FieldLogger,
+) ActionFactory {
 	return &actionFactory{
-		c2Client:  c2Client,
-		errorChan: errorChan,
-		logger:    logger,
+		c2Client:       c2Client,
+		targetResolver: targetResolver,
+		maxTargets:     maxTargets,
+		dryRun:         dryRun,
+		clock:          clock,
+		errorChan:      errorChan,
+		logger:         logger,
+	}
+}
+
+func (f *actionFactory) DryRun(rule models.Rule) bool {
+	return f.dryRun || rule.DryRun
+}
+
+// Create returns the action to execute for given rule. When the rule holds a list of actions,
+// a pipeline executing them in sequence is returned.
+func (f *actionFactory) Create(rule models.Rule, triggerCtx TriggerContext) (Action, error) {
+	c2Client := f.c2Client
+	if f.DryRun(rule) {
+		c2Client = newDryRunC2(f.c2Client, f.logger.WithField("rule", rule.ID))
+	}
+
+	if len(rule.Actions) == 0 {
+		return f.create(rule.ActionType, rule.ActionSettings, rule, triggerCtx, c2Client)
+	}
+
+	pipeline := &pipelineAction{
+		logger: f.logger,
+	}
+
+	for _, ruleAction := range rule.Actions {
+		action, err := f.create(ruleAction.ActionType, ruleAction.Settings, rule, triggerCtx, c2Client)
+		if err != nil {
+			return nil, err
+		}
+
+		step := pipelineStep{
+			actionType: ruleAction.ActionType,
+			action:     action,
+			onFailure:  ruleAction.OnFailure,
+		}
+
+		if ruleAction.OnFailure == pb.ActionFailurePolicy_COMPENSATE {
+			step.compensate, err = f.create(ruleAction.CompensateType, ruleAction.CompensateSettings, rule, triggerCtx, c2Client)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		pipeline.steps = append(pipeline.steps, step)
 	}
+
+	return pipeline, nil
 }
 
-func (f *actionFactory) Create(rule models.Rule) (Action, error) {
-	var action Action
+func (f *actionFactory) create(
+	actionType pb.ActionType,
+	settings []byte,
+	rule models.Rule,
+	triggerCtx TriggerContext,
+	c2Client services.C2,
+) (Action, error) {
+	constructor, ok := lookupConstructor(actionType)
+	if !ok {
+		return nil, fmt.Errorf("unknown action type %d", actionType)
+	}
 
-	switch rule.ActionType {
-	case pb.ActionType_KEY_ROTATION:
-		action = &keyRotationAction{
-			targets:   rule.Targets,
-			c2Client:  f.c2Client,
-			errorChan: f.errorChan,
-			logger:    f.logger,
+	var actionSettings pb.ActionSettings
+	if pb.HasActionSettings(actionType) {
+		var err error
+		actionSettings, err = pb.DecodeActionSettings(actionType, settings)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode %s action settings: %v", actionType, err)
 		}
-	default:
-		return nil, fmt.Errorf("unknown action type %d", rule.ActionType)
 	}
 
-	return action, nil
+	return constructor(ActionParams{
+		Rule:           rule,
+		Trigger:        triggerCtx,
+		Settings:       actionSettings,
+		C2Client:       c2Client,
+		TargetResolver: f.targetResolver,
+		MaxTargets:     f.maxTargets,
+		DryRun:         f.DryRun(rule),
+		Clock:          f.clock,
+		ErrorChan:      f.errorChan,
+		Logger:         f.logger,
+	})
 }
 
 // UnsupportedTargetType is an error returned when trying to execute
@@ -88,55 +185,376 @@ func (e UnsupportedTargetType) Error() string {
 	)
 }
 
+// TooManyTargets is an error returned when the rule targets resolve to more
+// clients or topics than an action execution is allowed to affect.
+type TooManyTargets struct {
+	Action Action
+	Count  int
+	Max    int
+}
+
+func (e TooManyTargets) Error() string {
+	return fmt.Sprintf(
+		"ERROR: action %T resolved %d targets, exceeding the limit of %d",
+		e.Action,
+		e.Count,
+		e.Max,
+	)
+}
+
+// ExecutionFailed is an error returned when an action failed to resolve
+// or to be executed on some of the rule targets.
+type ExecutionFailed struct {
+	Action Action
+	Failed int
+}
+
+func (e ExecutionFailed) Error() string {
+	return fmt.Sprintf("ERROR: action %T failed on %d targets", e.Action, e.Failed)
+}
+
 type keyRotationAction struct {
-	targets  []models.Target
-	c2Client services.C2
-	logger   log.FieldLogger
+	targets        []models.Target
+	c2Client       services.C2
+	targetResolver TargetResolver
+	maxTargets     int
+	// splay, when not 0, spreads the rotations of the resolved targets over this duration
+	splay  time.Duration
+	dryRun bool
+	clock  clock.Clock
+	logger log.FieldLogger
 
 	errorChan chan<- error
+
+	// executedAt and pending are set by Execute, for Complete to rotate the splayed targets
+	executedAt time.Time
+	pending    []pendingRotation
+}
+
+// pendingRotation is a splayed rotation, and the index of its outcome in the ones returned by Execute
+type pendingRotation struct {
+	index    int
+	resolved resolvedTarget
 }
 
-var _ Action = &keyRotationAction{}
+var _ PendingAction = &keyRotationAction{}
 
-func (a *keyRotationAction) Execute(ctx context.Context) {
+// Execute rotates the keys of the resolved targets. When the action has a splay, and is not
+// executed in dry-run mode, their outcomes are returned pending instead, for Complete to rotate them.
+func (a *keyRotationAction) Execute(ctx context.Context) ([]models.ExecutionOutcome, error) {
 	ctx, span := trace.StartSpan(ctx, "KeyRotationAction.Execute")
 	defer span.End()
 
-	for _, target := range a.targets {
-		logger := a.logger.WithFields(log.Fields{
-			"action":     "keyRotation",
-			"target":     target.Expr,
-			"targetType": pb.TargetType_name[int32(target.Type)],
-		})
+	logger := a.logger.WithField("action", "keyRotation")
+
+	resolvedTargets, outcomes, err := resolveTargets(
+		ctx,
+		a,
+		pb.ActionType_KEY_ROTATION,
+		a.targetResolver,
+		a.targets,
+		a.maxTargets,
+		a.errorChan,
+		logger,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	a.executedAt = a.clock.Now()
+	for _, resolved := range resolvedTargets {
+		if a.splay > 0 && !a.dryRun {
+			outcome := resolved.outcome(pb.ActionType_KEY_ROTATION, nil)
+			outcome.Pending = true
+			a.pending = append(a.pending, pendingRotation{index: len(outcomes), resolved: resolved})
+			outcomes = append(outcomes, outcome)
+
+			continue
+		}
+
+		err := logResult(resolved.logger(logger), a.rotate(ctx, resolved))
+		outcomes = append(outcomes, withDryRunCalls(a.c2Client, resolved.outcome(pb.ActionType_KEY_ROTATION, err)))
+	}
+
+	return outcomes, executionResult(a, outcomes)
+}
+
+// Complete rotates each pending target at its offset within the splay duration, after the execution
+func (a *keyRotationAction) Complete(ctx context.Context, done func(index int, err error)) {
+	logger := a.logger.WithField("action", "keyRotation")
+
+	offsets := make(map[resolvedTarget]time.Duration, len(a.pending))
+	for _, pending := range a.pending {
+		offsets[pending.resolved] = splayOffset(pending.resolved, a.splay)
+	}
+
+	sort.SliceStable(a.pending, func(i, j int) bool {
+		return offsets[a.pending[i].resolved] < offsets[a.pending[j].resolved]
+	})
+
+	for _, pending := range a.pending {
+		err := waitUntil(ctx, a.clock, a.executedAt.Add(offsets[pending.resolved]))
+		if err == nil {
+			err = a.rotate(ctx, pending.resolved)
+		}
+
+		done(pending.index, logResult(pending.resolved.logger(logger), err))
+	}
+}
+
+func (a *keyRotationAction) rotate(ctx context.Context, resolved resolvedTarget) error {
+	switch resolved.target.Type {
+	case pb.TargetType_CLIENT:
+		return a.c2Client.NewClientKey(ctx, resolved.name)
+	case pb.TargetType_TOPIC:
+		return a.c2Client.NewTopicKey(ctx, resolved.name)
+	}
+
+	return nil
+}
+
+// triggerSplay returns the splay setting of the trigger, or 0 when the trigger has none
+func triggerSplay(trigger models.Trigger) time.Duration {
+	if trigger.TriggerType != pb.TriggerType_TIME_INTERVAL {
+		return 0
+	}
+
+	settings := &pb.TriggerSettingsTimeInterval{}
+	if err := settings.Decode(trigger.Settings); err != nil {
+		return 0
+	}
+
+	return settings.SplayDuration()
+}
+
+// splayOffset returns the offset of the resolved target within splay. It only depends
+// on the target type and name, so a given target is always rotated at the same offset.
+func splayOffset(resolved resolvedTarget, splay time.Duration) time.Duration {
+	h := fnv.New64a()
+	fmt.Fprintf(h, "%d:%s", resolved.target.Type, resolved.name)
+
+	return time.Duration(h.Sum64() % uint64(splay))
+}
+
+// waitUntil blocks until t on the given clock, returning an error if ctx is done before
+func waitUntil(ctx context.Context, clock clock.Clock, t time.Time) error {
+	delay := t.Sub(clock.Now())
+	if delay <= 0 {
+		return nil
+	}
+
+	select {
+	case <-clock.After(delay):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+type removeClientAction struct {
+	targets        []models.Target
+	c2Client       services.C2
+	targetResolver TargetResolver
+	maxTargets     int
+	logger         log.FieldLogger
+
+	errorChan chan<- error
+}
+
+var _ Action = &removeClientAction{}
+
+func (a *removeClientAction) Execute(ctx context.Context) ([]models.ExecutionOutcome, error) {
+	ctx, span := trace.StartSpan(ctx, "RemoveClientAction.Execute")
+	defer span.End()
+
+	logger := a.logger.WithField("action", "removeClient")
+
+	resolvedTargets, outcomes, err := resolveTargets(
+		ctx,
+		a,
+		pb.ActionType_REMOVE_CLIENT,
+		a.targetResolver,
+		a.targets,
+		a.maxTargets,
+		a.errorChan,
+		logger,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, resolved := range resolvedTargets {
+		err := a.c2Client.RemoveClient(ctx, resolved.name)
+		logResult(resolved.logger(logger), err)
+		outcomes = append(outcomes, withDryRunCalls(a.c2Client, resolved.outcome(pb.ActionType_REMOVE_CLIENT, err)))
+	}
+
+	return outcomes, executionResult(a, outcomes)
+}
+
+type unlinkClientTopicAction struct {
+	targets        []models.Target
+	c2Client       services.C2
+	targetResolver TargetResolver
+	maxTargets     int
+	logger         log.FieldLogger
+
+	errorChan chan<- error
+}
+
+var _ Action = &unlinkClientTopicAction{}
+
+// Execute removes every client matched by the rule CLIENT targets
+// from every topic matched by the rule TOPIC targets.
+func (a *unlinkClientTopicAction) Execute(ctx context.Context) ([]models.ExecutionOutcome, error) {
+	ctx, span := trace.StartSpan(ctx, "UnlinkClientTopicAction.Execute")
+	defer span.End()
+
+	logger := a.logger.WithField("action", "unlinkClientTopic")
 
-		switch target.Type {
+	// Each client is unlinked from each topic, so maxTargets
+	// applies to the number of pairs rather than to the resolved names.
+	resolvedTargets, outcomes, err := resolveTargets(
+		ctx,
+		a,
+		pb.ActionType_UNLINK_CLIENT_TOPIC,
+		a.targetResolver,
+		a.targets,
+		0,
+		a.errorChan,
+		logger,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var clients, topics []resolvedTarget
+	for _, resolved := range resolvedTargets {
+		switch resolved.target.Type {
 		case pb.TargetType_CLIENT:
-			// TODO: for now we expect target to be defined with exact names of client.
-			// But we may want later to allow some wildcards to target multiple clients at once
-			// like weather-station-*, which should match weather-station-east, weather-station-west...
-			// But it might not be possible to fetch all existing client names (huge number)
-			// Maybe we could just send the wildcarded expression to the C2 and let it deal with it and
-			// match the clients directly from a DB query.
-			err := a.c2Client.NewClientKey(ctx, target.Expr)
-			if err != nil {
-				logger.WithError(err).Error("failed to execute action")
-				continue
-			}
+			clients = append(clients, resolved)
 		case pb.TargetType_TOPIC:
-			err := a.c2Client.NewTopicKey(ctx, target.Expr)
-			if err != nil {
-				logger.WithError(err).Error("failed to execute action")
+			topics = append(topics, resolved)
+		}
+	}
 
-				continue
-			}
-		default:
-			err := UnsupportedTargetType{Action: a, TargetTypeName: pb.TargetType_name[int32(target.Type)]}
-			a.errorChan <- err
-			logger.WithError(err).Error("failed to execute action")
+	if len(clients) == 0 || len(topics) == 0 {
+		logger.Warn("action requires both client and topic targets, nothing to unlink")
 
-			continue
+		return outcomes, executionResult(a, outcomes)
+	}
+
+	if pairs := len(clients) * len(topics); a.maxTargets > 0 && pairs > a.maxTargets {
+		err := TooManyTargets{Action: a, Count: pairs, Max: a.maxTargets}
+		a.errorChan <- err
+		logger.WithError(err).Error("aborted action execution")
+
+		return nil, err
+	}
+
+	for _, client := range clients {
+		for _, topic := range topics {
+			err := a.c2Client.RemoveTopicClient(ctx, client.name, topic.name)
+			logResult(logger.WithFields(log.Fields{"client": client.name, "topic": topic.name}), err)
+			outcomes = append(outcomes, withDryRunCalls(a.c2Client, newOutcome(
+				pb.ActionType_UNLINK_CLIENT_TOPIC,
+				pb.TargetType_ANY,
+				fmt.Sprintf("client %s, topic %s", client.name, topic.name),
+				err,
+			)))
 		}
+	}
+
+	return outcomes, executionResult(a, outcomes)
+}
+
+type resetTopicAction struct {
+	targets        []models.Target
+	c2Client       services.C2
+	targetResolver TargetResolver
+	maxTargets     int
+	logger         log.FieldLogger
 
-		logger.Info("successfully executed action")
+	errorChan chan<- error
+}
+
+var _ Action = &resetTopicAction{}
+
+// Execute removes every topic matched by the rule TOPIC targets
+// and creates them again, with a brand new key.
+func (a *resetTopicAction) Execute(ctx context.Context) ([]models.ExecutionOutcome, error) {
+	ctx, span := trace.StartSpan(ctx, "ResetTopicAction.Execute")
+	defer span.End()
+
+	logger := a.logger.WithField("action", "resetTopic")
+
+	resolvedTargets, outcomes, err := resolveTargets(
+		ctx,
+		a,
+		pb.ActionType_RESET_TOPIC,
+		a.targetResolver,
+		a.targets,
+		a.maxTargets,
+		a.errorChan,
+		logger,
+	)
+	if err != nil {
+		return nil, err
 	}
+
+	for _, resolved := range resolvedTargets {
+		err := a.c2Client.RemoveTopic(ctx, resolved.name)
+		if err == nil {
+			err = a.c2Client.NewTopicKey(ctx, resolved.name)
+		}
+
+		logResult(resolved.logger(logger), err)
+		outcomes = append(outcomes, withDryRunCalls(a.c2Client, resolved.outcome(pb.ActionType_RESET_TOPIC, err)))
+	}
+
+	return outcomes, executionResult(a, outcomes)
+}
+
+// logResult logs the outcome of an action execution, and returns err
+func logResult(logger log.FieldLogger, err error) error {
+	if err != nil {
+		logger.WithError(err).Error("failed to execute action")
+
+		return err
+	}
+
+	logger.Info("successfully executed action")
+
+	return nil
+}
+
+// newOutcome returns the outcome of an action execution on a single target
+func newOutcome(actionType pb.ActionType, targetType pb.TargetType, target string, err error) models.ExecutionOutcome {
+	outcome := models.ExecutionOutcome{
+		ActionType: actionType,
+		TargetType: targetType,
+		Target:     target,
+	}
+
+	if err != nil {
+		outcome.Error = err.Error()
+	}
+
+	return outcome
+}
+
+// executionResult returns an ExecutionFailed error when some of the action outcomes failed
+func executionResult(action Action, outcomes []models.ExecutionOutcome) error {
+	var failed int
+	for _, outcome := range outcomes {
+		if len(outcome.Error) > 0 {
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return ExecutionFailed{Action: action, Failed: failed}
+	}
+
+	return nil
 }
@@ -14,7 +14,7 @@
 //
 
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/teserakt-io/automation-engine/internal/engine/actions (interfaces: ActionFactory,Action)
+// Source: github.com/teserakt-io/automation-engine/internal/engine/actions (interfaces: ActionFactory,Action,PendingAction)
 
 // Package actions is a generated GoMock package.
 package actions
@@ -50,18 +50,32 @@ func (m *MockActionFactory) EXPECT() *MockActionFactoryMockRecorder {
 }
 
 // Create mocks base method
-func (m *MockActionFactory) Create(arg0 models.Rule) (Action, error) {
+func (m *MockActionFactory) Create(arg0 models.Rule, arg1 TriggerContext) (Action, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", arg0)
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
 	ret0, _ := ret[0].(Action)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Create indicates an expected call of Create
-func (mr *MockActionFactoryMockRecorder) Create(arg0 interface{}) *gomock.Call {
+func (mr *MockActionFactoryMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockActionFactory)(nil).Create), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockActionFactory)(nil).Create), arg0, arg1)
+}
+
+// DryRun mocks base method
+func (m *MockActionFactory) DryRun(arg0 models.Rule) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DryRun", arg0)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// DryRun indicates an expected call of DryRun
+func (mr *MockActionFactoryMockRecorder) DryRun(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DryRun", reflect.TypeOf((*MockActionFactory)(nil).DryRun), arg0)
 }
 
 // MockAction is a mock of Action interface
@@ -88,9 +102,12 @@ func (m *MockAction) EXPECT() *MockActionMockRecorder {
 }
 
 // Execute mocks base method
-func (m *MockAction) Execute(arg0 context.Context) {
+func (m *MockAction) Execute(arg0 context.Context) ([]models.ExecutionOutcome, error) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "Execute", arg0)
+	ret := m.ctrl.Call(m, "Execute", arg0)
+	ret0, _ := ret[0].([]models.ExecutionOutcome)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // Execute indicates an expected call of Execute
@@ -98,3 +115,53 @@ func (mr *MockActionMockRecorder) Execute(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockAction)(nil).Execute), arg0)
 }
+
+// MockPendingAction is a mock of PendingAction interface
+type MockPendingAction struct {
+	ctrl     *gomock.Controller
+	recorder *MockPendingActionMockRecorder
+}
+
+// MockPendingActionMockRecorder is the mock recorder for MockPendingAction
+type MockPendingActionMockRecorder struct {
+	mock *MockPendingAction
+}
+
+// NewMockPendingAction creates a new mock instance
+func NewMockPendingAction(ctrl *gomock.Controller) *MockPendingAction {
+	mock := &MockPendingAction{ctrl: ctrl}
+	mock.recorder = &MockPendingActionMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockPendingAction) EXPECT() *MockPendingActionMockRecorder {
+	return m.recorder
+}
+
+// Complete mocks base method
+func (m *MockPendingAction) Complete(arg0 context.Context, arg1 func(int, error)) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Complete", arg0, arg1)
+}
+
+// Complete indicates an expected call of Complete
+func (mr *MockPendingActionMockRecorder) Complete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Complete", reflect.TypeOf((*MockPendingAction)(nil).Complete), arg0, arg1)
+}
+
+// Execute mocks base method
+func (m *MockPendingAction) Execute(arg0 context.Context) ([]models.ExecutionOutcome, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Execute", arg0)
+	ret0, _ := ret[0].([]models.ExecutionOutcome)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Execute indicates an expected call of Execute
+func (mr *MockPendingActionMockRecorder) Execute(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockPendingAction)(nil).Execute), arg0)
+}
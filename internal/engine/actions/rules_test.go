@@ -16,6 +16,7 @@ package actions
 
 import (
 	"context"
+	"errors"
 	"io/ioutil"
 	"reflect"
 	"testing"
@@ -24,6 +25,7 @@ import (
 	"github.com/golang/mock/gomock"
 	log "github.com/sirupsen/logrus"
 
+	"github.com/teserakt-io/automation-engine/internal/clock"
 	"github.com/teserakt-io/automation-engine/internal/models"
 	"github.com/teserakt-io/automation-engine/internal/pb"
 	"github.com/teserakt-io/automation-engine/internal/services"
@@ -40,7 +42,7 @@ func TestKeyRotationAction(t *testing.T) {
 	logger := log.New()
 	logger.SetOutput(ioutil.Discard)
 
-	t.Run("Execute calls the expected C2 client method", func(t *testing.T) {
+	t.Run("Execute calls the expected C2 client method and skips ANY targets", func(t *testing.T) {
 		targets := []models.Target{
 			models.Target{Type: pb.TargetType_CLIENT, Expr: "client1"},
 			models.Target{Type: pb.TargetType_TOPIC, Expr: "topic1"},
@@ -57,39 +59,487 @@ func TestKeyRotationAction(t *testing.T) {
 		)
 
 		action := &keyRotationAction{
-			targets:   targets,
-			c2Client:  mockC2Client,
-			errorChan: errorChan,
-			logger:    logger,
+			clock:          clock.New(),
+			targets:        targets,
+			c2Client:       mockC2Client,
+			targetResolver: NewTargetResolver(mockC2Client, DefaultResolverPageSize),
+			errorChan:      errorChan,
+			logger:         logger,
 		}
 
 		go action.Execute(context.Background())
 
 		select {
 		case err := <-errorChan:
-			terr, ok := err.(UnsupportedTargetType)
+			t.Errorf("Expected ANY targets to be skipped without error, got %s", err)
+		case <-time.After(10 * time.Millisecond):
+		}
+	})
+
+	t.Run("Execute rotates every client and topic matching the targets expressions", func(t *testing.T) {
+		mockTargetResolver := NewMockTargetResolver(mockCtrl)
+
+		targets := []models.Target{
+			models.Target{Type: pb.TargetType_CLIENT, Expr: "weather-station-.*"},
+			models.Target{Type: pb.TargetType_TOPIC, Expr: "/devices/.*"},
+		}
+
+		gomock.InOrder(
+			mockTargetResolver.EXPECT().Resolve(gomock.Any(), targets[0]).Return([]string{"weather-station-east", "weather-station-west"}, nil),
+			mockTargetResolver.EXPECT().Resolve(gomock.Any(), targets[1]).Return([]string{"/devices/groupA"}, nil),
+			mockC2Client.EXPECT().NewClientKey(gomock.Any(), "weather-station-east"),
+			mockC2Client.EXPECT().NewClientKey(gomock.Any(), "weather-station-west"),
+			mockC2Client.EXPECT().NewTopicKey(gomock.Any(), "/devices/groupA"),
+		)
+
+		action := &keyRotationAction{
+			clock:          clock.New(),
+			targets:        targets,
+			c2Client:       mockC2Client,
+			targetResolver: mockTargetResolver,
+			maxTargets:     3,
+			errorChan:      errorChan,
+			logger:         logger,
+		}
+
+		go action.Execute(context.Background())
+
+		select {
+		case err := <-errorChan:
+			t.Errorf("Expected no error, got %s", err)
+		case <-time.After(10 * time.Millisecond):
+		}
+	})
+
+	t.Run("Execute leaves splayed rotations pending and Complete spreads them over the splay duration", func(t *testing.T) {
+		mockTargetResolver := NewMockTargetResolver(mockCtrl)
+
+		targets := []models.Target{
+			models.Target{Type: pb.TargetType_CLIENT, Expr: "client-.*"},
+		}
+		names := []string{"client-1", "client-2", "client-3", "client-4"}
+
+		splay := 50 * time.Millisecond
+		mockTargetResolver.EXPECT().Resolve(gomock.Any(), targets[0]).Return(names, nil)
+
+		action := &keyRotationAction{
+			clock:          clock.New(),
+			targets:        targets,
+			c2Client:       mockC2Client,
+			targetResolver: mockTargetResolver,
+			splay:          splay,
+			errorChan:      errorChan,
+			logger:         logger,
+		}
+
+		start := time.Now()
+		outcomes, err := action.Execute(context.Background())
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if len(outcomes) != len(names) {
+			t.Fatalf("Expected %d outcomes, got %d", len(names), len(outcomes))
+		}
+
+		for _, outcome := range outcomes {
+			if !outcome.Pending || len(outcome.Error) > 0 {
+				t.Errorf("Expected outcome to be pending without error, got %#v", outcome)
+			}
+		}
+
+		rotatedAt := make(map[string]time.Duration)
+		mockC2Client.EXPECT().NewClientKey(gomock.Any(), gomock.Any()).Times(len(names)).DoAndReturn(
+			func(_ context.Context, name string) error {
+				rotatedAt[name] = time.Since(start)
+				return nil
+			},
+		)
+
+		var rotated []string
+		action.Complete(context.Background(), func(index int, err error) {
+			if err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+
+			rotated = append(rotated, outcomes[index].Target)
+		})
+
+		if len(rotated) != len(names) {
+			t.Fatalf("Expected %d completed outcomes, got %d", len(names), len(rotated))
+		}
+
+		for i, name := range rotated {
+			offset := splayOffset(resolvedTarget{target: targets[0], name: name}, splay)
+			if rotatedAt[name] < offset {
+				t.Errorf("Expected %s to be rotated after %v, got %v", name, offset, rotatedAt[name])
+			}
+
+			if i > 0 {
+				prev := splayOffset(resolvedTarget{target: targets[0], name: rotated[i-1]}, splay)
+				if offset < prev {
+					t.Errorf("Expected rotations to be ordered by offset, got %s (%v) after %s (%v)", name, offset, rotated[i-1], prev)
+				}
+			}
+		}
+	})
+
+	t.Run("Complete fails the remaining rotations when its context is done", func(t *testing.T) {
+		mockTargetResolver := NewMockTargetResolver(mockCtrl)
+
+		targets := []models.Target{
+			models.Target{Type: pb.TargetType_CLIENT, Expr: "client-.*"},
+		}
+
+		mockTargetResolver.EXPECT().Resolve(gomock.Any(), targets[0]).Return([]string{"client-1", "client-2"}, nil)
+
+		action := &keyRotationAction{
+			clock:          clock.NewFake(time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)),
+			targets:        targets,
+			c2Client:       mockC2Client,
+			targetResolver: mockTargetResolver,
+			splay:          time.Hour,
+			errorChan:      errorChan,
+			logger:         logger,
+		}
+
+		if _, err := action.Execute(context.Background()); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		completed := make(map[int]error)
+		action.Complete(ctx, func(index int, err error) {
+			completed[index] = err
+		})
+
+		if len(completed) != 2 {
+			t.Fatalf("Expected 2 completed outcomes, got %d", len(completed))
+		}
+
+		for index, err := range completed {
+			if err != context.Canceled {
+				t.Errorf("Expected outcome #%d error to be %v, got %v", index, context.Canceled, err)
+			}
+		}
+	})
+
+	t.Run("Execute does not wait for the splay in dry-run mode", func(t *testing.T) {
+		mockTargetResolver := NewMockTargetResolver(mockCtrl)
+
+		targets := []models.Target{
+			models.Target{Type: pb.TargetType_CLIENT, Expr: "client-.*"},
+		}
+
+		gomock.InOrder(
+			mockTargetResolver.EXPECT().Resolve(gomock.Any(), targets[0]).Return([]string{"client-1", "client-2"}, nil),
+			mockC2Client.EXPECT().NewClientKey(gomock.Any(), "client-1"),
+			mockC2Client.EXPECT().NewClientKey(gomock.Any(), "client-2"),
+		)
+
+		action := &keyRotationAction{
+			clock:          clock.NewFake(time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)),
+			targets:        targets,
+			c2Client:       mockC2Client,
+			targetResolver: mockTargetResolver,
+			splay:          time.Hour,
+			dryRun:         true,
+			errorChan:      errorChan,
+			logger:         logger,
+		}
+
+		outcomes, err := action.Execute(context.Background())
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if len(outcomes) != 2 {
+			t.Errorf("Expected 2 outcomes, got %d", len(outcomes))
+		}
+
+		for _, outcome := range outcomes {
+			if outcome.Pending {
+				t.Errorf("Expected dry-run outcome not to be pending")
+			}
+		}
+	})
+
+	t.Run("Execute aborts without rotating any key when too many targets are resolved", func(t *testing.T) {
+		mockTargetResolver := NewMockTargetResolver(mockCtrl)
+
+		targets := []models.Target{
+			models.Target{Type: pb.TargetType_CLIENT, Expr: ".*"},
+		}
+
+		mockTargetResolver.EXPECT().Resolve(gomock.Any(), targets[0]).Return([]string{"client1", "client2", "client3"}, nil)
+
+		action := &keyRotationAction{
+			clock:          clock.New(),
+			targets:        targets,
+			c2Client:       mockC2Client,
+			targetResolver: mockTargetResolver,
+			maxTargets:     2,
+			errorChan:      errorChan,
+			logger:         logger,
+		}
+
+		go action.Execute(context.Background())
+
+		select {
+		case err := <-errorChan:
+			terr, ok := err.(TooManyTargets)
 			if !ok {
-				t.Errorf("Expected an UnsupportedTargetType error, got %T", err)
+				t.Fatalf("Expected a TooManyTargets error, got %T", err)
 			}
 
-			if reflect.DeepEqual(terr.Action, action) == false {
-				t.Errorf("Expected error action to be %#v, got %#v", action, terr.Action)
+			if terr.Count != 3 || terr.Max != 2 {
+				t.Errorf("Expected error to report 3 targets for a max of 2, got %d and %d", terr.Count, terr.Max)
 			}
+		case <-time.After(10 * time.Millisecond):
+			t.Errorf("Expected a TooManyTargets error")
+		}
+	})
+}
+
+func TestRemoveClientAction(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockC2Client := services.NewMockC2(mockCtrl)
+
+	errorChan := make(chan error)
+
+	logger := log.New()
+	logger.SetOutput(ioutil.Discard)
+
+	t.Run("Execute removes every resolved client", func(t *testing.T) {
+		targets := []models.Target{
+			models.Target{Type: pb.TargetType_CLIENT, Expr: "client1"},
+			models.Target{Type: pb.TargetType_CLIENT, Expr: "client2"},
+		}
+
+		gomock.InOrder(
+			mockC2Client.EXPECT().RemoveClient(gomock.Any(), "client1"),
+			mockC2Client.EXPECT().RemoveClient(gomock.Any(), "client2"),
+		)
+
+		action := &removeClientAction{
+			targets:        targets,
+			c2Client:       mockC2Client,
+			targetResolver: NewTargetResolver(mockC2Client, DefaultResolverPageSize),
+			errorChan:      errorChan,
+			logger:         logger,
+		}
+
+		go action.Execute(context.Background())
+
+		select {
+		case err := <-errorChan:
+			t.Errorf("Expected no error, got %s", err)
+		case <-time.After(10 * time.Millisecond):
+		}
+	})
+
+	t.Run("Execute returns an error when some targets failed", func(t *testing.T) {
+		action := &removeClientAction{
+			targets: []models.Target{
+				models.Target{Type: pb.TargetType_CLIENT, Expr: "client1"},
+				models.Target{Type: pb.TargetType_CLIENT, Expr: "client2"},
+			},
+			c2Client:       mockC2Client,
+			targetResolver: NewTargetResolver(mockC2Client, DefaultResolverPageSize),
+			errorChan:      errorChan,
+			logger:         logger,
+		}
+
+		gomock.InOrder(
+			mockC2Client.EXPECT().RemoveClient(gomock.Any(), "client1").Return(errors.New("remove failed")),
+			mockC2Client.EXPECT().RemoveClient(gomock.Any(), "client2"),
+		)
 
-			if terr.TargetTypeName != pb.TargetType_name[int32(pb.TargetType_ANY)] {
-				t.Errorf(
-					"Expected error target type name to be %s, got %s",
-					pb.TargetType_name[int32(pb.TargetType_ANY)],
-					terr.TargetTypeName,
-				)
+		outcomes, err := action.Execute(context.Background())
+		expectedErr := ExecutionFailed{Action: action, Failed: 1}
+		if err != expectedErr {
+			t.Errorf("Expected error to be %#v, got %#v", expectedErr, err)
+		}
+
+		expectedOutcomes := []models.ExecutionOutcome{
+			models.ExecutionOutcome{
+				ActionType: pb.ActionType_REMOVE_CLIENT,
+				TargetType: pb.TargetType_CLIENT,
+				Target:     "client1",
+				Error:      "remove failed",
+			},
+			models.ExecutionOutcome{
+				ActionType: pb.ActionType_REMOVE_CLIENT,
+				TargetType: pb.TargetType_CLIENT,
+				Target:     "client2",
+			},
+		}
+		if reflect.DeepEqual(outcomes, expectedOutcomes) == false {
+			t.Errorf("Expected outcomes to be %#v, got %#v", expectedOutcomes, outcomes)
+		}
+	})
+
+	t.Run("Execute reports TOPIC targets as unsupported", func(t *testing.T) {
+		action := &removeClientAction{
+			targets:        []models.Target{models.Target{Type: pb.TargetType_TOPIC, Expr: "topic1"}},
+			c2Client:       mockC2Client,
+			targetResolver: NewTargetResolver(mockC2Client, DefaultResolverPageSize),
+			errorChan:      errorChan,
+			logger:         logger,
+		}
+
+		go action.Execute(context.Background())
+
+		select {
+		case err := <-errorChan:
+			if _, ok := err.(UnsupportedTargetType); !ok {
+				t.Errorf("Expected an UnsupportedTargetType error, got %T", err)
 			}
 		case <-time.After(10 * time.Millisecond):
 			t.Errorf("Expected an UnsupportedTargetType error")
 		}
+	})
+}
+
+func TestUnlinkClientTopicAction(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockC2Client := services.NewMockC2(mockCtrl)
+
+	errorChan := make(chan error)
+
+	logger := log.New()
+	logger.SetOutput(ioutil.Discard)
+
+	t.Run("Execute unlinks every resolved client from every resolved topic", func(t *testing.T) {
+		targets := []models.Target{
+			models.Target{Type: pb.TargetType_CLIENT, Expr: "client1"},
+			models.Target{Type: pb.TargetType_TOPIC, Expr: "topic1"},
+			models.Target{Type: pb.TargetType_CLIENT, Expr: "client2"},
+			models.Target{Type: pb.TargetType_TOPIC, Expr: "topic2"},
+		}
+
+		gomock.InOrder(
+			mockC2Client.EXPECT().RemoveTopicClient(gomock.Any(), "client1", "topic1"),
+			mockC2Client.EXPECT().RemoveTopicClient(gomock.Any(), "client1", "topic2"),
+			mockC2Client.EXPECT().RemoveTopicClient(gomock.Any(), "client2", "topic1"),
+			mockC2Client.EXPECT().RemoveTopicClient(gomock.Any(), "client2", "topic2"),
+		)
+
+		action := &unlinkClientTopicAction{
+			targets:        targets,
+			c2Client:       mockC2Client,
+			targetResolver: NewTargetResolver(mockC2Client, DefaultResolverPageSize),
+			errorChan:      errorChan,
+			logger:         logger,
+		}
+
+		go action.Execute(context.Background())
+
+		select {
+		case err := <-errorChan:
+			t.Errorf("Expected no error, got %s", err)
+		case <-time.After(10 * time.Millisecond):
+		}
+	})
+
+	t.Run("Execute aborts when the client and topic pairs exceed the max targets", func(t *testing.T) {
+		mockTargetResolver := NewMockTargetResolver(mockCtrl)
+
+		targets := []models.Target{
+			models.Target{Type: pb.TargetType_CLIENT, Expr: "client.*"},
+			models.Target{Type: pb.TargetType_TOPIC, Expr: "topic.*"},
+		}
+
+		mockTargetResolver.EXPECT().Resolve(gomock.Any(), targets[0]).Return([]string{"client1", "client2", "client3"}, nil)
+		mockTargetResolver.EXPECT().Resolve(gomock.Any(), targets[1]).Return([]string{"topic1", "topic2", "topic3"}, nil)
+
+		action := &unlinkClientTopicAction{
+			targets:        targets,
+			c2Client:       mockC2Client,
+			targetResolver: mockTargetResolver,
+			maxTargets:     8,
+			errorChan:      errorChan,
+			logger:         logger,
+		}
+
+		go action.Execute(context.Background())
+
+		select {
+		case err := <-errorChan:
+			terr, ok := err.(TooManyTargets)
+			if !ok {
+				t.Fatalf("Expected a TooManyTargets error, got %T", err)
+			}
+
+			if terr.Count != 9 || terr.Max != 8 {
+				t.Errorf("Expected error to report 9 targets for a max of 8, got %d and %d", terr.Count, terr.Max)
+			}
+		case <-time.After(10 * time.Millisecond):
+			t.Errorf("Expected a TooManyTargets error")
+		}
+	})
+
+	t.Run("Execute does nothing without topic targets", func(t *testing.T) {
+		action := &unlinkClientTopicAction{
+			targets:        []models.Target{models.Target{Type: pb.TargetType_CLIENT, Expr: "client1"}},
+			c2Client:       mockC2Client,
+			targetResolver: NewTargetResolver(mockC2Client, DefaultResolverPageSize),
+			errorChan:      errorChan,
+			logger:         logger,
+		}
+
+		go action.Execute(context.Background())
+
+		select {
+		case err := <-errorChan:
+			t.Errorf("Expected no error, got %s", err)
+		case <-time.After(10 * time.Millisecond):
+		}
+	})
+}
+
+func TestResetTopicAction(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockC2Client := services.NewMockC2(mockCtrl)
+
+	errorChan := make(chan error)
+
+	logger := log.New()
+	logger.SetOutput(ioutil.Discard)
+
+	t.Run("Execute removes and creates again every resolved topic", func(t *testing.T) {
+		targets := []models.Target{
+			models.Target{Type: pb.TargetType_TOPIC, Expr: "topic1"},
+			models.Target{Type: pb.TargetType_TOPIC, Expr: "topic2"},
+		}
+
+		gomock.InOrder(
+			mockC2Client.EXPECT().RemoveTopic(gomock.Any(), "topic1"),
+			mockC2Client.EXPECT().NewTopicKey(gomock.Any(), "topic1"),
+			mockC2Client.EXPECT().RemoveTopic(gomock.Any(), "topic2").Return(errors.New("remove failed")),
+		)
+
+		action := &resetTopicAction{
+			targets:        targets,
+			c2Client:       mockC2Client,
+			targetResolver: NewTargetResolver(mockC2Client, DefaultResolverPageSize),
+			errorChan:      errorChan,
+			logger:         logger,
+		}
+
+		go action.Execute(context.Background())
 
 		select {
 		case err := <-errorChan:
-			t.Errorf("Expected only 1 error, got more: %s", err)
+			t.Errorf("Expected no error, got %s", err)
 		case <-time.After(10 * time.Millisecond):
 		}
 	})
@@ -106,7 +556,9 @@ func TestActionFactory(t *testing.T) {
 	logger := log.New()
 	logger.SetOutput(ioutil.Discard)
 
-	factory := NewActionFactory(mockC2Client, errorChan, logger)
+	mockTargetResolver := NewMockTargetResolver(mockCtrl)
+
+	factory := NewActionFactory(mockC2Client, mockTargetResolver, 10, false, clock.New(), errorChan, logger)
 	t.Run("Create keyRotationAction returns expected struct", func(t *testing.T) {
 		rule := models.Rule{
 			ActionType: pb.ActionType_KEY_ROTATION,
@@ -116,7 +568,7 @@ func TestActionFactory(t *testing.T) {
 			},
 		}
 
-		action, err := factory.Create(rule)
+		action, err := factory.Create(rule, TriggerContext{})
 		if err != nil {
 			t.Errorf("Expected create to not return error, got %s", err)
 		}
@@ -134,17 +586,169 @@ func TestActionFactory(t *testing.T) {
 			t.Errorf("Expected C2 client to be %p, got %p", mockC2Client, typedAction.c2Client)
 		}
 
+		if typedAction.targetResolver != mockTargetResolver {
+			t.Errorf("Expected target resolver to be %p, got %p", mockTargetResolver, typedAction.targetResolver)
+		}
+
+		if typedAction.maxTargets != 10 {
+			t.Errorf("Expected action maxTargets to be 10, got %d", typedAction.maxTargets)
+		}
+
 		if typedAction.errorChan != errorChan {
 			t.Errorf("Expected action errorChan to be %p, got %p", errorChan, typedAction.errorChan)
 		}
 	})
 
+	t.Run("Create keyRotationAction reads the splay from the time interval trigger", func(t *testing.T) {
+		triggerSettings := &pb.TriggerSettingsTimeInterval{Expr: "0 0 * * *", Splay: "10m"}
+		encoded, err := triggerSettings.Encode()
+		if err != nil {
+			t.Fatalf("Failed to encode trigger settings: %v", err)
+		}
+
+		rule := models.Rule{ActionType: pb.ActionType_KEY_ROTATION}
+		triggerCtx := TriggerContext{
+			Trigger: models.Trigger{TriggerType: pb.TriggerType_TIME_INTERVAL, Settings: encoded},
+		}
+
+		action, err := factory.Create(rule, triggerCtx)
+		if err != nil {
+			t.Fatalf("Expected create to not return error, got %s", err)
+		}
+
+		if splay := action.(*keyRotationAction).splay; splay != 10*time.Minute {
+			t.Errorf("Expected action splay to be %v, got %v", 10*time.Minute, splay)
+		}
+	})
+
+	t.Run("Create returns the action matching the rule action type", func(t *testing.T) {
+		testCases := map[pb.ActionType]Action{
+			pb.ActionType_KEY_ROTATION:        &keyRotationAction{},
+			pb.ActionType_REMOVE_CLIENT:       &removeClientAction{},
+			pb.ActionType_UNLINK_CLIENT_TOPIC: &unlinkClientTopicAction{},
+			pb.ActionType_RESET_TOPIC:         &resetTopicAction{},
+		}
+
+		for actionType, expectedAction := range testCases {
+			action, err := factory.Create(models.Rule{ActionType: actionType}, TriggerContext{})
+			if err != nil {
+				t.Errorf("Expected create to not return error, got %s", err)
+			}
+
+			if reflect.TypeOf(action) != reflect.TypeOf(expectedAction) {
+				t.Errorf("Expected action to be a %T, got %T", expectedAction, action)
+			}
+		}
+	})
+
+	t.Run("Create returns a webhook action with decoded settings", func(t *testing.T) {
+		rule := models.Rule{
+			ActionType:     pb.ActionType_WEBHOOK,
+			ActionSettings: []byte(`{"url":"http://example.com/hook","maxRetries":2}`),
+		}
+		triggerCtx := TriggerContext{Trigger: models.Trigger{ID: 1}, Time: time.Now()}
+
+		action, err := factory.Create(rule, triggerCtx)
+		if err != nil {
+			t.Fatalf("Expected create to not return error, got %s", err)
+		}
+
+		typedAction, ok := action.(*webhookAction)
+		if !ok {
+			t.Fatalf("Expected action to be a webhookAction, got %T", action)
+		}
+
+		expectedSettings := &pb.ActionSettingsWebhook{URL: "http://example.com/hook", MaxRetries: 2}
+		if reflect.DeepEqual(typedAction.settings, expectedSettings) == false {
+			t.Errorf("Expected action settings to be %#v, got %#v", expectedSettings, typedAction.settings)
+		}
+
+		if reflect.DeepEqual(typedAction.triggerCtx, triggerCtx) == false {
+			t.Errorf("Expected action triggerCtx to be %#v, got %#v", triggerCtx, typedAction.triggerCtx)
+		}
+	})
+
+	t.Run("Create returns error on invalid webhook settings", func(t *testing.T) {
+		rule := models.Rule{
+			ActionType:     pb.ActionType_WEBHOOK,
+			ActionSettings: []byte(`not_even_json`),
+		}
+
+		if _, err := factory.Create(rule, TriggerContext{}); err == nil {
+			t.Errorf("Expected an error when creating a webhook action with invalid settings")
+		}
+	})
+
+	t.Run("newWebhookAction returns error on unexpected settings type", func(t *testing.T) {
+		if _, err := newWebhookAction(ActionParams{}); err == nil {
+			t.Errorf("Expected an error when creating a webhook action without webhook settings")
+		}
+	})
+
+	t.Run("Create returns a pipeline when the rule holds a list of actions", func(t *testing.T) {
+		rule := models.Rule{
+			Actions: []models.Action{
+				models.Action{ActionType: pb.ActionType_KEY_ROTATION, OnFailure: pb.ActionFailurePolicy_CONTINUE},
+				models.Action{
+					ActionType:         pb.ActionType_RESET_TOPIC,
+					OnFailure:          pb.ActionFailurePolicy_COMPENSATE,
+					CompensateType:     pb.ActionType_WEBHOOK,
+					CompensateSettings: []byte(`{"url":"http://example.com/hook"}`),
+				},
+			},
+		}
+
+		action, err := factory.Create(rule, TriggerContext{})
+		if err != nil {
+			t.Fatalf("Expected create to not return error, got %s", err)
+		}
+
+		pipeline, ok := action.(*pipelineAction)
+		if !ok {
+			t.Fatalf("Expected action to be a pipelineAction, got %T", action)
+		}
+
+		if len(pipeline.steps) != 2 {
+			t.Fatalf("Expected 2 pipeline steps, got %d", len(pipeline.steps))
+		}
+
+		if _, ok := pipeline.steps[0].action.(*keyRotationAction); !ok {
+			t.Errorf("Expected first step action to be a keyRotationAction, got %T", pipeline.steps[0].action)
+		}
+		if pipeline.steps[0].onFailure != pb.ActionFailurePolicy_CONTINUE {
+			t.Errorf("Expected first step failure policy to be CONTINUE, got %s", pipeline.steps[0].onFailure)
+		}
+		if pipeline.steps[0].compensate != nil {
+			t.Errorf("Expected first step to not have a compensating action, got %T", pipeline.steps[0].compensate)
+		}
+
+		if _, ok := pipeline.steps[1].action.(*resetTopicAction); !ok {
+			t.Errorf("Expected second step action to be a resetTopicAction, got %T", pipeline.steps[1].action)
+		}
+		if _, ok := pipeline.steps[1].compensate.(*webhookAction); !ok {
+			t.Errorf("Expected second step compensating action to be a webhookAction, got %T", pipeline.steps[1].compensate)
+		}
+	})
+
+	t.Run("Create returns error when one of the rule actions is invalid", func(t *testing.T) {
+		rule := models.Rule{
+			Actions: []models.Action{
+				models.Action{ActionType: pb.ActionType_KEY_ROTATION},
+				models.Action{ActionType: pb.ActionType_UNDEFINED_ACTION},
+			},
+		}
+
+		if _, err := factory.Create(rule, TriggerContext{}); err == nil {
+			t.Errorf("Expected an error when creating a pipeline with an invalid action")
+		}
+	})
+
 	t.Run("Create returns error on unsupported action type", func(t *testing.T) {
 		rule := models.Rule{
 			ActionType: pb.ActionType_UNDEFINED_ACTION,
 		}
 
-		action, err := factory.Create(rule)
+		action, err := factory.Create(rule, TriggerContext{})
 		if err == nil {
 			t.Errorf("Expected an error when creating an unsupported type of action")
 		}
@@ -154,3 +758,22 @@ func TestActionFactory(t *testing.T) {
 		}
 	})
 }
+
+func TestSplayOffset(t *testing.T) {
+	splay := 10 * time.Minute
+	client := resolvedTarget{target: models.Target{Type: pb.TargetType_CLIENT}, name: "sensor-1"}
+	topic := resolvedTarget{target: models.Target{Type: pb.TargetType_TOPIC}, name: "sensor-1"}
+
+	offset := splayOffset(client, splay)
+	if offset < 0 || offset >= splay {
+		t.Errorf("Expected offset to be within [0, %v), got %v", splay, offset)
+	}
+
+	if again := splayOffset(client, splay); again != offset {
+		t.Errorf("Expected offset to be deterministic, got %v then %v", offset, again)
+	}
+
+	if splayOffset(topic, splay) == offset {
+		t.Errorf("Expected client and topic with the same name to have different offsets")
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright 2020 Teserakt AG
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+//go:generate mockgen -copyright_file ../../../doc/COPYRIGHT_TEMPLATE.txt -destination=targets_mocks.go -package actions -self_package github.com/teserakt-io/automation-engine/internal/engine/actions github.com/teserakt-io/automation-engine/internal/engine/actions TargetResolver
+
+import (
+	"context"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	"go.opencensus.io/trace"
+
+	"github.com/teserakt-io/automation-engine/internal/models"
+	"github.com/teserakt-io/automation-engine/internal/pb"
+	"github.com/teserakt-io/automation-engine/internal/services"
+)
+
+// DefaultResolverPageSize defines how many clients or topics are fetched
+// at once from the C2 when resolving a target expression.
+const DefaultResolverPageSize int64 = 100
+
+// TargetResolver expands a target expression to the list of C2 clients or topics names it matches
+type TargetResolver interface {
+	Resolve(ctx context.Context, target models.Target) ([]string, error)
+}
+
+type targetResolver struct {
+	c2Client services.C2
+	pageSize int64
+}
+
+var _ TargetResolver = (*targetResolver)(nil)
+
+// NewTargetResolver creates a new TargetResolver, listing clients and topics from given C2 client
+func NewTargetResolver(c2Client services.C2, pageSize int64) TargetResolver {
+	return &targetResolver{
+		c2Client: c2Client,
+		pageSize: pageSize,
+	}
+}
+
+// Resolve returns the names of all the clients or topics matching the target expression.
+// Literal expressions are returned as is, without querying the C2.
+func (r *targetResolver) Resolve(ctx context.Context, target models.Target) ([]string, error) {
+	ctx, span := trace.StartSpan(ctx, "TargetResolver.Resolve")
+	defer span.End()
+
+	if target.IsLiteral() {
+		return []string{target.Expr}, nil
+	}
+
+	re, err := target.Regexp()
+	if err != nil {
+		return nil, fmt.Errorf("invalid target expression: %v", err)
+	}
+
+	var list func(ctx context.Context, offset, count int64) ([]string, error)
+	switch target.Type {
+	case pb.TargetType_CLIENT:
+		list = r.c2Client.GetClients
+	case pb.TargetType_TOPIC:
+		list = r.c2Client.GetTopics
+	default:
+		return nil, fmt.Errorf("cannot resolve target of type %s", pb.TargetType_name[int32(target.Type)])
+	}
+
+	var matches []string
+	for offset := int64(0); ; offset += r.pageSize {
+		names, err := list(ctx, offset, r.pageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, name := range names {
+			if re.MatchString(name) {
+				matches = append(matches, name)
+			}
+		}
+
+		if int64(len(names)) < r.pageSize {
+			break
+		}
+	}
+
+	return matches, nil
+}
+
+// resolvedTarget holds a single client or topic name obtained from a rule target
+type resolvedTarget struct {
+	target models.Target
+	name   string
+}
+
+// outcome returns the outcome of the given action type execution on the resolved target
+func (r resolvedTarget) outcome(actionType pb.ActionType, err error) models.ExecutionOutcome {
+	return newOutcome(actionType, r.target.Type, r.name, err)
+}
+
+func (r resolvedTarget) logger(logger log.FieldLogger) log.FieldLogger {
+	return logger.WithFields(log.Fields{
+		"target":     r.target.Expr,
+		"targetType": pb.TargetType_name[int32(r.target.Type)],
+		"name":       r.name,
+	})
+}
+
+// resolveTargets expands the targets to the clients and topics names they match, and
+// returns them along with a failed outcome for each target which could not be resolved.
+// Targets of a type the actionType definition doesn't support are reported as UnsupportedTargetType
+// on the errorChan, and skipped. ANY targets are skipped too, as they only match EVENT triggers.
+// When more than maxTargets names are resolved, a TooManyTargets error is
+// reported on the errorChan and returned, meaning the action must not be executed.
+func resolveTargets(
+	ctx context.Context,
+	action Action,
+	actionType pb.ActionType,
+	resolver TargetResolver,
+	targets []models.Target,
+	maxTargets int,
+	errorChan chan<- error,
+	logger log.FieldLogger,
+) ([]resolvedTarget, []models.ExecutionOutcome, error) {
+	definition, ok := pb.LookupAction(actionType)
+	if !ok {
+		return nil, nil, fmt.Errorf("unknown action type %d", actionType)
+	}
+
+	var resolvedTargets []resolvedTarget
+	var unresolved []models.ExecutionOutcome
+	for _, target := range targets {
+		targetLogger := logger.WithFields(log.Fields{
+			"target":     target.Expr,
+			"targetType": pb.TargetType_name[int32(target.Type)],
+		})
+
+		if !definition.SupportsTargetType(target.Type) {
+			err := UnsupportedTargetType{Action: action, TargetTypeName: pb.TargetType_name[int32(target.Type)]}
+			errorChan <- err
+			targetLogger.WithError(err).Error("failed to execute action")
+
+			continue
+		}
+
+		if target.Type == pb.TargetType_ANY {
+			targetLogger.Debug("skipped ANY target")
+
+			continue
+		}
+
+		names, err := resolver.Resolve(ctx, target)
+		if err != nil {
+			targetLogger.WithError(err).Error("failed to resolve target")
+			unresolved = append(unresolved, newOutcome(actionType, target.Type, target.Expr, err))
+
+			continue
+		}
+
+		if len(names) == 0 {
+			targetLogger.Warn("target did not match any client or topic")
+		}
+
+		for _, name := range names {
+			resolvedTargets = append(resolvedTargets, resolvedTarget{target: target, name: name})
+		}
+	}
+
+	if maxTargets > 0 && len(resolvedTargets) > maxTargets {
+		err := TooManyTargets{Action: action, Count: len(resolvedTargets), Max: maxTargets}
+		errorChan <- err
+		logger.WithError(err).Error("aborted action execution")
+
+		return nil, unresolved, err
+	}
+
+	return resolvedTargets, unresolved, nil
+}
@@ -0,0 +1,65 @@
+// Copyright 2020 Teserakt AG
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/teserakt-io/automation-engine/internal/engine/actions (interfaces: TargetResolver)
+
+// Package actions is a generated GoMock package.
+package actions
+
+import (
+	context "context"
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/teserakt-io/automation-engine/internal/models"
+	reflect "reflect"
+)
+
+// MockTargetResolver is a mock of TargetResolver interface
+type MockTargetResolver struct {
+	ctrl     *gomock.Controller
+	recorder *MockTargetResolverMockRecorder
+}
+
+// MockTargetResolverMockRecorder is the mock recorder for MockTargetResolver
+type MockTargetResolverMockRecorder struct {
+	mock *MockTargetResolver
+}
+
+// NewMockTargetResolver creates a new mock instance
+func NewMockTargetResolver(ctrl *gomock.Controller) *MockTargetResolver {
+	mock := &MockTargetResolver{ctrl: ctrl}
+	mock.recorder = &MockTargetResolverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockTargetResolver) EXPECT() *MockTargetResolverMockRecorder {
+	return m.recorder
+}
+
+// Resolve mocks base method
+func (m *MockTargetResolver) Resolve(arg0 context.Context, arg1 models.Target) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resolve", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Resolve indicates an expected call of Resolve
+func (mr *MockTargetResolverMockRecorder) Resolve(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resolve", reflect.TypeOf((*MockTargetResolver)(nil).Resolve), arg0, arg1)
+}
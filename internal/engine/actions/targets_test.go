@@ -0,0 +1,102 @@
+// Copyright 2020 Teserakt AG
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/teserakt-io/automation-engine/internal/models"
+	"github.com/teserakt-io/automation-engine/internal/pb"
+	"github.com/teserakt-io/automation-engine/internal/services"
+)
+
+func TestTargetResolver(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockC2Client := services.NewMockC2(mockCtrl)
+
+	resolver := NewTargetResolver(mockC2Client, 2)
+	ctx := context.Background()
+
+	t.Run("Resolve returns literal expressions without querying the C2", func(t *testing.T) {
+		names, err := resolver.Resolve(ctx, models.Target{Type: pb.TargetType_CLIENT, Expr: "client1"})
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+
+		expectedNames := []string{"client1"}
+		if reflect.DeepEqual(names, expectedNames) == false {
+			t.Errorf("Expected names to be %v, got %v", expectedNames, names)
+		}
+	})
+
+	t.Run("Resolve pages through the C2 clients and returns the matching ones", func(t *testing.T) {
+		gomock.InOrder(
+			mockC2Client.EXPECT().GetClients(gomock.Any(), int64(0), int64(2)).Return([]string{"weather-station-east", "other"}, nil),
+			mockC2Client.EXPECT().GetClients(gomock.Any(), int64(2), int64(2)).Return([]string{"weather-station-west", "weather-station-"}, nil),
+			mockC2Client.EXPECT().GetClients(gomock.Any(), int64(4), int64(2)).Return([]string{"not-weather-station-north"}, nil),
+		)
+
+		names, err := resolver.Resolve(ctx, models.Target{Type: pb.TargetType_CLIENT, Expr: "weather-station-.+"})
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+
+		expectedNames := []string{"weather-station-east", "weather-station-west"}
+		if reflect.DeepEqual(names, expectedNames) == false {
+			t.Errorf("Expected names to be %v, got %v", expectedNames, names)
+		}
+	})
+
+	t.Run("Resolve pages through the C2 topics and returns the matching ones", func(t *testing.T) {
+		gomock.InOrder(
+			mockC2Client.EXPECT().GetTopics(gomock.Any(), int64(0), int64(2)).Return([]string{"/devices/a", "/devices/b"}, nil),
+			mockC2Client.EXPECT().GetTopics(gomock.Any(), int64(2), int64(2)).Return(nil, nil),
+		)
+
+		names, err := resolver.Resolve(ctx, models.Target{Type: pb.TargetType_TOPIC, Expr: "/devices/.*"})
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+
+		expectedNames := []string{"/devices/a", "/devices/b"}
+		if reflect.DeepEqual(names, expectedNames) == false {
+			t.Errorf("Expected names to be %v, got %v", expectedNames, names)
+		}
+	})
+
+	t.Run("Resolve forwards C2 errors", func(t *testing.T) {
+		expectedErr := errors.New("c2 is down")
+		mockC2Client.EXPECT().GetTopics(gomock.Any(), int64(0), int64(2)).Return(nil, expectedErr)
+
+		_, err := resolver.Resolve(ctx, models.Target{Type: pb.TargetType_TOPIC, Expr: ".*"})
+		if err != expectedErr {
+			t.Errorf("Expected error to be %v, got %v", expectedErr, err)
+		}
+	})
+
+	t.Run("Resolve returns an error on unsupported target types", func(t *testing.T) {
+		_, err := resolver.Resolve(ctx, models.Target{Type: pb.TargetType_ANY, Expr: ".*"})
+		if err == nil {
+			t.Errorf("Expected an error when resolving an ANY target")
+		}
+	})
+}
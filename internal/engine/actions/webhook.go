@@ -0,0 +1,309 @@
+// Copyright 2020 Teserakt AG
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	log "github.com/sirupsen/logrus"
+	"go.opencensus.io/trace"
+
+	"github.com/teserakt-io/automation-engine/internal/clock"
+	"github.com/teserakt-io/automation-engine/internal/models"
+	"github.com/teserakt-io/automation-engine/internal/pb"
+)
+
+const (
+	// DefaultWebhookRetryDelay is the delay before retrying a failed webhook delivery.
+	// It is doubled after each failed attempt.
+	DefaultWebhookRetryDelay = time.Second
+
+	// WebhookSignatureHeader is the request header holding the hex encoded HMAC-SHA256
+	// of the request body, computed with the webhook secret, when one is configured.
+	WebhookSignatureHeader = "X-C2ae-Signature"
+)
+
+// WebhookPayload is the body sent by the webhook action, either json encoded
+// or as the data given to the configured body template.
+type WebhookPayload struct {
+	Rule    WebhookRule    `json:"rule"`
+	Trigger WebhookTrigger `json:"trigger"`
+	Event   *WebhookEvent  `json:"event,omitempty"`
+}
+
+// WebhookRule describes the rule which executed the webhook action.
+// Action is set on single action rules, and Actions on rules executing a list of actions.
+type WebhookRule struct {
+	ID           int             `json:"id"`
+	Description  string          `json:"description"`
+	Action       string          `json:"action,omitempty"`
+	Actions      []string        `json:"actions,omitempty"`
+	LastExecuted time.Time       `json:"lastExecuted"`
+	Targets      []WebhookTarget `json:"targets"`
+}
+
+// WebhookTarget describes one of the rule targets
+type WebhookTarget struct {
+	Type string `json:"type"`
+	Expr string `json:"expr"`
+}
+
+// WebhookTrigger describes the trigger which caused the rule execution
+type WebhookTrigger struct {
+	ID       int             `json:"id"`
+	Type     string          `json:"type"`
+	Settings json.RawMessage `json:"settings,omitempty"`
+	Time     time.Time       `json:"time"`
+}
+
+// WebhookEvent describes the C2 event which caused the trigger to fire
+type WebhookEvent struct {
+	Type      string    `json:"type"`
+	Source    string    `json:"source"`
+	Target    string    `json:"target"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// WebhookStatusError is returned when the webhook endpoint responds with a non 2xx status code
+type WebhookStatusError struct {
+	StatusCode int
+	Status     string
+}
+
+func (e WebhookStatusError) Error() string {
+	return fmt.Sprintf("webhook responded with status %s", e.Status)
+}
+
+// Retryable returns true when the delivery may succeed on a later attempt
+func (e WebhookStatusError) Retryable() bool {
+	return e.StatusCode >= http.StatusInternalServerError || e.StatusCode == http.StatusTooManyRequests
+}
+
+type webhookAction struct {
+	rule       models.Rule
+	triggerCtx TriggerContext
+	settings   *pb.ActionSettingsWebhook
+	httpClient *http.Client
+	retryDelay time.Duration
+	clock      clock.Clock
+	// dryRun skips sending the request, it is only recorded in the outcome
+	dryRun bool
+	logger log.FieldLogger
+
+	errorChan chan<- error
+}
+
+var _ Action = &webhookAction{}
+
+// Execute sends an HTTP request describing the rule execution to the configured URL,
+// retrying with an exponential backoff on network errors and 5xx or 429 responses.
+// A single outcome is returned, targeting the webhook URL.
+// In dry-run mode, the body is rendered but the request is not sent.
+func (a *webhookAction) Execute(ctx context.Context) ([]models.ExecutionOutcome, error) {
+	ctx, span := trace.StartSpan(ctx, "WebhookAction.Execute")
+	defer span.End()
+
+	logger := a.logger.WithFields(log.Fields{
+		"action": "webhook",
+		"rule":   a.rule.ID,
+		"url":    a.settings.URL,
+	})
+
+	body, err := a.body()
+	if err != nil {
+		err = fmt.Errorf("failed to build webhook body: %v", err)
+		a.errorChan <- err
+		logger.WithError(err).Error("failed to execute action")
+
+		return []models.ExecutionOutcome{a.outcome(err)}, err
+	}
+
+	if a.dryRun {
+		outcome := a.outcome(nil)
+		outcome.DryRunCalls = fmt.Sprintf("%s %s", a.settings.HTTPMethod(), a.settings.URL)
+		logger.WithField("call", outcome.DryRunCalls).Info("dry run, webhook request not sent")
+
+		return []models.ExecutionOutcome{outcome}, nil
+	}
+
+	err = logResult(logger, a.send(ctx, body, logger))
+
+	return []models.ExecutionOutcome{a.outcome(err)}, err
+}
+
+func (a *webhookAction) outcome(err error) models.ExecutionOutcome {
+	return newOutcome(pb.ActionType_WEBHOOK, pb.TargetType_ANY, a.settings.URL, err)
+}
+
+func (a *webhookAction) send(ctx context.Context, body []byte, logger log.FieldLogger) error {
+	var err error
+	for attempt := 0; attempt <= a.settings.MaxRetries; attempt++ {
+		if attempt > 0 {
+			delay := a.retryDelay << uint(attempt-1)
+			logger.WithError(err).WithFields(log.Fields{
+				"attempt": attempt,
+				"delay":   delay,
+			}).Warn("webhook delivery failed, retrying")
+
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-a.clock.After(delay):
+			}
+		}
+
+		err = a.deliver(ctx, body)
+		if err == nil {
+			return nil
+		}
+
+		if statusErr, ok := err.(WebhookStatusError); ok && !statusErr.Retryable() {
+			return err
+		}
+	}
+
+	return err
+}
+
+func (a *webhookAction) deliver(ctx context.Context, body []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, a.settings.TimeoutDuration())
+	defer cancel()
+
+	req, err := http.NewRequest(a.settings.HTTPMethod(), a.settings.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+
+	req.Header.Set("Content-Type", a.settings.ContentTypeHeader())
+	for name, value := range a.settings.Headers {
+		req.Header.Set(name, value)
+	}
+
+	if len(a.settings.Secret) > 0 {
+		req.Header.Set(WebhookSignatureHeader, signWebhookBody(a.settings.Secret, body))
+	}
+
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Drain the body so the connection can be reused
+	io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return WebhookStatusError{StatusCode: resp.StatusCode, Status: resp.Status}
+	}
+
+	return nil
+}
+
+// body returns the request body, rendered from the body template when one is configured,
+// or the json encoded payload otherwise.
+func (a *webhookAction) body() ([]byte, error) {
+	payload, err := a.payload()
+	if err != nil {
+		return nil, err
+	}
+
+	tpl, err := a.settings.Template()
+	if err != nil {
+		return nil, err
+	}
+
+	if tpl == nil {
+		return json.Marshal(payload)
+	}
+
+	buf := bytes.NewBuffer(nil)
+	if err := tpl.Execute(buf, payload); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (a *webhookAction) payload() (WebhookPayload, error) {
+	payload := WebhookPayload{
+		Rule: WebhookRule{
+			ID:           a.rule.ID,
+			Description:  a.rule.Description,
+			LastExecuted: a.rule.LastExecuted,
+			Targets:      []WebhookTarget{},
+		},
+		Trigger: WebhookTrigger{
+			ID:       a.triggerCtx.Trigger.ID,
+			Type:     a.triggerCtx.Trigger.TriggerType.String(),
+			Settings: json.RawMessage(a.triggerCtx.Trigger.Settings),
+			Time:     a.triggerCtx.Time,
+		},
+	}
+
+	if len(a.rule.Actions) == 0 {
+		payload.Rule.Action = a.rule.ActionType.String()
+	}
+
+	for _, action := range a.rule.Actions {
+		payload.Rule.Actions = append(payload.Rule.Actions, action.ActionType.String())
+	}
+
+	for _, target := range a.rule.Targets {
+		payload.Rule.Targets = append(payload.Rule.Targets, WebhookTarget{
+			Type: target.Type.String(),
+			Expr: target.Expr,
+		})
+	}
+
+	if evt := a.triggerCtx.Event; evt != nil {
+		var timestamp time.Time
+		if evt.Timestamp != nil {
+			var err error
+			timestamp, err = ptypes.Timestamp(evt.Timestamp)
+			if err != nil {
+				return WebhookPayload{}, err
+			}
+		}
+
+		payload.Event = &WebhookEvent{
+			Type:      evt.Type.String(),
+			Source:    evt.Source,
+			Target:    evt.Target,
+			Timestamp: timestamp,
+		}
+	}
+
+	return payload, nil
+}
+
+// signWebhookBody returns the hex encoded HMAC-SHA256 of body, using secret as key
+func signWebhookBody(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,313 @@
+// Copyright 2020 Teserakt AG
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	log "github.com/sirupsen/logrus"
+	c2pb "github.com/teserakt-io/c2/pkg/pb"
+
+	"github.com/teserakt-io/automation-engine/internal/clock"
+	"github.com/teserakt-io/automation-engine/internal/models"
+	"github.com/teserakt-io/automation-engine/internal/pb"
+)
+
+func TestWebhookAction(t *testing.T) {
+	errorChan := make(chan error)
+
+	logger := log.New()
+	logger.SetOutput(ioutil.Discard)
+
+	rule := models.Rule{
+		ID:          1,
+		Description: "notify on unsubscriptions",
+		ActionType:  pb.ActionType_WEBHOOK,
+		Targets:     []models.Target{models.Target{Type: pb.TargetType_CLIENT, Expr: "client.*"}},
+	}
+
+	eventTime := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	eventTimestamp, err := ptypes.TimestampProto(eventTime)
+	if err != nil {
+		t.Fatalf("Failed to create event timestamp: %v", err)
+	}
+
+	triggerCtx := TriggerContext{
+		Trigger: models.Trigger{
+			ID:          2,
+			TriggerType: pb.TriggerType_EVENT,
+			Settings:    []byte(`{"eventType":"CLIENT_UNSUBSCRIBED","maxOccurrence":1}`),
+		},
+		Time: eventTime,
+		Event: &c2pb.Event{
+			Type:      c2pb.EventType_CLIENT_UNSUBSCRIBED,
+			Source:    "client1",
+			Target:    "topic1",
+			Timestamp: eventTimestamp,
+		},
+	}
+
+	newWebhookAction := func(settings *pb.ActionSettingsWebhook) *webhookAction {
+		return &webhookAction{
+			clock:      clock.New(),
+			rule:       rule,
+			triggerCtx: triggerCtx,
+			settings:   settings,
+			httpClient: &http.Client{},
+			retryDelay: time.Millisecond,
+			errorChan:  errorChan,
+			logger:     logger,
+		}
+	}
+
+	t.Run("Execute sends the json payload with configured headers and signature", func(t *testing.T) {
+		var received int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&received, 1)
+
+			if r.Method != http.MethodPut {
+				t.Errorf("Expected method to be PUT, got %s", r.Method)
+			}
+
+			if r.Header.Get("Authorization") != "Bearer token" {
+				t.Errorf("Expected Authorization header to be set, got %s", r.Header.Get("Authorization"))
+			}
+
+			if r.Header.Get("Content-Type") != "application/json" {
+				t.Errorf("Expected Content-Type to be application/json, got %s", r.Header.Get("Content-Type"))
+			}
+
+			body, err := ioutil.ReadAll(r.Body)
+			if err != nil {
+				t.Fatalf("Failed to read request body: %v", err)
+			}
+
+			expectedSignature := signWebhookBody("s3cr3t", body)
+			if r.Header.Get(WebhookSignatureHeader) != expectedSignature {
+				t.Errorf("Expected signature to be %s, got %s", expectedSignature, r.Header.Get(WebhookSignatureHeader))
+			}
+
+			payload := WebhookPayload{}
+			if err := json.Unmarshal(body, &payload); err != nil {
+				t.Fatalf("Failed to decode payload: %v", err)
+			}
+
+			if payload.Rule.ID != rule.ID || payload.Rule.Action != "WEBHOOK" || len(payload.Rule.Targets) != 1 {
+				t.Errorf("Unexpected payload rule: %#v", payload.Rule)
+			}
+
+			if payload.Trigger.ID != triggerCtx.Trigger.ID || payload.Trigger.Type != "EVENT" || !payload.Trigger.Time.Equal(eventTime) {
+				t.Errorf("Unexpected payload trigger: %#v", payload.Trigger)
+			}
+
+			expectedEvent := &WebhookEvent{
+				Type:      "CLIENT_UNSUBSCRIBED",
+				Source:    "client1",
+				Target:    "topic1",
+				Timestamp: eventTime,
+			}
+			if payload.Event == nil || *payload.Event != *expectedEvent {
+				t.Errorf("Expected payload event to be %#v, got %#v", expectedEvent, payload.Event)
+			}
+		}))
+		defer server.Close()
+
+		action := newWebhookAction(&pb.ActionSettingsWebhook{
+			URL:     server.URL,
+			Method:  http.MethodPut,
+			Headers: map[string]string{"Authorization": "Bearer token"},
+			Secret:  "s3cr3t",
+		})
+
+		action.Execute(context.Background())
+
+		if atomic.LoadInt32(&received) != 1 {
+			t.Errorf("Expected webhook to be called once, got %d", received)
+		}
+	})
+
+	t.Run("Execute renders the body template", func(t *testing.T) {
+		bodyChan := make(chan string, 1)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Content-Type") != "text/plain" {
+				t.Errorf("Expected Content-Type to be text/plain, got %s", r.Header.Get("Content-Type"))
+			}
+
+			body, _ := ioutil.ReadAll(r.Body)
+			bodyChan <- string(body)
+		}))
+		defer server.Close()
+
+		action := newWebhookAction(&pb.ActionSettingsWebhook{
+			URL:          server.URL,
+			BodyTemplate: "rule {{.Rule.ID}} triggered by {{.Event.Source}}",
+			ContentType:  "text/plain",
+		})
+
+		action.Execute(context.Background())
+
+		expectedBody := "rule 1 triggered by client1"
+		select {
+		case body := <-bodyChan:
+			if body != expectedBody {
+				t.Errorf("Expected body to be %q, got %q", expectedBody, body)
+			}
+		default:
+			t.Errorf("Expected webhook to be called")
+		}
+	})
+
+	t.Run("Execute reports body template errors on the errorChan", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Errorf("Expected webhook to not be called")
+		}))
+		defer server.Close()
+
+		action := newWebhookAction(&pb.ActionSettingsWebhook{
+			URL:          server.URL,
+			BodyTemplate: "{{.Rule.Unknown}}",
+		})
+
+		go action.Execute(context.Background())
+
+		select {
+		case err := <-errorChan:
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		case <-time.After(100 * time.Millisecond):
+			t.Errorf("Expected an error on errorChan")
+		}
+	})
+
+	t.Run("Execute only records the request in dry-run mode", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Errorf("Expected webhook to not be called")
+		}))
+		defer server.Close()
+
+		action := newWebhookAction(&pb.ActionSettingsWebhook{URL: server.URL, Method: http.MethodPut})
+		action.dryRun = true
+
+		outcomes, err := action.Execute(context.Background())
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+
+		expectedOutcomes := []models.ExecutionOutcome{
+			models.ExecutionOutcome{
+				ActionType:  pb.ActionType_WEBHOOK,
+				TargetType:  pb.TargetType_ANY,
+				Target:      server.URL,
+				DryRunCalls: "PUT " + server.URL,
+			},
+		}
+		if reflect.DeepEqual(outcomes, expectedOutcomes) == false {
+			t.Errorf("Expected outcomes to be %#v, got %#v", expectedOutcomes, outcomes)
+		}
+	})
+
+	t.Run("Execute retries on server errors", func(t *testing.T) {
+		var received int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&received, 1) < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+		}))
+		defer server.Close()
+
+		action := newWebhookAction(&pb.ActionSettingsWebhook{URL: server.URL, MaxRetries: 5})
+		action.Execute(context.Background())
+
+		if atomic.LoadInt32(&received) != 3 {
+			t.Errorf("Expected webhook to be called 3 times, got %d", received)
+		}
+	})
+
+	t.Run("Execute stops retrying after MaxRetries", func(t *testing.T) {
+		var received int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&received, 1)
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		action := newWebhookAction(&pb.ActionSettingsWebhook{URL: server.URL, MaxRetries: 2})
+		action.Execute(context.Background())
+
+		if atomic.LoadInt32(&received) != 3 {
+			t.Errorf("Expected webhook to be called 3 times, got %d", received)
+		}
+	})
+
+	t.Run("Execute does not retry on client errors", func(t *testing.T) {
+		var received int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&received, 1)
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		defer server.Close()
+
+		action := newWebhookAction(&pb.ActionSettingsWebhook{URL: server.URL, MaxRetries: 2})
+		outcomes, err := action.Execute(context.Background())
+
+		expectedErr := WebhookStatusError{StatusCode: http.StatusBadRequest, Status: "400 Bad Request"}
+		if err != expectedErr {
+			t.Errorf("Expected error to be %#v, got %#v", expectedErr, err)
+		}
+
+		expectedOutcomes := []models.ExecutionOutcome{
+			models.ExecutionOutcome{
+				ActionType: pb.ActionType_WEBHOOK,
+				TargetType: pb.TargetType_ANY,
+				Target:     server.URL,
+				Error:      expectedErr.Error(),
+			},
+		}
+		if reflect.DeepEqual(outcomes, expectedOutcomes) == false {
+			t.Errorf("Expected outcomes to be %#v, got %#v", expectedOutcomes, outcomes)
+		}
+
+		if atomic.LoadInt32(&received) != 1 {
+			t.Errorf("Expected webhook to be called once, got %d", received)
+		}
+	})
+
+	t.Run("Execute retries when the request times out", func(t *testing.T) {
+		var received int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&received, 1) == 1 {
+				time.Sleep(50 * time.Millisecond)
+			}
+		}))
+		defer server.Close()
+
+		action := newWebhookAction(&pb.ActionSettingsWebhook{URL: server.URL, Timeout: "10ms", MaxRetries: 1})
+		action.Execute(context.Background())
+
+		if atomic.LoadInt32(&received) != 2 {
+			t.Errorf("Expected webhook to be called twice, got %d", received)
+		}
+	})
+}
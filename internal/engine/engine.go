@@ -14,52 +14,280 @@
 
 package engine
 
+//go:generate mockgen -copyright_file ../../doc/COPYRIGHT_TEMPLATE.txt -destination=engine_mocks.go -package engine -self_package github.com/teserakt-io/automation-engine/internal/engine github.com/teserakt-io/automation-engine/internal/engine AutomationEngine
+
 import (
 	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/jinzhu/gorm"
 	log "github.com/sirupsen/logrus"
 
+	"github.com/teserakt-io/automation-engine/internal/engine/actions"
 	"github.com/teserakt-io/automation-engine/internal/engine/watchers"
+	"github.com/teserakt-io/automation-engine/internal/events"
+	"github.com/teserakt-io/automation-engine/internal/models"
+	"github.com/teserakt-io/automation-engine/internal/pb"
 	"github.com/teserakt-io/automation-engine/internal/services"
 )
 
 // AutomationEngine interface describe the public methods available on the automation engine
 type AutomationEngine interface {
 	Start(context.Context) error
+	Reload(ctx context.Context, ruleIDs ...int) error
+	// RuleStatus returns the state of given rule and its triggers in the engine.
+	// Rules the engine has not loaded yet are reported stopped.
+	RuleStatus(ruleID int) models.RuleStatus
+	// Status returns the state of every rule known to the engine, and of the C2 event stream
+	Status() models.EngineStatus
+	// PendingContext returns the context the work left pending by the actions of given rule runs with.
+	// It outlives the rule reloads, and is cancelled once the rule is paused or deleted, or the engine stopped.
+	PendingContext(ruleID int) context.Context
+}
+
+// watcherStopTimeout bounds how long the engine waits for a ruleWatcher to return once stopped,
+// so a stuck ruleWatcher cannot block the engine, and the reloads of the other rules, forever.
+const watcherStopTimeout = 10 * time.Second
+
+// runningWatcher holds a started ruleWatcher, allowing to stop it
+type runningWatcher struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+	// stopped is set to 1 once the engine stopped the ruleWatcher
+	stopped *int32
+}
+
+// stop cancels the ruleWatcher and waits up to timeout for it to return.
+// It returns false when the ruleWatcher did not return in time.
+func (w runningWatcher) stop(timeout time.Duration) bool {
+	atomic.StoreInt32(w.stopped, 1)
+	w.cancel()
+
+	select {
+	case <-w.done:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
+
+// pendingContext holds the context of the pending work of a rule actions, allowing to cancel it
+type pendingContext struct {
+	ctx    context.Context
+	cancel context.CancelFunc
 }
 
 type automationEngine struct {
 	ruleService        services.RuleService
 	ruleWatcherFactory watchers.RuleWatcherFactory
+	statusTracker      watchers.StatusTracker
+	eventStreamer      events.Streamer
 	logger             log.FieldLogger
+	stopTimeout        time.Duration
+
+	lock     sync.Mutex
+	watchers map[int]runningWatcher
+	// ctx is the context the engine was started with, parent of the pending contexts
+	ctx     context.Context
+	pending map[int]pendingContext
 }
 
 var _ AutomationEngine = &automationEngine{}
 
-// NewAutomationEngine creates a new automation engine
+// NewAutomationEngine creates a new automation engine. The statusTracker must be the one
+// given to the watcher factories, so the engine reports the state recorded by its watchers,
+// and the eventStreamer the one streaming the events to its watchers.
 func NewAutomationEngine(
 	ruleService services.RuleService,
 	ruleWatcherFactory watchers.RuleWatcherFactory,
+	statusTracker watchers.StatusTracker,
+	eventStreamer events.Streamer,
 	logger log.FieldLogger,
 ) AutomationEngine {
 	return &automationEngine{
 		ruleService:        ruleService,
 		ruleWatcherFactory: ruleWatcherFactory,
+		statusTracker:      statusTracker,
+		eventStreamer:      eventStreamer,
 		logger:             logger,
+		stopTimeout:        watcherStopTimeout,
+		watchers:           make(map[int]runningWatcher),
+		ctx:                context.Background(),
+		pending:            make(map[int]pendingContext),
 	}
 }
 
+// Start starts a ruleWatcher for every enabled rule, running until ctx get cancelled.
+// Watchers previously started by the engine are stopped first, and their pending work cancelled.
 func (e *automationEngine) Start(ctx context.Context) error {
 	rules, err := e.ruleService.All(ctx)
 	if err != nil {
 		return err
 	}
 
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	for ruleID := range e.watchers {
+		e.stop(ruleID)
+	}
+
+	for ruleID := range e.pending {
+		e.cancelPending(ruleID)
+	}
+	e.ctx = ctx
+
 	for _, rule := range rules {
-		ruleWatcher := e.ruleWatcherFactory.Create(rule)
-		e.logger.WithField("rule", rule.ID).Info("started ruleWatcher")
-		go ruleWatcher.Start(ctx)
+		if !rule.Enabled {
+			e.statusTracker.SetRuleState(rule.ID, pb.RuleWatcherState_RULE_PAUSED)
+			e.logger.WithField("rule", rule.ID).Info("rule is paused, ruleWatcher not started")
+
+			continue
+		}
+
+		e.start(ctx, rule.ID, e.ruleWatcherFactory.Create(rule))
 	}
 
 	return nil
 }
+
+// Reload stops the ruleWatchers of given rules, and starts them again from the rules
+// currently stored, using ctx as their parent context. Rules which do not exist anymore or are paused
+// are only stopped, and rules without a running ruleWatcher are started.
+// When some rules fail to be loaded, the others are still reloaded and the first error is returned.
+func (e *automationEngine) Reload(ctx context.Context, ruleIDs ...int) error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	var firstErr error
+	for _, ruleID := range ruleIDs {
+		e.stop(ruleID)
+
+		rule, err := e.ruleService.ByID(ctx, ruleID)
+		if err == gorm.ErrRecordNotFound {
+			e.cancelPending(ruleID)
+			e.statusTracker.RemoveRule(ruleID)
+			e.logger.WithField("rule", ruleID).Info("rule removed, ruleWatcher not restarted")
+
+			continue
+		}
+		if err != nil {
+			e.logger.WithError(err).WithField("rule", ruleID).Error("failed to load rule")
+			e.statusTracker.RuleError(ruleID, fmt.Errorf("failed to load rule: %v", err))
+			if firstErr == nil {
+				firstErr = err
+			}
+
+			continue
+		}
+
+		if !rule.Enabled {
+			e.cancelPending(ruleID)
+			e.statusTracker.SetRuleState(ruleID, pb.RuleWatcherState_RULE_PAUSED)
+			e.logger.WithField("rule", ruleID).Info("rule is paused, ruleWatcher not restarted")
+
+			continue
+		}
+
+		e.start(ctx, rule.ID, e.ruleWatcherFactory.Create(rule))
+	}
+
+	return firstErr
+}
+
+// start runs the ruleWatcher and registers it for the given rule. Callers must hold the engine lock.
+func (e *automationEngine) start(ctx context.Context, ruleID int, ruleWatcher watchers.RuleWatcher) {
+	watcherCtx, cancel := context.WithCancel(ctx)
+	watcherCtx = actions.WithPendingContext(watcherCtx, e.pendingContext(ruleID))
+	running := runningWatcher{
+		cancel:  cancel,
+		done:    make(chan struct{}),
+		stopped: new(int32),
+	}
+
+	e.statusTracker.SetRuleState(ruleID, pb.RuleWatcherState_RULE_RUNNING)
+
+	go func() {
+		defer close(running.done)
+		ruleWatcher.Start(watcherCtx)
+
+		// The rule is also stopped when its watcher returns on its own, like when it has no triggers.
+		// Once stopped by the engine, the rule state belongs to the engine, which may have restarted it already.
+		if atomic.LoadInt32(running.stopped) == 0 {
+			e.statusTracker.SetRuleState(ruleID, pb.RuleWatcherState_RULE_STOPPED)
+		}
+	}()
+
+	e.watchers[ruleID] = running
+	e.logger.WithField("rule", ruleID).Info("started ruleWatcher")
+}
+
+// stop stops the ruleWatcher of given rule, if any, and waits for it to return, up to the engine stopTimeout.
+// Callers must hold the engine lock.
+func (e *automationEngine) stop(ruleID int) {
+	running, ok := e.watchers[ruleID]
+	if !ok {
+		return
+	}
+
+	delete(e.watchers, ruleID)
+	if !running.stop(e.stopTimeout) {
+		e.logger.WithFields(log.Fields{
+			"rule":    ruleID,
+			"timeout": e.stopTimeout,
+		}).Error("ruleWatcher did not return in time, not waiting for it anymore")
+	}
+
+	e.statusTracker.SetRuleState(ruleID, pb.RuleWatcherState_RULE_STOPPED)
+	e.logger.WithField("rule", ruleID).Info("stopped ruleWatcher")
+}
+
+func (e *automationEngine) PendingContext(ruleID int) context.Context {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	return e.pendingContext(ruleID)
+}
+
+// pendingContext returns the pending context of given rule, creating it when needed.
+// Callers must hold the engine lock.
+func (e *automationEngine) pendingContext(ruleID int) context.Context {
+	pending, ok := e.pending[ruleID]
+	if !ok {
+		pending.ctx, pending.cancel = context.WithCancel(e.ctx)
+		e.pending[ruleID] = pending
+	}
+
+	return pending.ctx
+}
+
+// cancelPending cancels the pending work of given rule actions, if any. Callers must hold the engine lock.
+func (e *automationEngine) cancelPending(ruleID int) {
+	pending, ok := e.pending[ruleID]
+	if !ok {
+		return
+	}
+
+	delete(e.pending, ruleID)
+	pending.cancel()
+	e.logger.WithField("rule", ruleID).Info("cancelled rule pending actions")
+}
+
+func (e *automationEngine) RuleStatus(ruleID int) models.RuleStatus {
+	status, ok := e.statusTracker.RuleStatus(ruleID)
+	if !ok {
+		return models.RuleStatus{RuleID: ruleID, State: pb.RuleWatcherState_RULE_STOPPED}
+	}
+
+	return status
+}
+
+func (e *automationEngine) Status() models.EngineStatus {
+	return models.EngineStatus{
+		Rules:       e.statusTracker.RuleStatuses(),
+		EventStream: e.eventStreamer.Status(),
+	}
+}
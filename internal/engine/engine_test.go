@@ -18,14 +18,20 @@ import (
 	"context"
 	"errors"
 	"io/ioutil"
+	"reflect"
 	"testing"
 	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/jinzhu/gorm"
 	log "github.com/sirupsen/logrus"
 
+	"github.com/teserakt-io/automation-engine/internal/clock"
+	"github.com/teserakt-io/automation-engine/internal/engine/actions"
 	"github.com/teserakt-io/automation-engine/internal/engine/watchers"
+	"github.com/teserakt-io/automation-engine/internal/events"
 	"github.com/teserakt-io/automation-engine/internal/models"
+	"github.com/teserakt-io/automation-engine/internal/pb"
 	"github.com/teserakt-io/automation-engine/internal/services"
 )
 
@@ -41,16 +47,17 @@ func TestAutomationEngine(t *testing.T) {
 
 	mockRuleService := services.NewMockRuleService(mockCtrl)
 	mockRuleWatcherFactory := watchers.NewMockRuleWatcherFactory(mockCtrl)
+	mockEventStreamer := events.NewMockStreamer(mockCtrl)
 
 	logger := log.New()
 	logger.SetOutput(ioutil.Discard)
 
-	engine := NewAutomationEngine(mockRuleService, mockRuleWatcherFactory, logger)
+	engine := NewAutomationEngine(mockRuleService, mockRuleWatcherFactory, watchers.NewStatusTracker(clock.New()), mockEventStreamer, logger)
 
 	rules := []models.Rule{
-		models.Rule{ID: 1},
-		models.Rule{ID: 2},
-		models.Rule{ID: 3},
+		models.Rule{ID: 1, Enabled: true},
+		models.Rule{ID: 2, Enabled: true},
+		models.Rule{ID: 3, Enabled: true},
 	}
 
 	mockRuleWatcher1 := watchers.NewMockRuleWatcher(mockCtrl)
@@ -97,4 +104,331 @@ func TestAutomationEngine(t *testing.T) {
 			t.Errorf("Expected error to be %v, got %v", expectedError, err)
 		}
 	})
+
+	t.Run("Reload only restarts the rule watchers of given rules", func(t *testing.T) {
+		engine := NewAutomationEngine(mockRuleService, mockRuleWatcherFactory, watchers.NewStatusTracker(clock.New()), mockEventStreamer, logger)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		ruleWatcher1 := watchers.NewMockRuleWatcher(mockCtrl)
+		ruleWatcher2 := watchers.NewMockRuleWatcher(mockCtrl)
+		ruleWatcher3 := watchers.NewMockRuleWatcher(mockCtrl)
+
+		stopped := make(chan int, 3)
+		startWatcher := func(ruleID int) func(context.Context) {
+			return func(ctx context.Context) {
+				<-ctx.Done()
+				stopped <- ruleID
+			}
+		}
+
+		mockRuleService.EXPECT().All(gomock.Any()).Times(1).Return(rules, nil)
+		mockRuleWatcherFactory.EXPECT().Create(rules[0]).Times(1).Return(ruleWatcher1)
+		mockRuleWatcherFactory.EXPECT().Create(rules[1]).Times(1).Return(ruleWatcher2)
+		mockRuleWatcherFactory.EXPECT().Create(rules[2]).Times(1).Return(ruleWatcher3)
+		ruleWatcher1.EXPECT().Start(gomock.Any()).Times(1).DoAndReturn(startWatcher(1))
+		ruleWatcher2.EXPECT().Start(gomock.Any()).Times(1).DoAndReturn(startWatcher(2))
+		ruleWatcher3.EXPECT().Start(gomock.Any()).Times(1).DoAndReturn(startWatcher(3))
+
+		if err := engine.Start(ctx); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		updatedRule := models.Rule{ID: 2, Description: "updated", Enabled: true}
+		newRule := models.Rule{ID: 4, Enabled: true}
+		mockUpdatedRuleWatcher := watchers.NewMockRuleWatcher(mockCtrl)
+		mockNewRuleWatcher := watchers.NewMockRuleWatcher(mockCtrl)
+
+		mockRuleService.EXPECT().ByID(gomock.Any(), 2).Times(1).Return(updatedRule, nil)
+		mockRuleService.EXPECT().ByID(gomock.Any(), 3).Times(1).Return(models.Rule{}, gorm.ErrRecordNotFound)
+		mockRuleService.EXPECT().ByID(gomock.Any(), 4).Times(1).Return(newRule, nil)
+		mockRuleWatcherFactory.EXPECT().Create(updatedRule).Times(1).Return(mockUpdatedRuleWatcher)
+		mockRuleWatcherFactory.EXPECT().Create(newRule).Times(1).Return(mockNewRuleWatcher)
+		mockUpdatedRuleWatcher.EXPECT().Start(gomock.Any()).Times(1).DoAndReturn(startWatcher(2))
+		mockNewRuleWatcher.EXPECT().Start(gomock.Any()).Times(1).DoAndReturn(startWatcher(4))
+
+		if err := engine.Reload(ctx, 2, 3, 4); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// Reload waits for the watchers to be stopped
+		for _, expectedID := range []int{2, 3} {
+			select {
+			case ruleID := <-stopped:
+				if ruleID != expectedID {
+					t.Errorf("Expected rule %d watcher to be stopped, got %d", expectedID, ruleID)
+				}
+			default:
+				t.Errorf("Expected rule %d watcher to be stopped", expectedID)
+			}
+		}
+
+		select {
+		case ruleID := <-stopped:
+			t.Errorf("Expected no other watcher to be stopped, got %d", ruleID)
+		default:
+		}
+	})
+
+	t.Run("Reload still reloads the other rules when one fails to be loaded", func(t *testing.T) {
+		engine := NewAutomationEngine(mockRuleService, mockRuleWatcherFactory, watchers.NewStatusTracker(clock.New()), mockEventStreamer, logger)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		expectedError := errors.New("ruleService ByID() failed")
+		rule := models.Rule{ID: 2, Enabled: true}
+		ruleWatcher := watchers.NewMockRuleWatcher(mockCtrl)
+
+		mockRuleService.EXPECT().ByID(gomock.Any(), 1).Times(1).Return(models.Rule{}, expectedError)
+		mockRuleService.EXPECT().ByID(gomock.Any(), 2).Times(1).Return(rule, nil)
+		mockRuleWatcherFactory.EXPECT().Create(rule).Times(1).Return(ruleWatcher)
+		ruleWatcher.EXPECT().Start(gomock.Any()).Times(1).DoAndReturn(func(ctx context.Context) {
+			<-ctx.Done()
+		})
+
+		if err := engine.Reload(ctx, 1, 2); err != expectedError {
+			t.Errorf("Expected error to be %v, got %v", expectedError, err)
+		}
+	})
+
+	t.Run("Reload does not wait forever for a stuck rule watcher", func(t *testing.T) {
+		statusTracker := watchers.NewStatusTracker(clock.New())
+		engine := NewAutomationEngine(mockRuleService, mockRuleWatcherFactory, statusTracker, mockEventStreamer, logger)
+		engine.(*automationEngine).stopTimeout = 10 * time.Millisecond
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		rule := models.Rule{ID: 7, Enabled: true}
+		stuckRuleWatcher := watchers.NewMockRuleWatcher(mockCtrl)
+		unblock := make(chan struct{})
+		defer close(unblock)
+
+		mockRuleService.EXPECT().All(gomock.Any()).Times(1).Return([]models.Rule{rule}, nil)
+		mockRuleWatcherFactory.EXPECT().Create(rule).Times(1).Return(stuckRuleWatcher)
+		stuckRuleWatcher.EXPECT().Start(gomock.Any()).Times(1).DoAndReturn(func(ctx context.Context) {
+			<-unblock
+		})
+
+		if err := engine.Start(ctx); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		rule.Enabled = false
+		mockRuleService.EXPECT().ByID(gomock.Any(), rule.ID).Times(1).Return(rule, nil)
+
+		reloaded := make(chan error)
+		go func() {
+			reloaded <- engine.Reload(ctx, rule.ID)
+		}()
+
+		select {
+		case err := <-reloaded:
+			if err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Expected Reload to return")
+		}
+
+		if state := engine.RuleStatus(rule.ID).State; state != pb.RuleWatcherState_RULE_PAUSED {
+			t.Errorf("Expected rule to be paused, got %s", state)
+		}
+	})
+
+	t.Run("Paused rules are not started", func(t *testing.T) {
+		engine := NewAutomationEngine(mockRuleService, mockRuleWatcherFactory, watchers.NewStatusTracker(clock.New()), mockEventStreamer, logger)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		pausedRule := models.Rule{ID: 5, Enabled: false}
+		enabledRule := models.Rule{ID: 6, Enabled: true}
+		ruleWatcher := watchers.NewMockRuleWatcher(mockCtrl)
+		stopped := make(chan struct{})
+
+		mockRuleService.EXPECT().All(gomock.Any()).Times(1).Return([]models.Rule{pausedRule, enabledRule}, nil)
+		mockRuleWatcherFactory.EXPECT().Create(enabledRule).Times(1).Return(ruleWatcher)
+		ruleWatcher.EXPECT().Start(gomock.Any()).Times(1).DoAndReturn(func(ctx context.Context) {
+			<-ctx.Done()
+			close(stopped)
+		})
+
+		if err := engine.Start(ctx); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// Pausing the enabled rule stops its watcher without starting a new one
+		enabledRule.Enabled = false
+		mockRuleService.EXPECT().ByID(gomock.Any(), enabledRule.ID).Times(1).Return(enabledRule, nil)
+
+		if err := engine.Reload(ctx, enabledRule.ID); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		select {
+		case <-stopped:
+		default:
+			t.Errorf("Expected paused rule watcher to be stopped")
+		}
+	})
+
+	t.Run("Pending contexts outlive reloads until the rule is paused or deleted", func(t *testing.T) {
+		engine := NewAutomationEngine(mockRuleService, mockRuleWatcherFactory, watchers.NewStatusTracker(clock.New()), mockEventStreamer, logger)
+
+		ctx, cancel := context.WithCancel(context.Background())
+
+		rule := models.Rule{ID: 7, Enabled: true}
+		startWatcher := func(ctx context.Context) {
+			<-ctx.Done()
+		}
+
+		var watcherPendingCtx context.Context
+		ruleWatcher := watchers.NewMockRuleWatcher(mockCtrl)
+		mockRuleService.EXPECT().All(gomock.Any()).Times(1).Return([]models.Rule{rule}, nil)
+		mockRuleWatcherFactory.EXPECT().Create(rule).Times(1).Return(ruleWatcher)
+		ruleWatcher.EXPECT().Start(gomock.Any()).Times(1).DoAndReturn(func(ctx context.Context) {
+			watcherPendingCtx = actions.PendingContext(ctx)
+			<-ctx.Done()
+		})
+
+		if err := engine.Start(ctx); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		pendingCtx := engine.PendingContext(rule.ID)
+
+		reloadedWatcher := watchers.NewMockRuleWatcher(mockCtrl)
+		mockRuleService.EXPECT().ByID(gomock.Any(), rule.ID).Times(1).Return(rule, nil)
+		mockRuleWatcherFactory.EXPECT().Create(rule).Times(1).Return(reloadedWatcher)
+		reloadedWatcher.EXPECT().Start(gomock.Any()).Times(1).DoAndReturn(startWatcher)
+
+		if err := engine.Reload(ctx, rule.ID); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if watcherPendingCtx != pendingCtx {
+			t.Errorf("Expected the rule watcher to execute actions with the rule pending context")
+		}
+
+		if err := pendingCtx.Err(); err != nil {
+			t.Errorf("Expected pending context to outlive the reload, got %v", err)
+		}
+
+		if engine.PendingContext(rule.ID) != pendingCtx {
+			t.Errorf("Expected the pending context to be kept on reload")
+		}
+
+		// Pausing the rule cancels its pending context
+		pausedRule := models.Rule{ID: 7, Enabled: false}
+		mockRuleService.EXPECT().ByID(gomock.Any(), rule.ID).Times(1).Return(pausedRule, nil)
+
+		if err := engine.Reload(ctx, rule.ID); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if err := pendingCtx.Err(); err != context.Canceled {
+			t.Errorf("Expected pending context to be cancelled once the rule is paused, got %v", err)
+		}
+
+		// Deleting the rule cancels its pending context
+		pendingCtx = engine.PendingContext(rule.ID)
+		mockRuleService.EXPECT().ByID(gomock.Any(), rule.ID).Times(1).Return(models.Rule{}, gorm.ErrRecordNotFound)
+
+		if err := engine.Reload(ctx, rule.ID); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if err := pendingCtx.Err(); err != context.Canceled {
+			t.Errorf("Expected pending context to be cancelled once the rule is deleted, got %v", err)
+		}
+
+		// Stopping the engine cancels every pending context
+		pendingCtx = engine.PendingContext(rule.ID)
+		cancel()
+
+		if err := pendingCtx.Err(); err != context.Canceled {
+			t.Errorf("Expected pending context to be cancelled once the engine is stopped, got %v", err)
+		}
+	})
+
+	t.Run("RuleStatus reports the state of the rules in the engine", func(t *testing.T) {
+		engine := NewAutomationEngine(mockRuleService, mockRuleWatcherFactory, watchers.NewStatusTracker(clock.New()), mockEventStreamer, logger)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		pausedRule := models.Rule{ID: 7, Enabled: false}
+		enabledRule := models.Rule{ID: 8, Enabled: true}
+		ruleWatcher := watchers.NewMockRuleWatcher(mockCtrl)
+
+		mockRuleService.EXPECT().All(gomock.Any()).Times(1).Return([]models.Rule{pausedRule, enabledRule}, nil)
+		mockRuleWatcherFactory.EXPECT().Create(enabledRule).Times(1).Return(ruleWatcher)
+		ruleWatcher.EXPECT().Start(gomock.Any()).Times(1).DoAndReturn(func(ctx context.Context) {
+			<-ctx.Done()
+		})
+
+		if err := engine.Start(ctx); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		expectedStates := map[int]pb.RuleWatcherState{
+			pausedRule.ID:  pb.RuleWatcherState_RULE_PAUSED,
+			enabledRule.ID: pb.RuleWatcherState_RULE_RUNNING,
+			9:              pb.RuleWatcherState_RULE_STOPPED,
+		}
+		for ruleID, expectedState := range expectedStates {
+			status := engine.RuleStatus(ruleID)
+			if status.RuleID != ruleID || status.State != expectedState {
+				t.Errorf("Expected rule %d to be %s, got %#v", ruleID, expectedState, status)
+			}
+		}
+
+		// A rule failing to be reloaded is stopped, and reports why
+		expectedError := errors.New("ruleService ByID() failed")
+		mockRuleService.EXPECT().ByID(gomock.Any(), enabledRule.ID).Times(1).Return(models.Rule{}, expectedError)
+
+		if err := engine.Reload(ctx, enabledRule.ID); err != expectedError {
+			t.Errorf("Expected error to be %v, got %v", expectedError, err)
+		}
+
+		status := engine.RuleStatus(enabledRule.ID)
+		if status.State != pb.RuleWatcherState_RULE_STOPPED || status.ErrorCount != 1 || len(status.LastError) == 0 {
+			t.Errorf("Expected rule to be stopped with an error, got %#v", status)
+		}
+	})
+
+	t.Run("Status reports the rules known to the engine and the event stream", func(t *testing.T) {
+		statusTracker := watchers.NewStatusTracker(clock.New())
+		engine := NewAutomationEngine(mockRuleService, mockRuleWatcherFactory, statusTracker, mockEventStreamer, logger)
+
+		statusTracker.SetRuleState(2, pb.RuleWatcherState_RULE_PAUSED)
+		statusTracker.SetRuleState(1, pb.RuleWatcherState_RULE_RUNNING)
+		statusTracker.TriggerCounted(1, 1, 3)
+
+		eventStreamStatus := models.EventStreamStatus{Connected: true, Listeners: 1}
+		mockEventStreamer.EXPECT().Status().Times(1).Return(eventStreamStatus)
+
+		expectedStatus := models.EngineStatus{
+			Rules: []models.RuleStatus{
+				models.RuleStatus{
+					RuleID:   1,
+					State:    pb.RuleWatcherState_RULE_RUNNING,
+					Triggers: []models.TriggerStatus{models.TriggerStatus{TriggerID: 1, Counter: 3}},
+				},
+				models.RuleStatus{
+					RuleID:   2,
+					State:    pb.RuleWatcherState_RULE_PAUSED,
+					Triggers: []models.TriggerStatus{},
+				},
+			},
+			EventStream: eventStreamStatus,
+		}
+
+		if status := engine.Status(); reflect.DeepEqual(status, expectedStatus) == false {
+			t.Errorf("Expected status to be %#v, got %#v", expectedStatus, status)
+		}
+	})
 }
@@ -22,10 +22,10 @@ import (
 
 	log "github.com/sirupsen/logrus"
 
+	"github.com/teserakt-io/automation-engine/internal/clock"
 	"github.com/teserakt-io/automation-engine/internal/engine/actions"
 	"github.com/teserakt-io/automation-engine/internal/events"
 	"github.com/teserakt-io/automation-engine/internal/models"
-	"github.com/teserakt-io/automation-engine/internal/pb"
 	"github.com/teserakt-io/automation-engine/internal/services"
 )
 
@@ -36,26 +36,40 @@ type RuleWatcherFactory interface {
 
 type ruleWatcherFactory struct {
 	ruleWriter            services.RuleWriter
+	executionService      services.ExecutionService
 	triggerWatcherFactory TriggerWatcherFactory
 	actionFactory         actions.ActionFactory
+	resumePolicy          string
+	clock                 clock.Clock
+	statusTracker         StatusTracker
 	errorChan             chan<- error
 	logger                log.FieldLogger
 }
 
 var _ RuleWatcherFactory = &ruleWatcherFactory{}
 
-// NewRuleWatcherFactory creates a new RuleWatcherFactory
+// NewRuleWatcherFactory creates a new RuleWatcherFactory. The resumePolicy, one of the config.ResumePolicy
+// constants, defines how the created watchers handle the runs missed while their rule was paused.
+// The created watchers record the state and errors of their rule and triggers in the statusTracker.
 func NewRuleWatcherFactory(
 	ruleWriter services.RuleWriter,
+	executionService services.ExecutionService,
 	triggerWatcherFactory TriggerWatcherFactory,
 	actionFactory actions.ActionFactory,
+	resumePolicy string,
+	clock clock.Clock,
+	statusTracker StatusTracker,
 	errorChan chan<- error,
 	logger log.FieldLogger,
 ) RuleWatcherFactory {
 	return &ruleWatcherFactory{
 		ruleWriter:            ruleWriter,
+		executionService:      executionService,
 		triggerWatcherFactory: triggerWatcherFactory,
 		actionFactory:         actionFactory,
+		resumePolicy:          resumePolicy,
+		clock:                 clock,
+		statusTracker:         statusTracker,
 		errorChan:             errorChan,
 		logger:                logger,
 	}
@@ -65,8 +79,12 @@ func (f *ruleWatcherFactory) Create(rule models.Rule) RuleWatcher {
 	return &ruleWatcher{
 		rule:                  rule,
 		ruleWriter:            f.ruleWriter,
+		executionService:      f.executionService,
 		triggerWatcherFactory: f.triggerWatcherFactory,
 		actionFactory:         f.actionFactory,
+		resumePolicy:          f.resumePolicy,
+		clock:                 f.clock,
+		statusTracker:         f.statusTracker,
 		triggeredChan:         make(chan TriggerEvent),
 		errorChan:             f.errorChan,
 		logger:                f.logger,
@@ -74,12 +92,14 @@ func (f *ruleWatcherFactory) Create(rule models.Rule) RuleWatcher {
 }
 
 // TriggerWatcherFactory allows to create trigger watchers from a given trigger
-// independently of the trigger type
+// independently of the trigger type. A non zero resumedAt means the runs missed
+// between lastExecuted and resumedAt, while the trigger rule was paused, must be fired once on resume.
 type TriggerWatcherFactory interface {
 	Create(
 		trigger models.Trigger,
 		targets []models.Target,
 		lastExecuted time.Time,
+		resumedAt time.Time,
 		triggeredChan chan<- TriggerEvent,
 		errorChan chan<- error,
 	) (TriggerWatcher, error)
@@ -90,17 +110,24 @@ type triggerWatcherFactory struct {
 	streamListenerFactory events.StreamListenerFactory
 	triggerStateService   services.TriggerStateService
 	validator             models.TriggerValidator
+	statusTracker         StatusTracker
+	clock                 clock.Clock
 }
 
 var _ TriggerWatcherFactory = (*triggerWatcherFactory)(nil)
 var _ TriggerWatcher = (*schedulerWatcher)(nil)
 var _ TriggerWatcher = (*eventWatcher)(nil)
+var _ TriggerWatcher = (*onceWatcher)(nil)
 
-// NewTriggerWatcherFactory creates a new watcher factory for given trigger
+// NewTriggerWatcherFactory creates a new watcher factory for given trigger.
+// The created watchers read the current time and wait for their schedules on the given clock,
+// and record their next fire time or event counter in the statusTracker.
 func NewTriggerWatcherFactory(
 	streamListenerFactory events.StreamListenerFactory,
 	triggerStateService services.TriggerStateService,
 	validator models.TriggerValidator,
+	statusTracker StatusTracker,
+	clock clock.Clock,
 	logger log.FieldLogger,
 ) TriggerWatcherFactory {
 	return &triggerWatcherFactory{
@@ -108,6 +135,8 @@ func NewTriggerWatcherFactory(
 		streamListenerFactory: streamListenerFactory,
 		triggerStateService:   triggerStateService,
 		validator:             validator,
+		statusTracker:         statusTracker,
+		clock:                 clock,
 	}
 }
 
@@ -115,40 +144,27 @@ func (f *triggerWatcherFactory) Create(
 	trigger models.Trigger,
 	targets []models.Target,
 	lastExecuted time.Time,
+	resumedAt time.Time,
 	triggeredChan chan<- TriggerEvent,
 	errorChan chan<- error,
 ) (TriggerWatcher, error) {
-	var watcher TriggerWatcher
-
-	switch trigger.TriggerType {
-	case pb.TriggerType_TIME_INTERVAL:
-		watcher = &schedulerWatcher{
-			validator:     f.validator,
-			trigger:       trigger,
-			triggeredChan: triggeredChan,
-			errorChan:     errorChan,
-			updateChan:    make(chan time.Time, 1),
-			lastExecuted:  lastExecuted,
-			logger:        f.logger,
-		}
-
-	case pb.TriggerType_EVENT:
-		watcher = &eventWatcher{
-			triggerStateService:   f.triggerStateService,
-			validator:             f.validator,
-			trigger:               trigger,
-			targets:               targets,
-			triggeredChan:         triggeredChan,
-			errorChan:             errorChan,
-			updateChan:            make(chan time.Time),
-			lastExecuted:          lastExecuted,
-			logger:                f.logger,
-			streamListenerFactory: f.streamListenerFactory,
-		}
-
-	default:
+	constructor, ok := lookupWatcherConstructor(trigger.TriggerType)
+	if !ok {
 		return nil, fmt.Errorf("TriggerWatcherFactory don't know how to handle trigger type %s", trigger.TriggerType)
 	}
 
-	return watcher, nil
+	return constructor(TriggerWatcherParams{
+		Trigger:               trigger,
+		Targets:               targets,
+		LastExecuted:          lastExecuted,
+		ResumedAt:             resumedAt,
+		TriggeredChan:         triggeredChan,
+		ErrorChan:             errorChan,
+		StreamListenerFactory: f.streamListenerFactory,
+		TriggerStateService:   f.triggerStateService,
+		Validator:             f.validator,
+		StatusTracker:         f.statusTracker,
+		Clock:                 f.clock,
+		Logger:                f.logger,
+	})
 }
@@ -50,18 +50,18 @@ func (m *MockTriggerWatcherFactory) EXPECT() *MockTriggerWatcherFactoryMockRecor
 }
 
 // Create mocks base method
-func (m *MockTriggerWatcherFactory) Create(arg0 models.Trigger, arg1 []models.Target, arg2 time.Time, arg3 chan<- TriggerEvent, arg4 chan<- error) (TriggerWatcher, error) {
+func (m *MockTriggerWatcherFactory) Create(arg0 models.Trigger, arg1 []models.Target, arg2, arg3 time.Time, arg4 chan<- TriggerEvent, arg5 chan<- error) (TriggerWatcher, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", arg0, arg1, arg2, arg3, arg4)
+	ret := m.ctrl.Call(m, "Create", arg0, arg1, arg2, arg3, arg4, arg5)
 	ret0, _ := ret[0].(TriggerWatcher)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Create indicates an expected call of Create
-func (mr *MockTriggerWatcherFactoryMockRecorder) Create(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+func (mr *MockTriggerWatcherFactoryMockRecorder) Create(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTriggerWatcherFactory)(nil).Create), arg0, arg1, arg2, arg3, arg4)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTriggerWatcherFactory)(nil).Create), arg0, arg1, arg2, arg3, arg4, arg5)
 }
 
 // MockRuleWatcherFactory is a mock of RuleWatcherFactory interface
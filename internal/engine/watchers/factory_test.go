@@ -15,6 +15,7 @@
 package watchers
 
 import (
+	"context"
 	"io/ioutil"
 	reflect "reflect"
 	"testing"
@@ -23,6 +24,8 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	log "github.com/sirupsen/logrus"
 
+	"github.com/teserakt-io/automation-engine/internal/clock"
+	"github.com/teserakt-io/automation-engine/internal/config"
 	"github.com/teserakt-io/automation-engine/internal/engine/actions"
 	"github.com/teserakt-io/automation-engine/internal/events"
 	"github.com/teserakt-io/automation-engine/internal/models"
@@ -35,18 +38,25 @@ func TestRuleWatcherFactory(t *testing.T) {
 	defer mockCtrl.Finish()
 
 	mockRuleWriter := services.NewMockRuleService(mockCtrl)
+	mockExecutionService := services.NewMockExecutionService(mockCtrl)
 	mockTriggerWatcherFactory := NewMockTriggerWatcherFactory(mockCtrl)
 	mockActionFactory := actions.NewMockActionFactory(mockCtrl)
 
 	errorChan := make(chan<- error)
+	testClock := clock.NewFake(time.Now())
+	statusTracker := NewStatusTracker(testClock)
 
 	logger := log.New()
 	logger.SetOutput(ioutil.Discard)
 
 	factory := NewRuleWatcherFactory(
 		mockRuleWriter,
+		mockExecutionService,
 		mockTriggerWatcherFactory,
 		mockActionFactory,
+		config.ResumePolicySkip,
+		testClock,
+		statusTracker,
 		errorChan,
 		logger,
 	)
@@ -69,6 +79,10 @@ func TestRuleWatcherFactory(t *testing.T) {
 			t.Errorf("Expected ruleWriter to be %p, got %p", mockRuleWriter, typedWatcher.ruleWriter)
 		}
 
+		if reflect.DeepEqual(typedWatcher.executionService, mockExecutionService) == false {
+			t.Errorf("Expected executionService to be %p, got %p", mockExecutionService, typedWatcher.executionService)
+		}
+
 		if reflect.DeepEqual(typedWatcher.triggerWatcherFactory, mockTriggerWatcherFactory) == false {
 			t.Errorf(
 				"Expected triggerWatcherFactory to be %p, got %p",
@@ -77,6 +91,18 @@ func TestRuleWatcherFactory(t *testing.T) {
 			)
 		}
 
+		if typedWatcher.clock != testClock {
+			t.Errorf("Expected clock to be %p, got %p", testClock, typedWatcher.clock)
+		}
+
+		if typedWatcher.statusTracker != statusTracker {
+			t.Errorf("Expected statusTracker to be %p, got %p", statusTracker, typedWatcher.statusTracker)
+		}
+
+		if typedWatcher.resumePolicy != config.ResumePolicySkip {
+			t.Errorf("Expected resumePolicy to be %s, got %s", config.ResumePolicySkip, typedWatcher.resumePolicy)
+		}
+
 		if reflect.DeepEqual(typedWatcher.errorChan, errorChan) == false {
 			t.Errorf("Expected errorChan to be %p, got %p", errorChan, typedWatcher.errorChan)
 		}
@@ -98,7 +124,9 @@ func TestTriggerWatcherFactory(t *testing.T) {
 	logger := log.New()
 	logger.SetOutput(ioutil.Discard)
 
-	factory := NewTriggerWatcherFactory(mockStreamListenerFactory, mockTriggerStateService, mockValidator, logger)
+	testClock := clock.NewFake(time.Now())
+	statusTracker := NewStatusTracker(testClock)
+	factory := NewTriggerWatcherFactory(mockStreamListenerFactory, mockTriggerStateService, mockValidator, statusTracker, testClock, logger)
 
 	expectedLastExecuted := time.Now()
 
@@ -110,7 +138,8 @@ func TestTriggerWatcherFactory(t *testing.T) {
 			TriggerType: pb.TriggerType_TIME_INTERVAL,
 		}
 
-		watcher, err := factory.Create(trigger, nil, expectedLastExecuted, triggeredChan, errorChan)
+		expectedResumedAt := expectedLastExecuted.Add(time.Hour)
+		watcher, err := factory.Create(trigger, nil, expectedLastExecuted, expectedResumedAt, triggeredChan, errorChan)
 		if err != nil {
 			t.Errorf("Expected no error, got %s", err)
 		}
@@ -132,6 +161,10 @@ func TestTriggerWatcherFactory(t *testing.T) {
 			)
 		}
 
+		if typedWatcher.resumedAt != expectedResumedAt {
+			t.Errorf("Expected watcher resumedAt to be %v, got %v", expectedResumedAt, typedWatcher.resumedAt)
+		}
+
 		if reflect.DeepEqual(typedWatcher.triggeredChan, triggeredChan) == false {
 			t.Errorf("Expected watcher triggeredChan to be %#v, got %#v", triggeredChan, typedWatcher.triggeredChan)
 		}
@@ -140,6 +173,14 @@ func TestTriggerWatcherFactory(t *testing.T) {
 			t.Errorf("Expected watcher errorChan to be %#v, got %#v", errorChan, typedWatcher.errorChan)
 		}
 
+		if typedWatcher.clock != testClock {
+			t.Errorf("Expected watcher clock to be %p, got %p", testClock, typedWatcher.clock)
+		}
+
+		if typedWatcher.statusTracker != statusTracker {
+			t.Errorf("Expected watcher statusTracker to be %p, got %p", statusTracker, typedWatcher.statusTracker)
+		}
+
 		if typedWatcher.updateChan == nil {
 			t.Errorf("Expected watcher updateChan to be not nil")
 		}
@@ -155,7 +196,7 @@ func TestTriggerWatcherFactory(t *testing.T) {
 			models.Target{ID: 2},
 			models.Target{ID: 3},
 		}
-		watcher, err := factory.Create(trigger, targets, expectedLastExecuted, triggeredChan, errorChan)
+		watcher, err := factory.Create(trigger, targets, expectedLastExecuted, time.Time{}, triggeredChan, errorChan)
 		if err != nil {
 			t.Errorf("Expected no error, got %s", err)
 		}
@@ -189,19 +230,120 @@ func TestTriggerWatcherFactory(t *testing.T) {
 			t.Errorf("Expected watcher targets to be %#v, got %#v", targets, typedWatcher.targets)
 		}
 
+		if typedWatcher.clock != testClock {
+			t.Errorf("Expected watcher clock to be %p, got %p", testClock, typedWatcher.clock)
+		}
+
+		if typedWatcher.updateChan == nil {
+			t.Errorf("Expected watcher updateChan to be not nil")
+		}
+	})
+
+	t.Run("Factory creates onceWatcher", func(t *testing.T) {
+		trigger := models.Trigger{
+			TriggerType: pb.TriggerType_ONCE,
+		}
+
+		watcher, err := factory.Create(trigger, nil, expectedLastExecuted, time.Time{}, triggeredChan, errorChan)
+		if err != nil {
+			t.Errorf("Expected no error, got %s", err)
+		}
+
+		typedWatcher, ok := watcher.(*onceWatcher)
+		if !ok {
+			t.Fatalf("Expected watcher to be a *onceWatcher, got a %T", watcher)
+		}
+
+		if reflect.DeepEqual(typedWatcher.trigger, trigger) == false {
+			t.Errorf("Expected watcher trigger to be %#v, got %#v", trigger, typedWatcher.trigger)
+		}
+
+		if reflect.DeepEqual(typedWatcher.triggerStateService, mockTriggerStateService) == false {
+			t.Errorf("Expected watcher triggerStateService to be %p, got %p", mockTriggerStateService, typedWatcher.triggerStateService)
+		}
+
+		if reflect.DeepEqual(typedWatcher.triggeredChan, triggeredChan) == false {
+			t.Errorf("Expected watcher triggeredChan to be %#v, got %#v", triggeredChan, typedWatcher.triggeredChan)
+		}
+
+		if reflect.DeepEqual(typedWatcher.errorChan, errorChan) == false {
+			t.Errorf("Expected watcher errorChan to be %#v, got %#v", errorChan, typedWatcher.errorChan)
+		}
+
+		if typedWatcher.clock != testClock {
+			t.Errorf("Expected watcher clock to be %p, got %p", testClock, typedWatcher.clock)
+		}
+
 		if typedWatcher.updateChan == nil {
 			t.Errorf("Expected watcher updateChan to be not nil")
 		}
 	})
 
+	t.Run("Factory creates watchers of registered trigger types", func(t *testing.T) {
+		customType := pb.TriggerType(1100)
+		RegisterTrigger(
+			pb.TriggerDefinition{
+				Type:        customType,
+				Name:        "CUSTOM_WATCHER_TEST",
+				NewSettings: func() pb.TriggerSettings { return &pb.TriggerSettingsOnce{} },
+			},
+			func(params TriggerWatcherParams) (TriggerWatcher, error) {
+				return &customTriggerWatcher{params: params}, nil
+			},
+		)
+
+		trigger := models.Trigger{
+			ID:          1,
+			TriggerType: customType,
+		}
+		targets := []models.Target{models.Target{ID: 1}}
+
+		watcher, err := factory.Create(trigger, targets, expectedLastExecuted, time.Time{}, triggeredChan, errorChan)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		typedWatcher, ok := watcher.(*customTriggerWatcher)
+		if !ok {
+			t.Fatalf("Expected watcher to be a *customTriggerWatcher, got %T", watcher)
+		}
+
+		expectedParams := TriggerWatcherParams{
+			Trigger:               trigger,
+			Targets:               targets,
+			LastExecuted:          expectedLastExecuted,
+			TriggeredChan:         triggeredChan,
+			ErrorChan:             errorChan,
+			StreamListenerFactory: mockStreamListenerFactory,
+			TriggerStateService:   mockTriggerStateService,
+			Validator:             mockValidator,
+			StatusTracker:         statusTracker,
+			Clock:                 testClock,
+			Logger:                logger,
+		}
+		if reflect.DeepEqual(typedWatcher.params, expectedParams) == false {
+			t.Errorf("Expected watcher params to be %#v, got %#v", expectedParams, typedWatcher.params)
+		}
+	})
+
 	t.Run("Factory returns error on unknown trigger type", func(t *testing.T) {
 		trigger := models.Trigger{
 			TriggerType: pb.TriggerType_UNDEFINED_TRIGGER,
 		}
 
-		_, err := factory.Create(trigger, nil, expectedLastExecuted, triggeredChan, errorChan)
+		_, err := factory.Create(trigger, nil, expectedLastExecuted, time.Time{}, triggeredChan, errorChan)
 		if err == nil {
 			t.Errorf("Expected error, got nil")
 		}
 	})
 }
+
+type customTriggerWatcher struct {
+	params TriggerWatcherParams
+}
+
+func (w *customTriggerWatcher) Start(ctx context.Context) {}
+
+func (w *customTriggerWatcher) UpdateLastExecuted(lastExecuted time.Time) error {
+	return nil
+}
@@ -0,0 +1,143 @@
+// Copyright 2020 Teserakt AG
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package watchers
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/teserakt-io/automation-engine/internal/clock"
+	"github.com/teserakt-io/automation-engine/internal/events"
+	"github.com/teserakt-io/automation-engine/internal/models"
+	"github.com/teserakt-io/automation-engine/internal/pb"
+	"github.com/teserakt-io/automation-engine/internal/services"
+)
+
+// TriggerWatcherConstructor creates a watcher for a trigger of a registered trigger type
+type TriggerWatcherConstructor func(TriggerWatcherParams) (TriggerWatcher, error)
+
+// TriggerWatcherParams holds what a TriggerWatcherConstructor needs to create a trigger watcher.
+// The watcher must send a TriggerEvent on TriggeredChan each time the trigger fires, and
+// report its errors on ErrorChan. Its UpdateLastExecuted must not block once its Start returned.
+// It may record its next fire time or event counter in the StatusTracker.
+type TriggerWatcherParams struct {
+	Trigger models.Trigger
+	// Targets are the targets of the trigger rule
+	Targets []models.Target
+	// LastExecuted is the last time the trigger rule has been executed
+	LastExecuted time.Time
+	// ResumedAt is set when the runs missed while the trigger rule was paused, from LastExecuted
+	// up to ResumedAt, must be fired once on resume, whatever the trigger misfire policy
+	ResumedAt     time.Time
+	TriggeredChan chan<- TriggerEvent
+	ErrorChan     chan<- error
+
+	StreamListenerFactory events.StreamListenerFactory
+	TriggerStateService   services.TriggerStateService
+	Validator             models.TriggerValidator
+	StatusTracker         StatusTracker
+	Clock                 clock.Clock
+	Logger                log.FieldLogger
+}
+
+var watcherConstructors = struct {
+	sync.RWMutex
+	byType map[pb.TriggerType]TriggerWatcherConstructor
+}{
+	byType: make(map[pb.TriggerType]TriggerWatcherConstructor),
+}
+
+func init() {
+	registerWatcherConstructor(pb.TriggerType_TIME_INTERVAL, newSchedulerWatcher)
+	registerWatcherConstructor(pb.TriggerType_EVENT, newEventWatcher)
+	registerWatcherConstructor(pb.TriggerType_ONCE, newOnceWatcher)
+}
+
+// RegisterTrigger registers a new trigger type, along with the constructor used by the
+// TriggerWatcherFactory to create its watchers. See pb.RegisterTrigger for the definition requirements.
+// It panics when the trigger type is already registered, and is meant to be called from init functions.
+func RegisterTrigger(definition pb.TriggerDefinition, constructor TriggerWatcherConstructor) {
+	pb.RegisterTrigger(definition)
+	registerWatcherConstructor(definition.Type, constructor)
+}
+
+func registerWatcherConstructor(triggerType pb.TriggerType, constructor TriggerWatcherConstructor) {
+	watcherConstructors.Lock()
+	defer watcherConstructors.Unlock()
+
+	if _, ok := watcherConstructors.byType[triggerType]; ok {
+		panic(fmt.Sprintf("watchers: constructor of trigger type %s is already registered", triggerType))
+	}
+
+	watcherConstructors.byType[triggerType] = constructor
+}
+
+func lookupWatcherConstructor(triggerType pb.TriggerType) (TriggerWatcherConstructor, bool) {
+	watcherConstructors.RLock()
+	defer watcherConstructors.RUnlock()
+
+	constructor, ok := watcherConstructors.byType[triggerType]
+
+	return constructor, ok
+}
+
+func newSchedulerWatcher(params TriggerWatcherParams) (TriggerWatcher, error) {
+	return &schedulerWatcher{
+		validator:     params.Validator,
+		statusTracker: params.StatusTracker,
+		clock:         params.Clock,
+		trigger:       params.Trigger,
+		triggeredChan: params.TriggeredChan,
+		errorChan:     params.ErrorChan,
+		updateChan:    make(chan time.Time, 1),
+		lastExecuted:  params.LastExecuted,
+		resumedAt:     params.ResumedAt,
+		logger:        params.Logger,
+	}, nil
+}
+
+func newEventWatcher(params TriggerWatcherParams) (TriggerWatcher, error) {
+	return &eventWatcher{
+		triggerStateService:   params.TriggerStateService,
+		validator:             params.Validator,
+		statusTracker:         params.StatusTracker,
+		clock:                 params.Clock,
+		trigger:               params.Trigger,
+		targets:               params.Targets,
+		triggeredChan:         params.TriggeredChan,
+		errorChan:             params.ErrorChan,
+		updateChan:            make(chan time.Time, 1),
+		lastExecuted:          params.LastExecuted,
+		logger:                params.Logger,
+		streamListenerFactory: params.StreamListenerFactory,
+	}, nil
+}
+
+func newOnceWatcher(params TriggerWatcherParams) (TriggerWatcher, error) {
+	return &onceWatcher{
+		triggerStateService: params.TriggerStateService,
+		validator:           params.Validator,
+		statusTracker:       params.StatusTracker,
+		clock:               params.Clock,
+		trigger:             params.Trigger,
+		triggeredChan:       params.TriggeredChan,
+		errorChan:           params.ErrorChan,
+		updateChan:          make(chan time.Time, 1),
+		logger:              params.Logger,
+	}, nil
+}
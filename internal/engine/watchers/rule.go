@@ -18,12 +18,18 @@ package watchers
 
 import (
 	"context"
+	"fmt"
+	"time"
 
+	"github.com/golang/protobuf/ptypes"
 	log "github.com/sirupsen/logrus"
 	"go.opencensus.io/trace"
 
+	"github.com/teserakt-io/automation-engine/internal/clock"
+	"github.com/teserakt-io/automation-engine/internal/config"
 	"github.com/teserakt-io/automation-engine/internal/engine/actions"
 	"github.com/teserakt-io/automation-engine/internal/models"
+	"github.com/teserakt-io/automation-engine/internal/pb"
 	"github.com/teserakt-io/automation-engine/internal/services"
 )
 
@@ -38,48 +44,106 @@ type ruleWatcher struct {
 	rule                  models.Rule
 	triggerWatcherFactory TriggerWatcherFactory
 	actionFactory         actions.ActionFactory
+	resumePolicy          string
+	clock                 clock.Clock
 	ruleWriter            services.RuleWriter
+	executionService      services.ExecutionService
+	statusTracker         StatusTracker
 	errorChan             chan<- error
 	triggeredChan         chan TriggerEvent
 	logger                log.FieldLogger
 }
 
 func (w *ruleWatcher) Start(ctx context.Context) {
-	var triggerWatchers []TriggerWatcher
+	triggerWatchers := make(map[int]TriggerWatcher)
 
 	if len(w.rule.Triggers) == 0 {
 		w.logger.WithField("rule", w.rule.ID).Warn("rule has no triggers")
 		return
 	}
 
+	lastExecuted := w.rule.LastExecuted
+	var resumedAt time.Time
+	if w.rule.ResumedAt.After(lastExecuted) {
+		if w.resumePolicy == config.ResumePolicySkip {
+			// Schedule the next runs from the resume time, skipping the ones missed while paused
+			lastExecuted = w.rule.ResumedAt
+		} else {
+			resumedAt = w.rule.ResumedAt
+		}
+	}
+
+	if required := w.requiredTriggers(); required > len(w.rule.Triggers) {
+		w.logger.WithFields(log.Fields{
+			"rule":      w.rule.ID,
+			"required":  required,
+			"triggers":  len(w.rule.Triggers),
+			"threshold": w.rule.TriggerThreshold,
+		}).Warn("rule trigger threshold exceeds its number of triggers, it will never execute")
+	}
+
+	// triggersDone receives the trigger ID each time a started trigger watcher returns
+	triggersDone := make(chan int, len(w.rule.Triggers))
 	for _, trigger := range w.rule.Triggers {
+		// Each trigger watcher reports on its own channel, so its errors can be tracked in its status
+		triggerErrorChan := make(chan error)
 		triggerWatcher, err := w.triggerWatcherFactory.Create(
 			trigger,
 			w.rule.Targets,
-			w.rule.LastExecuted,
+			lastExecuted,
+			resumedAt,
 			w.triggeredChan,
-			w.errorChan,
+			triggerErrorChan,
 		)
 
 		if err != nil {
+			// A trigger watcher which cannot be created will never be
+			w.statusTracker.TriggerError(w.rule.ID, trigger.ID, InvalidTrigger{err})
 			w.errorChan <- err
 
 			continue
 		}
 
-		triggerWatchers = append(triggerWatchers, triggerWatcher)
+		triggerWatchers[trigger.ID] = triggerWatcher
+		w.statusTracker.TriggerStarted(w.rule.ID, trigger.ID)
+
+		go w.forwardTriggerErrors(trigger.ID, triggerErrorChan)
+		go func(triggerID int) {
+			triggerWatcher.Start(ctx)
 
-		go triggerWatcher.Start(ctx)
+			// Trigger watchers only report errors until they return
+			w.statusTracker.TriggerStopped(w.rule.ID, triggerID)
+			close(triggerErrorChan)
+			triggersDone <- triggerID
+		}(trigger.ID)
 	}
 
 	for {
 		select {
+		case triggerID := <-triggersDone:
+			// The rule last execution is not sent to the trigger watchers which returned
+			delete(triggerWatchers, triggerID)
+
 		case triggerEvt := <-w.triggeredChan:
 			ctx, span := trace.StartSpan(ctx, "RuleWatcher.RuleTriggered")
 			span.Annotate([]trace.Attribute{
 				trace.Int64Attribute("ruleID", int64(w.rule.ID)),
 				trace.Int64Attribute("triggerID", int64(triggerEvt.Trigger.ID)),
 			}, "Rule triggered")
+			w.statusTracker.TriggerFired(w.rule.ID, triggerEvt.Trigger.ID)
+
+			if reason, limited := w.rateLimited(ctx, triggerEvt.Time); limited {
+				w.suppress(ctx, triggerEvt, reason)
+				span.End()
+
+				continue
+			}
+
+			if !w.combine(ctx, triggerEvt) {
+				span.End()
+
+				continue
+			}
 
 			w.logger.WithFields(log.Fields{
 				"rule":    w.rule.ID,
@@ -87,29 +151,221 @@ func (w *ruleWatcher) Start(ctx context.Context) {
 			}).Info("rule triggered")
 
 			w.rule.LastExecuted = triggerEvt.Time
-			w.ruleWriter.Save(ctx, &w.rule)
+			if err := w.ruleWriter.SaveExecutionState(ctx, w.rule); err != nil {
+				w.reportError(fmt.Errorf("failed to save rule last execution: %v", err))
+			}
 
-			for _, triggerWatcher := range triggerWatchers {
+			for triggerID, triggerWatcher := range triggerWatchers {
 				if err := triggerWatcher.UpdateLastExecuted(triggerEvt.Time); err != nil {
-					w.errorChan <- err
+					w.reportTriggerError(triggerID, err)
 
 					continue
 				}
 			}
 
-			action, err := w.actionFactory.Create(w.rule)
-			if err != nil {
-				w.errorChan <- err
-
-				continue
-			}
-
-			action.Execute(ctx)
+			w.execute(ctx, triggerEvt)
 			span.End()
 		case <-ctx.Done():
 			w.logger.WithError(ctx.Err()).WithField("rule", w.rule.ID).Warn("stopping ruleWatcher")
+			w.waitTriggerWatchers(len(triggerWatchers), triggersDone)
 
 			return
 		}
 	}
 }
+
+// waitTriggerWatchers waits for the running trigger watchers to return, so they don't report on
+// the rule status once it is stopped. Their last firings are discarded, as the rule won't execute anymore.
+func (w *ruleWatcher) waitTriggerWatchers(running int, triggersDone <-chan int) {
+	for running > 0 {
+		select {
+		case <-w.triggeredChan:
+		case <-triggersDone:
+			running--
+		}
+	}
+}
+
+// forwardTriggerErrors records the errors of a trigger watcher in its trigger status,
+// and forwards them to the errorChan, until triggerErrorChan is closed.
+func (w *ruleWatcher) forwardTriggerErrors(triggerID int, triggerErrorChan <-chan error) {
+	for err := range triggerErrorChan {
+		w.reportTriggerError(triggerID, err)
+	}
+}
+
+// reportTriggerError records err in the status of given trigger, and sends it to the errorChan
+func (w *ruleWatcher) reportTriggerError(triggerID int, err error) {
+	w.statusTracker.TriggerError(w.rule.ID, triggerID, err)
+	w.errorChan <- err
+}
+
+// reportError records err in the rule status, and sends it to the errorChan
+func (w *ruleWatcher) reportError(err error) {
+	w.statusTracker.RuleError(w.rule.ID, err)
+	w.errorChan <- err
+}
+
+// rateLimited returns true, along with the reason, when the rule must not be executed at t,
+// because its cooldown since LastExecuted has not elapsed or its executions budget is spent.
+func (w *ruleWatcher) rateLimited(ctx context.Context, t time.Time) (string, bool) {
+	if w.rule.Cooldown > 0 && !w.rule.LastExecuted.IsZero() && t.Sub(w.rule.LastExecuted) < w.rule.Cooldown {
+		return "cooldown", true
+	}
+
+	if w.rule.MaxExecutions > 0 {
+		since := t.Add(-w.rule.ExecutionPeriod)
+		executions, err := w.executionService.List(ctx, w.rule.ID, since, w.rule.MaxExecutions)
+		if err != nil {
+			// Let the rule execute rather than blocking it on an unreadable history
+			w.reportError(fmt.Errorf("failed to retrieve rule executions: %v", err))
+
+			return "", false
+		}
+
+		if len(executions) >= w.rule.MaxExecutions {
+			return "executions budget", true
+		}
+	}
+
+	return "", false
+}
+
+// suppress records a trigger firing which has been suppressed by the rule rate limiting
+func (w *ruleWatcher) suppress(ctx context.Context, triggerEvt TriggerEvent, reason string) {
+	w.rule.SuppressedCount++
+	w.rule.LastSuppressed = triggerEvt.Time
+
+	w.logger.WithFields(log.Fields{
+		"rule":       w.rule.ID,
+		"trigger":    triggerEvt.Trigger.ID,
+		"reason":     reason,
+		"suppressed": w.rule.SuppressedCount,
+	}).Warn("rule execution suppressed")
+
+	if err := w.ruleWriter.SaveExecutionState(ctx, w.rule); err != nil {
+		w.reportError(fmt.Errorf("failed to save rule suppressed count: %v", err))
+	}
+}
+
+// combine arms the trigger of triggerEvt, and returns true when the rule triggers combination
+// is satisfied, meaning the rule must be executed. The armed triggers are then reset.
+// Otherwise, the armed triggers are saved with the rule, so they are kept across restarts.
+func (w *ruleWatcher) combine(ctx context.Context, triggerEvt TriggerEvent) bool {
+	if w.rule.TriggerMode == pb.TriggerMode_ANY_TRIGGER {
+		return true
+	}
+
+	armed, err := w.rule.ArmedTriggerTimes()
+	if err != nil {
+		// Start arming again rather than blocking the rule on a corrupted state
+		w.reportError(fmt.Errorf("failed to decode rule armed triggers: %v", err))
+		armed = make(map[int]time.Time)
+	}
+	armed[triggerEvt.Trigger.ID] = triggerEvt.Time
+
+	// Triggers removed from the rule since they were armed are ignored
+	var armedCount int
+	for _, trigger := range w.rule.Triggers {
+		if _, ok := armed[trigger.ID]; ok {
+			armedCount++
+		}
+	}
+
+	required := w.requiredTriggers()
+	if armedCount >= required {
+		// The rule is saved along with its LastExecuted once the combination is satisfied
+		w.rule.ArmedTriggers = nil
+
+		return true
+	}
+
+	w.logger.WithFields(log.Fields{
+		"rule":     w.rule.ID,
+		"trigger":  triggerEvt.Trigger.ID,
+		"mode":     w.rule.TriggerMode,
+		"armed":    armedCount,
+		"required": required,
+	}).Info("rule trigger armed")
+
+	if err := w.rule.SetArmedTriggerTimes(armed); err != nil {
+		w.reportError(fmt.Errorf("failed to encode rule armed triggers: %v", err))
+
+		return false
+	}
+
+	if err := w.ruleWriter.SaveExecutionState(ctx, w.rule); err != nil {
+		w.reportError(fmt.Errorf("failed to save rule armed triggers: %v", err))
+	}
+
+	return false
+}
+
+// requiredTriggers returns how many triggers must be armed for the rule to execute
+func (w *ruleWatcher) requiredTriggers() int {
+	switch w.rule.TriggerMode {
+	case pb.TriggerMode_ALL_TRIGGERS:
+		return len(w.rule.Triggers)
+	case pb.TriggerMode_THRESHOLD_TRIGGERS:
+		return w.rule.TriggerThreshold
+	default:
+		return 1
+	}
+}
+
+// execute creates and executes the rule action, and records the execution outcomes
+func (w *ruleWatcher) execute(ctx context.Context, triggerEvt TriggerEvent) {
+	execution := &models.Execution{
+		RuleID:      w.rule.ID,
+		TriggerID:   triggerEvt.Trigger.ID,
+		TriggeredAt: triggerEvt.Time,
+		DryRun:      w.actionFactory.DryRun(w.rule),
+	}
+
+	if evt := triggerEvt.Event; evt != nil {
+		execution.EventType = evt.Type.String()
+		execution.EventSource = evt.Source
+		execution.EventTarget = evt.Target
+		if evt.Timestamp != nil {
+			eventTime, err := ptypes.Timestamp(evt.Timestamp)
+			if err != nil {
+				w.reportError(err)
+			}
+			execution.EventTime = eventTime
+		}
+	}
+
+	start := w.clock.Now()
+
+	action, err := w.actionFactory.Create(w.rule, actions.TriggerContext{
+		Trigger: triggerEvt.Trigger,
+		Time:    triggerEvt.Time,
+		Event:   triggerEvt.Event,
+	})
+	if err != nil {
+		w.reportError(err)
+	} else {
+		execution.Outcomes, err = action.Execute(ctx)
+		if err != nil {
+			w.logger.WithError(err).WithField("rule", w.rule.ID).Error("rule action failed")
+			w.statusTracker.RuleError(w.rule.ID, fmt.Errorf("rule action failed: %v", err))
+		}
+	}
+
+	execution.Duration = w.clock.Now().Sub(start)
+	if err != nil {
+		execution.Error = err.Error()
+	} else {
+		w.statusTracker.RuleExecuted(w.rule.ID)
+	}
+
+	if err := w.executionService.Save(ctx, execution); err != nil {
+		w.reportError(err)
+
+		return
+	}
+
+	if action != nil {
+		actions.CompletePending(actions.PendingContext(ctx), action, *execution, w.executionService, w.logger)
+	}
+}
@@ -18,14 +18,18 @@ import (
 	"context"
 	"errors"
 	"io/ioutil"
+	"reflect"
 	"testing"
 	"time"
 
 	"github.com/golang/mock/gomock"
 	log "github.com/sirupsen/logrus"
 
+	"github.com/teserakt-io/automation-engine/internal/clock"
+	"github.com/teserakt-io/automation-engine/internal/config"
 	"github.com/teserakt-io/automation-engine/internal/engine/actions"
 	"github.com/teserakt-io/automation-engine/internal/models"
+	"github.com/teserakt-io/automation-engine/internal/pb"
 	"github.com/teserakt-io/automation-engine/internal/services"
 )
 
@@ -54,6 +58,7 @@ func TestRuleWatcher(t *testing.T) {
 	logger.SetOutput(ioutil.Discard)
 
 	mockRuleWriter := services.NewMockRuleService(mockCtrl)
+	mockExecutionService := services.NewMockExecutionService(mockCtrl)
 	mockTriggerWatcherFactory := NewMockTriggerWatcherFactory(mockCtrl)
 	mockTriggerWatcher1 := NewMockTriggerWatcher(mockCtrl)
 	mockTriggerWatcher2 := NewMockTriggerWatcher(mockCtrl)
@@ -64,23 +69,26 @@ func TestRuleWatcher(t *testing.T) {
 	errorChan := make(chan error)
 
 	watcher := &ruleWatcher{
+		clock:                 clock.New(),
 		rule:                  rule,
 		ruleWriter:            mockRuleWriter,
+		executionService:      mockExecutionService,
 		triggerWatcherFactory: mockTriggerWatcherFactory,
 		actionFactory:         mockActionFactory,
 		triggeredChan:         triggeredChan,
+		statusTracker:         NewStatusTracker(clock.New()),
 		errorChan:             errorChan,
 		logger:                logger,
 	}
 
 	t.Run("Start start a triggerWatcher for each trigger", func(t *testing.T) {
 		mockTriggerWatcherFactory.EXPECT().
-			Create(trigger1, rule.Targets, rule.LastExecuted, gomock.Any(), gomock.Any()).
+			Create(trigger1, rule.Targets, rule.LastExecuted, time.Time{}, gomock.Any(), gomock.Any()).
 			Times(1).
 			Return(mockTriggerWatcher1, nil)
 
 		mockTriggerWatcherFactory.EXPECT().
-			Create(trigger2, rule.Targets, rule.LastExecuted, gomock.Any(), gomock.Any()).
+			Create(trigger2, rule.Targets, rule.LastExecuted, time.Time{}, gomock.Any(), gomock.Any()).
 			Times(1).
 			Return(mockTriggerWatcher2, nil)
 
@@ -110,12 +118,12 @@ func TestRuleWatcher(t *testing.T) {
 		defer cancel()
 
 		mockTriggerWatcherFactory.EXPECT().
-			Create(trigger1, rule.Targets, rule.LastExecuted, gomock.Any(), gomock.Any()).
+			Create(trigger1, rule.Targets, rule.LastExecuted, time.Time{}, gomock.Any(), gomock.Any()).
 			Times(1).
 			Return(nil, expectedError)
 
 		mockTriggerWatcherFactory.EXPECT().
-			Create(trigger2, rule.Targets, rule.LastExecuted, gomock.Any(), gomock.Any()).
+			Create(trigger2, rule.Targets, rule.LastExecuted, time.Time{}, gomock.Any(), gomock.Any()).
 			Times(1).
 			Return(mockTriggerWatcher2, nil)
 
@@ -135,6 +143,85 @@ func TestRuleWatcher(t *testing.T) {
 		}
 	})
 
+	t.Run("Trigger watcher errors are tracked in the trigger status", func(t *testing.T) {
+		statusTracker := NewStatusTracker(clock.New())
+		trackedRuleWatcher := &ruleWatcher{
+			clock:                 clock.New(),
+			rule:                  rule,
+			ruleWriter:            mockRuleWriter,
+			executionService:      mockExecutionService,
+			triggerWatcherFactory: mockTriggerWatcherFactory,
+			actionFactory:         mockActionFactory,
+			triggeredChan:         triggeredChan,
+			statusTracker:         statusTracker,
+			errorChan:             errorChan,
+			logger:                logger,
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		expectedError := InvalidTrigger{errors.New("failed to parse cron expression")}
+
+		mockTriggerWatcherFactory.EXPECT().
+			Create(trigger1, rule.Targets, rule.LastExecuted, time.Time{}, gomock.Any(), gomock.Any()).
+			Times(1).
+			Return(mockTriggerWatcher1, nil)
+
+		mockTriggerWatcherFactory.EXPECT().
+			Create(trigger2, rule.Targets, rule.LastExecuted, time.Time{}, gomock.Any(), gomock.Any()).
+			Times(1).
+			DoAndReturn(func(
+				trigger models.Trigger,
+				targets []models.Target,
+				lastExecuted time.Time,
+				resumedAt time.Time,
+				triggeredChan chan<- TriggerEvent,
+				triggerErrorChan chan<- error,
+			) (TriggerWatcher, error) {
+				mockTriggerWatcher2.EXPECT().Start(ctx).Times(1).Do(func(ctx context.Context) {
+					triggerErrorChan <- expectedError
+				})
+
+				return mockTriggerWatcher2, nil
+			})
+
+		mockTriggerWatcher1.EXPECT().Start(ctx).Times(1).DoAndReturn(func(ctx context.Context) {
+			<-ctx.Done()
+		})
+
+		go trackedRuleWatcher.Start(ctx)
+
+		select {
+		case err := <-errorChan:
+			if err != expectedError {
+				t.Errorf("Expected err to be %s, got %s", expectedError, err)
+			}
+		case <-time.After(100 * time.Millisecond):
+			t.Fatalf("Expected an error on errorChan")
+		}
+
+		// Let the invalid trigger watcher return
+		time.Sleep(10 * time.Millisecond)
+
+		status, ok := statusTracker.RuleStatus(rule.ID)
+		if !ok || len(status.Triggers) != 2 {
+			t.Fatalf("Expected rule triggers to be tracked, got %#v", status)
+		}
+
+		if status.Triggers[0].State != pb.TriggerWatcherState_TRIGGER_RUNNING {
+			t.Errorf("Expected trigger 1 to be running, got %s", status.Triggers[0].State)
+		}
+
+		if status.Triggers[1].State != pb.TriggerWatcherState_TRIGGER_INVALID {
+			t.Errorf("Expected trigger 2 to be invalid, got %s", status.Triggers[1].State)
+		}
+
+		if status.Triggers[1].LastError != expectedError.Error() || status.Triggers[1].ErrorCount != 1 {
+			t.Errorf("Expected trigger 2 last error to be %s, got %#v", expectedError, status.Triggers[1])
+		}
+	})
+
 	t.Run("All triggerWatchers get updated when one of them trigger and action get executed", func(t *testing.T) {
 		expectedTime := time.Now()
 		modifiedRule := rule
@@ -144,22 +231,25 @@ func TestRuleWatcher(t *testing.T) {
 		defer cancel()
 
 		newRuleWatcher := &ruleWatcher{
+			clock:                 clock.New(),
 			rule:                  modifiedRule,
 			ruleWriter:            mockRuleWriter,
+			executionService:      mockExecutionService,
 			triggerWatcherFactory: mockTriggerWatcherFactory,
 			actionFactory:         mockActionFactory,
 			triggeredChan:         triggeredChan,
+			statusTracker:         NewStatusTracker(clock.New()),
 			errorChan:             errorChan,
 			logger:                logger,
 		}
 
 		mockTriggerWatcherFactory.EXPECT().
-			Create(trigger1, rule.Targets, modifiedRule.LastExecuted, gomock.Any(), gomock.Any()).
+			Create(trigger1, rule.Targets, modifiedRule.LastExecuted, time.Time{}, gomock.Any(), gomock.Any()).
 			Times(1).
 			Return(mockTriggerWatcher1, nil)
 
 		mockTriggerWatcherFactory.EXPECT().
-			Create(trigger2, rule.Targets, modifiedRule.LastExecuted, gomock.Any(), gomock.Any()).
+			Create(trigger2, rule.Targets, modifiedRule.LastExecuted, time.Time{}, gomock.Any(), gomock.Any()).
 			Times(1).
 			Return(mockTriggerWatcher2, nil)
 
@@ -170,13 +260,44 @@ func TestRuleWatcher(t *testing.T) {
 			<-ctx.Done()
 		})
 
-		mockRuleWriter.EXPECT().Save(gomock.Any(), &modifiedRule).Times(1)
+		mockRuleWriter.EXPECT().SaveExecutionState(gomock.Any(), modifiedRule).Times(1)
 
 		mockTriggerWatcher1.EXPECT().UpdateLastExecuted(expectedTime).Times(1)
 		mockTriggerWatcher2.EXPECT().UpdateLastExecuted(expectedTime).Times(1)
 
-		mockActionFactory.EXPECT().Create(modifiedRule).Times(1).Return(mockAction, nil)
-		mockAction.EXPECT().Execute(gomock.Any()).Times(1)
+		expectedTriggerCtx := actions.TriggerContext{Trigger: modifiedRule.Triggers[1], Time: expectedTime}
+		mockActionFactory.EXPECT().DryRun(modifiedRule).Times(1).Return(true)
+		mockActionFactory.EXPECT().Create(modifiedRule, expectedTriggerCtx).Times(1).Return(mockAction, nil)
+		outcomes := []models.ExecutionOutcome{
+			models.ExecutionOutcome{ActionType: pb.ActionType_KEY_ROTATION, TargetType: pb.TargetType_CLIENT, Target: "client1"},
+		}
+		mockAction.EXPECT().Execute(gomock.Any()).Times(1).Return(outcomes, nil)
+
+		mockExecutionService.EXPECT().Save(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+			func(ctx context.Context, execution *models.Execution) error {
+				if execution.RuleID != modifiedRule.ID || execution.TriggerID != modifiedRule.Triggers[1].ID {
+					t.Errorf("Expected execution to reference rule and trigger, got %#v", execution)
+				}
+
+				if !execution.TriggeredAt.Equal(expectedTime) {
+					t.Errorf("Expected execution time to be %v, got %v", expectedTime, execution.TriggeredAt)
+				}
+
+				if !reflect.DeepEqual(execution.Outcomes, outcomes) {
+					t.Errorf("Expected execution outcomes to be %#v, got %#v", outcomes, execution.Outcomes)
+				}
+
+				if len(execution.Error) > 0 {
+					t.Errorf("Expected no execution error, got %s", execution.Error)
+				}
+
+				if !execution.DryRun {
+					t.Errorf("Expected execution to be recorded as a dry run")
+				}
+
+				return nil
+			},
+		)
 
 		go newRuleWatcher.Start(ctx)
 
@@ -189,6 +310,125 @@ func TestRuleWatcher(t *testing.T) {
 		}
 	})
 
+	t.Run("Pending outcomes are completed with the pending context once the execution is saved", func(t *testing.T) {
+		mockExecutionService := services.NewMockExecutionService(mockCtrl)
+		mockActionFactory := actions.NewMockActionFactory(mockCtrl)
+		mockPendingAction := actions.NewMockPendingAction(mockCtrl)
+
+		watcher := &ruleWatcher{
+			clock:            clock.New(),
+			rule:             rule,
+			ruleWriter:       mockRuleWriter,
+			executionService: mockExecutionService,
+			actionFactory:    mockActionFactory,
+			statusTracker:    NewStatusTracker(clock.New()),
+			errorChan:        errorChan,
+			logger:           logger,
+		}
+
+		pendingCtx, cancelPending := context.WithCancel(context.Background())
+		defer cancelPending()
+
+		ctx, cancel := context.WithCancel(actions.WithPendingContext(context.Background(), pendingCtx))
+		defer cancel()
+
+		outcomes := []models.ExecutionOutcome{
+			models.ExecutionOutcome{ActionType: pb.ActionType_KEY_ROTATION, TargetType: pb.TargetType_CLIENT, Target: "client1", Pending: true},
+		}
+
+		updated := make(chan models.ExecutionOutcome, 1)
+		gomock.InOrder(
+			mockActionFactory.EXPECT().DryRun(rule).Times(1).Return(false),
+			mockActionFactory.EXPECT().Create(rule, gomock.Any()).Times(1).Return(mockPendingAction, nil),
+			mockPendingAction.EXPECT().Execute(gomock.Any()).Times(1).Return(outcomes, nil),
+			mockExecutionService.EXPECT().Save(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+				func(ctx context.Context, execution *models.Execution) error {
+					execution.Outcomes[0].ID = 1
+					return nil
+				},
+			),
+			mockPendingAction.EXPECT().Complete(pendingCtx, gomock.Any()).Times(1).DoAndReturn(
+				func(ctx context.Context, done func(int, error)) {
+					done(0, nil)
+				},
+			),
+			mockExecutionService.EXPECT().UpdateOutcome(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+				func(ctx context.Context, outcome models.ExecutionOutcome) error {
+					updated <- outcome
+					return nil
+				},
+			),
+		)
+
+		watcher.execute(ctx, TriggerEvent{Trigger: trigger1, Time: time.Now()})
+
+		select {
+		case outcome := <-updated:
+			if outcome.ID != 1 || outcome.Pending {
+				t.Errorf("Expected outcome #1 to be completed, got %#v", outcome)
+			}
+		case <-time.After(time.Second):
+			t.Errorf("Expected the pending outcome to be updated")
+		}
+	})
+
+	t.Run("Trigger watchers which returned are not updated anymore", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		newRuleWatcher := &ruleWatcher{
+			clock:                 clock.New(),
+			rule:                  rule,
+			ruleWriter:            mockRuleWriter,
+			executionService:      mockExecutionService,
+			triggerWatcherFactory: mockTriggerWatcherFactory,
+			actionFactory:         mockActionFactory,
+			triggeredChan:         triggeredChan,
+			statusTracker:         NewStatusTracker(clock.New()),
+			errorChan:             errorChan,
+			logger:                logger,
+		}
+
+		mockTriggerWatcherFactory.EXPECT().
+			Create(trigger1, rule.Targets, rule.LastExecuted, time.Time{}, gomock.Any(), gomock.Any()).
+			Times(1).
+			Return(mockTriggerWatcher1, nil)
+
+		mockTriggerWatcherFactory.EXPECT().
+			Create(trigger2, rule.Targets, rule.LastExecuted, time.Time{}, gomock.Any(), gomock.Any()).
+			Times(1).
+			Return(mockTriggerWatcher2, nil)
+
+		mockTriggerWatcher1.EXPECT().Start(ctx).Times(1).DoAndReturn(func(ctx context.Context) {
+			<-ctx.Done()
+		})
+		// The second trigger watcher returns on its own, like on an invalid trigger
+		mockTriggerWatcher2.EXPECT().Start(ctx).Times(1)
+
+		expectedTime := time.Now()
+		mockRuleWriter.EXPECT().SaveExecutionState(gomock.Any(), gomock.Any()).Times(1)
+		mockTriggerWatcher1.EXPECT().UpdateLastExecuted(expectedTime).Times(1)
+		mockTriggerWatcher2.EXPECT().UpdateLastExecuted(gomock.Any()).Times(0)
+
+		mockActionFactory.EXPECT().DryRun(gomock.Any()).Times(1).Return(false)
+		mockActionFactory.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(mockAction, nil)
+		mockAction.EXPECT().Execute(gomock.Any()).Times(1).Return(nil, nil)
+		mockExecutionService.EXPECT().Save(gomock.Any(), gomock.Any()).Times(1)
+
+		go newRuleWatcher.Start(ctx)
+
+		// Let the second trigger watcher return
+		time.Sleep(10 * time.Millisecond)
+
+		triggeredChan <- TriggerEvent{Trigger: trigger1, Time: expectedTime}
+
+		select {
+		case err := <-errorChan:
+			t.Errorf("Expected no error on errorChan, got %s", err)
+		case <-time.After(100 * time.Millisecond):
+		}
+	})
+
 	t.Run("Error is sent on errorChan when the action fail to execute", func(t *testing.T) {
 		modifiedRule := models.Rule{
 			LastExecuted: time.Now(),
@@ -197,7 +437,7 @@ func TestRuleWatcher(t *testing.T) {
 		}
 
 		mockTriggerWatcherFactory.EXPECT().
-			Create(trigger1, rule.Targets, modifiedRule.LastExecuted, gomock.Any(), gomock.Any()).
+			Create(trigger1, rule.Targets, modifiedRule.LastExecuted, time.Time{}, gomock.Any(), gomock.Any()).
 			Times(1).
 			Return(mockTriggerWatcher1, nil)
 
@@ -208,19 +448,32 @@ func TestRuleWatcher(t *testing.T) {
 			<-ctx.Done()
 		})
 
-		mockRuleWriter.EXPECT().Save(gomock.Any(), gomock.Any()).Times(1)
+		mockRuleWriter.EXPECT().SaveExecutionState(gomock.Any(), gomock.Any()).Times(1)
 
 		mockTriggerWatcher1.EXPECT().UpdateLastExecuted(gomock.Any()).Times(1)
 
 		expectedError := errors.New("action factory failed to create action")
-		mockActionFactory.EXPECT().Create(gomock.Any()).Times(1).Return(nil, expectedError)
+		mockActionFactory.EXPECT().DryRun(gomock.Any()).Times(1).Return(false)
+		mockActionFactory.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(nil, expectedError)
+		mockExecutionService.EXPECT().Save(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+			func(ctx context.Context, execution *models.Execution) error {
+				if execution.Error != expectedError.Error() {
+					t.Errorf("Expected execution error to be %s, got %s", expectedError, execution.Error)
+				}
+
+				return nil
+			},
+		)
 
 		newRuleWatcher := &ruleWatcher{
+			clock:                 clock.New(),
 			rule:                  modifiedRule,
 			ruleWriter:            mockRuleWriter,
+			executionService:      mockExecutionService,
 			triggerWatcherFactory: mockTriggerWatcherFactory,
 			actionFactory:         mockActionFactory,
 			triggeredChan:         triggeredChan,
+			statusTracker:         NewStatusTracker(clock.New()),
 			errorChan:             errorChan,
 			logger:                logger,
 		}
@@ -242,4 +495,389 @@ func TestRuleWatcher(t *testing.T) {
 			t.Errorf("Expected an error when actionFactory failed to create action")
 		}
 	})
+
+	t.Run("Trigger watchers are scheduled according to the resume policy", func(t *testing.T) {
+		lastExecuted := time.Now().Add(-2 * time.Hour)
+		resumedAt := time.Now().Add(-time.Minute)
+
+		resumedRule := models.Rule{
+			ID:           2,
+			LastExecuted: lastExecuted,
+			ResumedAt:    resumedAt,
+			Enabled:      true,
+			Triggers:     []models.Trigger{trigger1},
+			Targets:      []models.Target{target1},
+		}
+
+		testData := map[string][2]time.Time{
+			config.ResumePolicyFireOnce: {lastExecuted, resumedAt},
+			config.ResumePolicySkip:     {resumedAt, time.Time{}},
+		}
+
+		for resumePolicy, expected := range testData {
+			expectedLastExecuted, expectedResumedAt := expected[0], expected[1]
+
+			ctx, cancel := context.WithCancel(context.Background())
+
+			triggerWatcherFactory := NewMockTriggerWatcherFactory(mockCtrl)
+			triggerWatcher := NewMockTriggerWatcher(mockCtrl)
+
+			triggerWatcherFactory.EXPECT().
+				Create(trigger1, resumedRule.Targets, expectedLastExecuted, expectedResumedAt, gomock.Any(), gomock.Any()).
+				Times(1).
+				Return(triggerWatcher, nil)
+			triggerWatcher.EXPECT().Start(gomock.Any()).Times(1)
+
+			resumedRuleWatcher := &ruleWatcher{
+				clock:                 clock.New(),
+				rule:                  resumedRule,
+				triggerWatcherFactory: triggerWatcherFactory,
+				resumePolicy:          resumePolicy,
+				triggeredChan:         make(chan TriggerEvent),
+				statusTracker:         NewStatusTracker(clock.New()),
+				errorChan:             errorChan,
+				logger:                logger,
+			}
+
+			done := make(chan struct{})
+			go func() {
+				resumedRuleWatcher.Start(ctx)
+				close(done)
+			}()
+
+			// Let the trigger watcher goroutine start before stopping the rule watcher
+			time.Sleep(10 * time.Millisecond)
+			cancel()
+			<-done
+		}
+	})
+}
+
+func TestRuleWatcherTriggerMode(t *testing.T) {
+	logger := log.New()
+	logger.SetOutput(ioutil.Discard)
+
+	trigger1 := models.Trigger{ID: 1}
+	trigger2 := models.Trigger{ID: 2}
+	trigger3 := models.Trigger{ID: 3}
+
+	// startRuleWatcher starts a ruleWatcher on rule, with a trigger watcher expecting to be updated
+	// executions times, and an action expected to be executed executions times.
+	startRuleWatcher := func(
+		t *testing.T,
+		mockCtrl *gomock.Controller,
+		rule models.Rule,
+		mockRuleWriter *services.MockRuleService,
+		executions int,
+	) (context.CancelFunc, chan TriggerEvent, chan error) {
+		mockExecutionService := services.NewMockExecutionService(mockCtrl)
+		mockTriggerWatcherFactory := NewMockTriggerWatcherFactory(mockCtrl)
+		mockTriggerWatcher := NewMockTriggerWatcher(mockCtrl)
+		mockActionFactory := actions.NewMockActionFactory(mockCtrl)
+		mockAction := actions.NewMockAction(mockCtrl)
+
+		mockTriggerWatcherFactory.EXPECT().
+			Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Times(len(rule.Triggers)).
+			Return(mockTriggerWatcher, nil)
+		mockTriggerWatcher.EXPECT().Start(gomock.Any()).AnyTimes().Do(func(ctx context.Context) {
+			<-ctx.Done()
+		})
+		mockTriggerWatcher.EXPECT().UpdateLastExecuted(gomock.Any()).Times(executions * len(rule.Triggers))
+
+		mockActionFactory.EXPECT().DryRun(gomock.Any()).Times(executions)
+		mockActionFactory.EXPECT().Create(gomock.Any(), gomock.Any()).Times(executions).Return(mockAction, nil)
+		mockAction.EXPECT().Execute(gomock.Any()).Times(executions)
+		mockExecutionService.EXPECT().Save(gomock.Any(), gomock.Any()).Times(executions)
+
+		triggeredChan := make(chan TriggerEvent)
+		errorChan := make(chan error)
+
+		watcher := &ruleWatcher{
+			clock:                 clock.New(),
+			rule:                  rule,
+			ruleWriter:            mockRuleWriter,
+			executionService:      mockExecutionService,
+			triggerWatcherFactory: mockTriggerWatcherFactory,
+			actionFactory:         mockActionFactory,
+			triggeredChan:         triggeredChan,
+			statusTracker:         NewStatusTracker(clock.New()),
+			errorChan:             errorChan,
+			logger:                logger,
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		go watcher.Start(ctx)
+
+		return cancel, triggeredChan, errorChan
+	}
+
+	// savedArmedTriggers returns a channel receiving the armed trigger IDs of each saved rule
+	savedArmedTriggers := func(t *testing.T, mockRuleWriter *services.MockRuleService) chan []int {
+		saved := make(chan []int, 10)
+		mockRuleWriter.EXPECT().SaveExecutionState(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+			func(ctx context.Context, rule models.Rule) error {
+				armed, err := rule.ArmedTriggerTimes()
+				if err != nil {
+					t.Errorf("Expected no error decoding armed triggers, got %v", err)
+				}
+
+				var ids []int
+				for _, trigger := range rule.Triggers {
+					if _, ok := armed[trigger.ID]; ok {
+						ids = append(ids, trigger.ID)
+					}
+				}
+				saved <- ids
+
+				return nil
+			},
+		)
+
+		return saved
+	}
+
+	expectSaved := func(t *testing.T, saved chan []int, expected []int) {
+		select {
+		case ids := <-saved:
+			if reflect.DeepEqual(ids, expected) == false {
+				t.Errorf("Expected saved armed triggers to be %v, got %v", expected, ids)
+			}
+		case <-time.After(100 * time.Millisecond):
+			t.Fatalf("Expected rule to be saved")
+		}
+	}
+
+	t.Run("ALL_TRIGGERS mode executes the rule once every trigger fired", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mockRuleWriter := services.NewMockRuleService(mockCtrl)
+		saved := savedArmedTriggers(t, mockRuleWriter)
+
+		rule := models.Rule{
+			ID:          1,
+			TriggerMode: pb.TriggerMode_ALL_TRIGGERS,
+			Triggers:    []models.Trigger{trigger1, trigger2},
+		}
+
+		cancel, triggeredChan, _ := startRuleWatcher(t, mockCtrl, rule, mockRuleWriter, 1)
+		defer cancel()
+
+		triggeredChan <- TriggerEvent{Trigger: trigger1, Time: time.Now()}
+		expectSaved(t, saved, []int{1})
+
+		// Firing again an armed trigger doesn't satisfy the combination
+		triggeredChan <- TriggerEvent{Trigger: trigger1, Time: time.Now()}
+		expectSaved(t, saved, []int{1})
+
+		triggeredChan <- TriggerEvent{Trigger: trigger2, Time: time.Now()}
+		expectSaved(t, saved, nil)
+
+		// Wait for the action to be executed
+		triggeredChan <- TriggerEvent{Trigger: trigger2, Time: time.Now()}
+		expectSaved(t, saved, []int{2})
+	})
+
+	t.Run("THRESHOLD_TRIGGERS mode executes the rule once enough triggers fired", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mockRuleWriter := services.NewMockRuleService(mockCtrl)
+		saved := savedArmedTriggers(t, mockRuleWriter)
+
+		rule := models.Rule{
+			ID:               1,
+			TriggerMode:      pb.TriggerMode_THRESHOLD_TRIGGERS,
+			TriggerThreshold: 2,
+			Triggers:         []models.Trigger{trigger1, trigger2, trigger3},
+		}
+
+		cancel, triggeredChan, _ := startRuleWatcher(t, mockCtrl, rule, mockRuleWriter, 1)
+		defer cancel()
+
+		triggeredChan <- TriggerEvent{Trigger: trigger3, Time: time.Now()}
+		expectSaved(t, saved, []int{3})
+
+		triggeredChan <- TriggerEvent{Trigger: trigger1, Time: time.Now()}
+		expectSaved(t, saved, nil)
+
+		triggeredChan <- TriggerEvent{Trigger: trigger2, Time: time.Now()}
+		expectSaved(t, saved, []int{2})
+	})
+
+	t.Run("Armed triggers are restored from the rule", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mockRuleWriter := services.NewMockRuleService(mockCtrl)
+		saved := savedArmedTriggers(t, mockRuleWriter)
+
+		rule := models.Rule{
+			ID:          1,
+			TriggerMode: pb.TriggerMode_ALL_TRIGGERS,
+			Triggers:    []models.Trigger{trigger1, trigger2},
+		}
+		// Trigger 4 was removed from the rule, and must not be counted
+		if err := rule.SetArmedTriggerTimes(map[int]time.Time{1: time.Now(), 4: time.Now()}); err != nil {
+			t.Fatalf("Failed to set armed triggers: %v", err)
+		}
+
+		cancel, triggeredChan, _ := startRuleWatcher(t, mockCtrl, rule, mockRuleWriter, 1)
+		defer cancel()
+
+		triggeredChan <- TriggerEvent{Trigger: trigger2, Time: time.Now()}
+		expectSaved(t, saved, nil)
+
+		triggeredChan <- TriggerEvent{Trigger: trigger2, Time: time.Now()}
+		expectSaved(t, saved, []int{2})
+	})
+}
+
+func TestRuleWatcherRateLimit(t *testing.T) {
+	logger := log.New()
+	logger.SetOutput(ioutil.Discard)
+
+	trigger1 := models.Trigger{ID: 1}
+	start := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	// startRuleWatcher starts a ruleWatcher on rule, with an action expected to be executed executions times,
+	// and returns a channel receiving each saved rule.
+	startRuleWatcher := func(
+		t *testing.T,
+		mockCtrl *gomock.Controller,
+		rule models.Rule,
+		mockExecutionService *services.MockExecutionService,
+		executions int,
+	) (context.CancelFunc, chan TriggerEvent, chan models.Rule) {
+		mockRuleWriter := services.NewMockRuleService(mockCtrl)
+		mockTriggerWatcherFactory := NewMockTriggerWatcherFactory(mockCtrl)
+		mockTriggerWatcher := NewMockTriggerWatcher(mockCtrl)
+		mockActionFactory := actions.NewMockActionFactory(mockCtrl)
+		mockAction := actions.NewMockAction(mockCtrl)
+
+		saved := make(chan models.Rule, 10)
+		mockRuleWriter.EXPECT().SaveExecutionState(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+			func(ctx context.Context, rule models.Rule) error {
+				saved <- rule
+
+				return nil
+			},
+		)
+
+		mockTriggerWatcherFactory.EXPECT().
+			Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Times(len(rule.Triggers)).
+			Return(mockTriggerWatcher, nil)
+		mockTriggerWatcher.EXPECT().Start(gomock.Any()).AnyTimes().Do(func(ctx context.Context) {
+			<-ctx.Done()
+		})
+		mockTriggerWatcher.EXPECT().UpdateLastExecuted(gomock.Any()).Times(executions * len(rule.Triggers))
+
+		mockActionFactory.EXPECT().DryRun(gomock.Any()).Times(executions)
+		mockActionFactory.EXPECT().Create(gomock.Any(), gomock.Any()).Times(executions).Return(mockAction, nil)
+		mockAction.EXPECT().Execute(gomock.Any()).Times(executions)
+		mockExecutionService.EXPECT().Save(gomock.Any(), gomock.Any()).Times(executions)
+
+		triggeredChan := make(chan TriggerEvent)
+
+		watcher := &ruleWatcher{
+			clock:                 clock.NewFake(start),
+			rule:                  rule,
+			ruleWriter:            mockRuleWriter,
+			executionService:      mockExecutionService,
+			triggerWatcherFactory: mockTriggerWatcherFactory,
+			actionFactory:         mockActionFactory,
+			triggeredChan:         triggeredChan,
+			statusTracker:         NewStatusTracker(clock.New()),
+			errorChan:             make(chan error),
+			logger:                logger,
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		go watcher.Start(ctx)
+
+		return cancel, triggeredChan, saved
+	}
+
+	expectSaved := func(t *testing.T, saved chan models.Rule, lastExecuted time.Time, suppressedCount int64) {
+		select {
+		case rule := <-saved:
+			if !rule.LastExecuted.Equal(lastExecuted) {
+				t.Errorf("Expected saved rule last executed to be %v, got %v", lastExecuted, rule.LastExecuted)
+			}
+			if rule.SuppressedCount != suppressedCount {
+				t.Errorf("Expected saved rule suppressed count to be %d, got %d", suppressedCount, rule.SuppressedCount)
+			}
+		case <-time.After(100 * time.Millisecond):
+			t.Fatalf("Expected rule to be saved")
+		}
+	}
+
+	t.Run("Firings during the rule cooldown are suppressed", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		rule := models.Rule{
+			ID:           1,
+			LastExecuted: start,
+			Cooldown:     time.Hour,
+			Triggers:     []models.Trigger{trigger1},
+		}
+
+		mockExecutionService := services.NewMockExecutionService(mockCtrl)
+		cancel, triggeredChan, saved := startRuleWatcher(t, mockCtrl, rule, mockExecutionService, 1)
+		defer cancel()
+
+		triggeredChan <- TriggerEvent{Trigger: trigger1, Time: start.Add(30 * time.Minute)}
+		expectSaved(t, saved, start, 1)
+
+		triggeredChan <- TriggerEvent{Trigger: trigger1, Time: start.Add(59 * time.Minute)}
+		expectSaved(t, saved, start, 2)
+
+		triggeredChan <- TriggerEvent{Trigger: trigger1, Time: start.Add(time.Hour)}
+		expectSaved(t, saved, start.Add(time.Hour), 2)
+
+		// Wait for the action to be executed
+		triggeredChan <- TriggerEvent{Trigger: trigger1, Time: start.Add(time.Hour + time.Minute)}
+		expectSaved(t, saved, start.Add(time.Hour), 3)
+	})
+
+	t.Run("Firings exceeding the rule executions budget are suppressed", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		rule := models.Rule{
+			ID:              1,
+			MaxExecutions:   2,
+			ExecutionPeriod: time.Hour,
+			Triggers:        []models.Trigger{trigger1},
+		}
+
+		mockExecutionService := services.NewMockExecutionService(mockCtrl)
+		gomock.InOrder(
+			mockExecutionService.EXPECT().
+				List(gomock.Any(), 1, start.Add(time.Minute-time.Hour), 2).
+				Return([]models.Execution{models.Execution{ID: 1}, models.Execution{ID: 2}}, nil),
+			mockExecutionService.EXPECT().
+				List(gomock.Any(), 1, start.Add(2*time.Minute-time.Hour), 2).
+				Return([]models.Execution{models.Execution{ID: 2}}, nil),
+			mockExecutionService.EXPECT().
+				List(gomock.Any(), 1, start.Add(3*time.Minute-time.Hour), 2).
+				Return([]models.Execution{models.Execution{ID: 2}, models.Execution{ID: 3}}, nil),
+		)
+
+		cancel, triggeredChan, saved := startRuleWatcher(t, mockCtrl, rule, mockExecutionService, 1)
+		defer cancel()
+
+		triggeredChan <- TriggerEvent{Trigger: trigger1, Time: start.Add(time.Minute)}
+		expectSaved(t, saved, time.Time{}, 1)
+
+		triggeredChan <- TriggerEvent{Trigger: trigger1, Time: start.Add(2 * time.Minute)}
+		expectSaved(t, saved, start.Add(2*time.Minute), 1)
+
+		// Wait for the action to be executed
+		triggeredChan <- TriggerEvent{Trigger: trigger1, Time: start.Add(3 * time.Minute)}
+		expectSaved(t, saved, start.Add(2*time.Minute), 2)
+	})
 }
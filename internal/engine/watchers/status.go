@@ -0,0 +1,274 @@
+// Copyright 2020 Teserakt AG
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package watchers
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/teserakt-io/automation-engine/internal/clock"
+	"github.com/teserakt-io/automation-engine/internal/models"
+	"github.com/teserakt-io/automation-engine/internal/pb"
+)
+
+// StatusTracker keeps the state of the rules and their triggers in the automation engine,
+// along with their last errors. It is updated by the engine and the watchers.
+type StatusTracker interface {
+	// RuleStatus returns the status of given rule, and false when the rule is not tracked
+	RuleStatus(ruleID int) (models.RuleStatus, bool)
+	// RuleStatuses returns the status of every tracked rules, sorted by rule ID
+	RuleStatuses() []models.RuleStatus
+
+	// SetRuleState sets the state of given rule. Setting a rule running forgets
+	// its triggers status, and stopping it stops its triggers still running.
+	SetRuleState(ruleID int, state pb.RuleWatcherState)
+	// RemoveRule stops tracking given rule
+	RemoveRule(ruleID int)
+	// RuleError records an error of given rule, making it erroring when running
+	RuleError(ruleID int, err error)
+	// RuleExecuted makes an erroring rule running again
+	RuleExecuted(ruleID int)
+
+	// TriggerStarted records the trigger as running
+	TriggerStarted(ruleID, triggerID int)
+	// TriggerStopped records the trigger as stopped, unless it is invalid
+	TriggerStopped(ruleID, triggerID int)
+	// TriggerError records an error of given trigger, making it invalid
+	// when err is an InvalidTrigger, or erroring otherwise
+	TriggerError(ruleID, triggerID int, err error)
+	// TriggerFired records the time the trigger fired, making an erroring trigger running again
+	TriggerFired(ruleID, triggerID int)
+	// TriggerScheduled records the next time a scheduled trigger will fire, zero when it has no more runs
+	TriggerScheduled(ruleID, triggerID int, next time.Time)
+	// TriggerCounted records the number of events an EVENT trigger counted
+	TriggerCounted(ruleID, triggerID int, counter int)
+}
+
+type statusTracker struct {
+	clock clock.Clock
+
+	lock  sync.RWMutex
+	rules map[int]*ruleStatus
+}
+
+type ruleStatus struct {
+	models.RuleStatus
+	triggers map[int]*models.TriggerStatus
+}
+
+var _ StatusTracker = &statusTracker{}
+
+// NewStatusTracker creates a new StatusTracker, recording the errors time from given clock
+func NewStatusTracker(clock clock.Clock) StatusTracker {
+	return &statusTracker{
+		clock: clock,
+		rules: make(map[int]*ruleStatus),
+	}
+}
+
+func (t *statusTracker) RuleStatus(ruleID int) (models.RuleStatus, bool) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	status, ok := t.rules[ruleID]
+	if !ok {
+		return models.RuleStatus{}, false
+	}
+
+	return status.copy(), true
+}
+
+func (t *statusTracker) RuleStatuses() []models.RuleStatus {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	statuses := make([]models.RuleStatus, 0, len(t.rules))
+	for _, status := range t.rules {
+		statuses = append(statuses, status.copy())
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].RuleID < statuses[j].RuleID
+	})
+
+	return statuses
+}
+
+func (t *statusTracker) SetRuleState(ruleID int, state pb.RuleWatcherState) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	status := t.rule(ruleID)
+	status.State = state
+
+	switch state {
+	case pb.RuleWatcherState_RULE_RUNNING:
+		status.triggers = make(map[int]*models.TriggerStatus)
+	case pb.RuleWatcherState_RULE_STOPPED, pb.RuleWatcherState_RULE_PAUSED:
+		for _, triggerStatus := range status.triggers {
+			stopTrigger(triggerStatus)
+		}
+	}
+}
+
+func (t *statusTracker) RemoveRule(ruleID int) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	delete(t.rules, ruleID)
+}
+
+func (t *statusTracker) RuleError(ruleID int, err error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	status := t.rule(ruleID)
+	status.LastError = err.Error()
+	status.LastErrorTime = t.clock.Now()
+	status.ErrorCount++
+
+	if status.State == pb.RuleWatcherState_RULE_RUNNING {
+		status.State = pb.RuleWatcherState_RULE_ERRORING
+	}
+}
+
+func (t *statusTracker) RuleExecuted(ruleID int) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	status := t.rule(ruleID)
+	if status.State == pb.RuleWatcherState_RULE_ERRORING {
+		status.State = pb.RuleWatcherState_RULE_RUNNING
+	}
+}
+
+func (t *statusTracker) TriggerStarted(ruleID, triggerID int) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	triggerStatus := t.trigger(ruleID, triggerID)
+	if triggerStatus.State != pb.TriggerWatcherState_TRIGGER_INVALID {
+		triggerStatus.State = pb.TriggerWatcherState_TRIGGER_RUNNING
+	}
+}
+
+func (t *statusTracker) TriggerStopped(ruleID, triggerID int) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	stopTrigger(t.trigger(ruleID, triggerID))
+}
+
+func (t *statusTracker) TriggerError(ruleID, triggerID int, err error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	triggerStatus := t.trigger(ruleID, triggerID)
+	triggerStatus.LastError = err.Error()
+	triggerStatus.LastErrorTime = t.clock.Now()
+	triggerStatus.ErrorCount++
+
+	switch {
+	case isInvalidTrigger(err):
+		triggerStatus.State = pb.TriggerWatcherState_TRIGGER_INVALID
+	case triggerStatus.State == pb.TriggerWatcherState_TRIGGER_RUNNING:
+		triggerStatus.State = pb.TriggerWatcherState_TRIGGER_ERRORING
+	}
+}
+
+func (t *statusTracker) TriggerFired(ruleID, triggerID int) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	triggerStatus := t.trigger(ruleID, triggerID)
+	triggerStatus.LastTriggered = t.clock.Now()
+
+	if triggerStatus.State == pb.TriggerWatcherState_TRIGGER_ERRORING {
+		triggerStatus.State = pb.TriggerWatcherState_TRIGGER_RUNNING
+	}
+}
+
+func (t *statusTracker) TriggerScheduled(ruleID, triggerID int, next time.Time) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.trigger(ruleID, triggerID).NextFire = next
+}
+
+func (t *statusTracker) TriggerCounted(ruleID, triggerID int, counter int) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.trigger(ruleID, triggerID).Counter = counter
+}
+
+// rule returns the status of given rule, tracking it when it is not yet.
+// Callers must hold the tracker lock.
+func (t *statusTracker) rule(ruleID int) *ruleStatus {
+	status, ok := t.rules[ruleID]
+	if !ok {
+		status = &ruleStatus{
+			RuleStatus: models.RuleStatus{RuleID: ruleID},
+			triggers:   make(map[int]*models.TriggerStatus),
+		}
+		t.rules[ruleID] = status
+	}
+
+	return status
+}
+
+// trigger returns the status of given trigger, tracking it when it is not yet.
+// Callers must hold the tracker lock.
+func (t *statusTracker) trigger(ruleID, triggerID int) *models.TriggerStatus {
+	status := t.rule(ruleID)
+
+	triggerStatus, ok := status.triggers[triggerID]
+	if !ok {
+		triggerStatus = &models.TriggerStatus{TriggerID: triggerID}
+		status.triggers[triggerID] = triggerStatus
+	}
+
+	return triggerStatus
+}
+
+// copy returns the rule status along with its triggers status, sorted by trigger ID
+func (s *ruleStatus) copy() models.RuleStatus {
+	status := s.RuleStatus
+	status.Triggers = make([]models.TriggerStatus, 0, len(s.triggers))
+	for _, triggerStatus := range s.triggers {
+		status.Triggers = append(status.Triggers, *triggerStatus)
+	}
+
+	sort.Slice(status.Triggers, func(i, j int) bool {
+		return status.Triggers[i].TriggerID < status.Triggers[j].TriggerID
+	})
+
+	return status
+}
+
+func stopTrigger(triggerStatus *models.TriggerStatus) {
+	// A stopped trigger won't fire anymore
+	triggerStatus.NextFire = time.Time{}
+	if triggerStatus.State != pb.TriggerWatcherState_TRIGGER_INVALID {
+		triggerStatus.State = pb.TriggerWatcherState_TRIGGER_STOPPED
+	}
+}
+
+func isInvalidTrigger(err error) bool {
+	_, ok := err.(InvalidTrigger)
+
+	return ok
+}
@@ -0,0 +1,141 @@
+// Copyright 2020 Teserakt AG
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package watchers
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/teserakt-io/automation-engine/internal/clock"
+	"github.com/teserakt-io/automation-engine/internal/models"
+	"github.com/teserakt-io/automation-engine/internal/pb"
+)
+
+func TestStatusTracker(t *testing.T) {
+	now := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	testClock := clock.NewFake(now)
+
+	t.Run("RuleStatus returns false for untracked rules", func(t *testing.T) {
+		tracker := NewStatusTracker(testClock)
+
+		if status, ok := tracker.RuleStatus(1); ok {
+			t.Errorf("Expected rule to not be tracked, got %#v", status)
+		}
+	})
+
+	t.Run("Trigger errors are tracked in the trigger status", func(t *testing.T) {
+		tracker := NewStatusTracker(testClock)
+
+		tracker.SetRuleState(1, pb.RuleWatcherState_RULE_RUNNING)
+		tracker.TriggerStarted(1, 2)
+		tracker.TriggerStarted(1, 1)
+		tracker.TriggerStarted(1, 3)
+
+		tracker.TriggerError(1, 1, InvalidTrigger{errors.New("invalid expr")})
+		tracker.TriggerStopped(1, 1)
+		tracker.TriggerError(1, 2, errors.New("state not saved"))
+		tracker.TriggerFired(1, 3)
+
+		expectedStatus := models.RuleStatus{
+			RuleID: 1,
+			State:  pb.RuleWatcherState_RULE_RUNNING,
+			Triggers: []models.TriggerStatus{
+				models.TriggerStatus{
+					TriggerID:     1,
+					State:         pb.TriggerWatcherState_TRIGGER_INVALID,
+					LastError:     "invalid expr",
+					LastErrorTime: now,
+					ErrorCount:    1,
+				},
+				models.TriggerStatus{
+					TriggerID:     2,
+					State:         pb.TriggerWatcherState_TRIGGER_ERRORING,
+					LastError:     "state not saved",
+					LastErrorTime: now,
+					ErrorCount:    1,
+				},
+				models.TriggerStatus{
+					TriggerID:     3,
+					State:         pb.TriggerWatcherState_TRIGGER_RUNNING,
+					LastTriggered: now,
+				},
+			},
+		}
+
+		status, ok := tracker.RuleStatus(1)
+		if !ok {
+			t.Fatalf("Expected rule to be tracked")
+		}
+
+		if reflect.DeepEqual(status, expectedStatus) == false {
+			t.Errorf("Expected status to be %#v, got %#v", expectedStatus, status)
+		}
+
+		// Firing makes an erroring trigger run again, and stopping the rule stops its valid triggers
+		tracker.TriggerFired(1, 2)
+		status, _ = tracker.RuleStatus(1)
+		if status.Triggers[1].State != pb.TriggerWatcherState_TRIGGER_RUNNING {
+			t.Errorf("Expected trigger 2 to be running, got %s", status.Triggers[1].State)
+		}
+
+		tracker.SetRuleState(1, pb.RuleWatcherState_RULE_STOPPED)
+		status, _ = tracker.RuleStatus(1)
+		expectedStates := []pb.TriggerWatcherState{
+			pb.TriggerWatcherState_TRIGGER_INVALID,
+			pb.TriggerWatcherState_TRIGGER_STOPPED,
+			pb.TriggerWatcherState_TRIGGER_STOPPED,
+		}
+		for i, expectedState := range expectedStates {
+			if status.Triggers[i].State != expectedState {
+				t.Errorf("Expected trigger %d to be %s, got %s", status.Triggers[i].TriggerID, expectedState, status.Triggers[i].State)
+			}
+		}
+
+		// Starting the rule again forgets its previous triggers
+		tracker.SetRuleState(1, pb.RuleWatcherState_RULE_RUNNING)
+		status, _ = tracker.RuleStatus(1)
+		if len(status.Triggers) != 0 {
+			t.Errorf("Expected no triggers status, got %#v", status.Triggers)
+		}
+	})
+
+	t.Run("Rule errors make the rule erroring until it executes", func(t *testing.T) {
+		tracker := NewStatusTracker(testClock)
+
+		tracker.SetRuleState(1, pb.RuleWatcherState_RULE_RUNNING)
+		tracker.RuleError(1, errors.New("action failed"))
+
+		status, _ := tracker.RuleStatus(1)
+		if status.State != pb.RuleWatcherState_RULE_ERRORING {
+			t.Errorf("Expected rule to be erroring, got %s", status.State)
+		}
+		if status.LastError != "action failed" || status.LastErrorTime != now || status.ErrorCount != 1 {
+			t.Errorf("Expected rule last error to be recorded, got %#v", status)
+		}
+
+		tracker.RuleExecuted(1)
+		status, _ = tracker.RuleStatus(1)
+		if status.State != pb.RuleWatcherState_RULE_RUNNING || status.LastError != "action failed" {
+			t.Errorf("Expected rule to be running and keep its last error, got %#v", status)
+		}
+
+		tracker.RemoveRule(1)
+		if _, ok := tracker.RuleStatus(1); ok {
+			t.Errorf("Expected removed rule to not be tracked")
+		}
+	})
+}
@@ -17,14 +17,19 @@ package watchers
 //go:generate mockgen -copyright_file ../../../doc/COPYRIGHT_TEMPLATE.txt -destination=trigger_mocks.go -package watchers -self_package github.com/teserakt-io/automation-engine/internal/engine/watchers github.com/teserakt-io/automation-engine/internal/engine/watchers TriggerWatcher
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"math/rand"
+	"regexp"
 	"time"
 
+	"github.com/golang/protobuf/ptypes"
 	"github.com/gorhill/cronexpr"
 	log "github.com/sirupsen/logrus"
 	c2pb "github.com/teserakt-io/c2/pkg/pb"
 
+	"github.com/teserakt-io/automation-engine/internal/clock"
 	"github.com/teserakt-io/automation-engine/internal/events"
 	"github.com/teserakt-io/automation-engine/internal/models"
 	"github.com/teserakt-io/automation-engine/internal/pb"
@@ -35,9 +40,12 @@ import (
 type TriggerEvent struct {
 	Trigger models.Trigger
 	Time    time.Time
+	// Event is the C2 event which caused the trigger to fire, nil when not triggered by an event
+	Event *c2pb.Event
 }
 
-// TriggerWatcher defines an interface for types watching on a trigger
+// TriggerWatcher defines an interface for types watching on a trigger.
+// UpdateLastExecuted must not block, even once Start returned.
 type TriggerWatcher interface {
 	Start(context.Context)
 	UpdateLastExecuted(time.Time) error
@@ -53,7 +61,9 @@ func (e InvalidTrigger) Error() string {
 }
 
 type schedulerWatcher struct {
-	validator models.TriggerValidator
+	validator     models.TriggerValidator
+	statusTracker StatusTracker
+	clock         clock.Clock
 
 	trigger       models.Trigger
 	triggeredChan chan<- TriggerEvent
@@ -62,6 +72,7 @@ type schedulerWatcher struct {
 
 	updateChan   chan time.Time
 	lastExecuted time.Time
+	resumedAt    time.Time
 }
 
 func (w *schedulerWatcher) Start(ctx context.Context) {
@@ -91,23 +102,94 @@ func (w *schedulerWatcher) Start(ctx context.Context) {
 		return
 	}
 
+	loc, err := settings.Location()
+	if err != nil {
+		w.errorChan <- InvalidTrigger{fmt.Errorf("failed to load trigger timezone: %v", err)}
+		return
+	}
+
+	notBefore, notAfter, err := settings.Bounds()
+	if err != nil {
+		w.errorChan <- InvalidTrigger{fmt.Errorf("failed to parse trigger bounds: %v", err)}
+		return
+	}
+
+	sched := schedule{
+		expr:          expr,
+		loc:           loc,
+		notBefore:     notBefore,
+		notAfter:      notAfter,
+		misfirePolicy: settings.Misfire(),
+		maxLateness:   settings.MaxLatenessDuration(),
+	}
+
+	// pending holds the number of missed runs still to be fired
+	var pending int
+	if !w.resumedAt.IsZero() {
+		// The runs missed while the rule was paused are fired once on resume, and the misfire policy
+		// only applies to the ones missed since then
+		if sched.dueWhilePaused(w.lastExecuted, w.resumedAt) {
+			pending++
+		}
+
+		missed, _, _ := sched.missedRuns(w.resumedAt, w.clock.Now())
+		pending += sched.dueRuns(missed)
+		w.lastExecuted = w.resumedAt
+
+		logger.WithFields(log.Fields{
+			"resumedAt":     w.resumedAt,
+			"due":           pending,
+			"misfirePolicy": sched.misfirePolicy,
+		}).Info("trigger resumed")
+	}
+
 	for {
-		var delay time.Duration
+		// trigger stays nil, and so never fires, once the schedule is over
+		var trigger <-chan time.Time
+		// next stays zero once the schedule is over
+		var next time.Time
+
+		now := w.clock.Now()
+		if pending == 0 {
+			missed, nextTime, ok := sched.missedRuns(w.lastExecuted, now)
+			if missed > 0 {
+				pending = sched.dueRuns(missed)
+				logger.WithFields(log.Fields{
+					"missed":        missed,
+					"due":           pending,
+					"misfirePolicy": sched.misfirePolicy,
+				}).Info("trigger missed scheduled runs")
+			}
 
-		nextTime := expr.Next(w.lastExecuted)
+			if pending == 0 {
+				if ok {
+					next = sched.jittered(nextTime, settings.JitterDuration())
+				} else {
+					logger.WithField("notAfter", notAfter).Info("trigger schedule is over")
+				}
+			}
+		}
 
-		if now := time.Now(); nextTime.After(now) {
-			delay = nextTime.Sub(now)
+		if pending > 0 {
+			next = sched.jittered(now, settings.JitterDuration())
+		}
+
+		w.statusTracker.TriggerScheduled(w.trigger.RuleID, w.trigger.ID, next)
+		if !next.IsZero() {
+			trigger = w.clock.After(next.Sub(now))
 		}
 
-		trigger := time.After(delay)
 		select {
 		case <-ctx.Done():
 			logger.WithError(ctx.Err()).Warn("stopping trigger schedulerWatcher")
 			return
 
 		case <-trigger:
-			now := time.Now()
+			if pending > 0 {
+				pending--
+			}
+
+			now := w.clock.Now()
 
 			w.triggeredChan <- TriggerEvent{
 				Trigger: w.trigger,
@@ -120,8 +202,227 @@ func (w *schedulerWatcher) Start(ctx context.Context) {
 	}
 }
 
+// schedule holds the parsed settings of a time interval trigger
+type schedule struct {
+	expr          *cronexpr.Expression
+	loc           *time.Location
+	notBefore     time.Time
+	notAfter      time.Time
+	misfirePolicy string
+	maxLateness   time.Duration
+}
+
+// missedRuns returns how many runs were due after lastExecuted and up to now, ignoring the ones
+// late by more than the schedule maxLateness, along with the time of the next run after now.
+// A never executed schedule, having a zero lastExecuted, counts as having missed a run
+// when now is within the schedule bounds.
+// ok is false when there is no next run, the schedule being over.
+func (s schedule) missedRuns(lastExecuted time.Time, now time.Time) (missed int, next time.Time, ok bool) {
+	if lastExecuted.IsZero() {
+		// No run can be computed from a zero lastExecuted, consider the missed run as due now
+		if !now.Before(s.notBefore) && (s.notAfter.IsZero() || !now.After(s.notAfter)) {
+			missed++
+		}
+	} else {
+		runTime := lastExecuted
+		for {
+			runTime, ok = boundedNextRun(s.expr, runTime, s.loc, s.notBefore, s.notAfter)
+			if !ok || runTime.After(now) {
+				break
+			}
+
+			if s.maxLateness == 0 || now.Sub(runTime) <= s.maxLateness {
+				missed++
+			}
+		}
+	}
+
+	next, ok = boundedNextRun(s.expr, now, s.loc, s.notBefore, s.notAfter)
+
+	return missed, next, ok
+}
+
+// dueWhilePaused returns whether a run was due after lastExecuted and up to resumedAt, while the rule
+// was paused. Unlike missedRuns, it ignores the schedule maxLateness, as the rule could not execute then.
+func (s schedule) dueWhilePaused(lastExecuted time.Time, resumedAt time.Time) bool {
+	paused := s
+	paused.maxLateness = 0
+	missed, _, _ := paused.missedRuns(lastExecuted, resumedAt)
+
+	return missed > 0
+}
+
+// dueRuns returns how many of the missed runs must be fired, according to the schedule misfire policy
+func (s schedule) dueRuns(missed int) int {
+	switch s.misfirePolicy {
+	case pb.MisfirePolicySkip:
+		return 0
+	case pb.MisfirePolicyFireAll:
+		return missed
+	default:
+		if missed > 0 {
+			return 1
+		}
+
+		return 0
+	}
+}
+
+// nextRun returns the next time expr is due after lastExecuted, evaluating expr in the loc time zone.
+// On DST transitions, a skipped local time is shifted forward by the transition offset,
+// and a repeated local time is only due once.
+func nextRun(expr *cronexpr.Expression, lastExecuted time.Time, loc *time.Location) time.Time {
+	return expr.Next(lastExecuted.In(loc))
+}
+
+// boundedNextRun returns the next time expr is due after lastExecuted, no earlier than notBefore.
+// It returns false when there is no next run before notAfter, or when expr has no next run at all,
+// like from a zero lastExecuted. Zero bounds are ignored.
+func boundedNextRun(expr *cronexpr.Expression, lastExecuted time.Time, loc *time.Location, notBefore, notAfter time.Time) (time.Time, bool) {
+	if !notAfter.IsZero() && lastExecuted.After(notAfter) {
+		return time.Time{}, false
+	}
+
+	if !notBefore.IsZero() && lastExecuted.Before(notBefore) {
+		// Let a run due at notBefore exactly happen
+		lastExecuted = notBefore.Add(-time.Nanosecond)
+	}
+
+	nextTime := nextRun(expr, lastExecuted, loc)
+	if nextTime.IsZero() || (!notAfter.IsZero() && nextTime.After(notAfter)) {
+		return time.Time{}, false
+	}
+
+	return nextTime, true
+}
+
+// jittered returns runTime delayed by a random jitter, no later than the schedule notAfter when set
+func (s schedule) jittered(runTime time.Time, jitter time.Duration) time.Time {
+	next := runTime.Add(randomJitter(jitter))
+	if !s.notAfter.IsZero() && next.After(s.notAfter) {
+		return s.notAfter
+	}
+
+	return next
+}
+
+// randomJitter returns a random duration in [0, max), or 0 when max is not positive
+func randomJitter(max time.Duration) time.Duration {
+	if max <= 0 {
+		return 0
+	}
+
+	return time.Duration(rand.Int63n(int64(max)))
+}
+
 func (w *schedulerWatcher) UpdateLastExecuted(lastExecuted time.Time) error {
-	w.updateChan <- lastExecuted
+	sendLastExecuted(w.updateChan, lastExecuted)
+
+	return nil
+}
+
+// sendLastExecuted sends lastExecuted on a trigger watcher updateChan, replacing the value
+// the watcher did not receive yet, if any. Only the latest value matters to the watcher, and
+// so sending on a buffered updateChan never blocks, even when the watcher already returned.
+func sendLastExecuted(updateChan chan time.Time, lastExecuted time.Time) {
+	select {
+	case <-updateChan:
+	default:
+	}
+
+	updateChan <- lastExecuted
+}
+
+type onceWatcher struct {
+	triggerStateService services.TriggerStateService
+	validator           models.TriggerValidator
+	statusTracker       StatusTracker
+	clock               clock.Clock
+
+	trigger       models.Trigger
+	triggeredChan chan<- TriggerEvent
+	errorChan     chan<- error
+	logger        log.FieldLogger
+
+	updateChan chan time.Time
+}
+
+func (w *onceWatcher) Start(ctx context.Context) {
+	logger := w.logger.WithFields(log.Fields{
+		"trigger": w.trigger.ID,
+		"rule":    w.trigger.RuleID,
+	})
+
+	// Validate trigger
+	if err := w.validator.ValidateTrigger(w.trigger); err != nil {
+		w.errorChan <- InvalidTrigger{fmt.Errorf("failed to validate trigger: %v", err)}
+		return
+	}
+
+	// Decode settings
+	settings := &pb.TriggerSettingsOnce{}
+	if err := settings.Decode(w.trigger.Settings); err != nil {
+		w.errorChan <- InvalidTrigger{fmt.Errorf("failed to decode trigger settings: %v", err)}
+		return
+	}
+
+	at, err := settings.Time()
+	if err != nil {
+		w.errorChan <- InvalidTrigger{fmt.Errorf("failed to parse trigger time: %v", err)}
+		return
+	}
+
+	state, err := w.triggerStateService.ByTriggerID(ctx, w.trigger.ID)
+	if err != nil {
+		w.errorChan <- fmt.Errorf("failed to fetch trigger state: %v", err)
+
+		return
+	}
+
+	// trigger stays nil, and so never fires, when the trigger is already done
+	var trigger <-chan time.Time
+	if state.Done {
+		logger.WithField("at", at).Info("trigger onceWatcher already fired")
+	} else {
+		var delay time.Duration
+		if now := w.clock.Now(); at.After(now) {
+			delay = at.Sub(now)
+		}
+
+		trigger = w.clock.After(delay)
+		w.statusTracker.TriggerScheduled(w.trigger.RuleID, w.trigger.ID, at)
+	}
+
+	logger.Info("started trigger onceWatcher")
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.WithError(ctx.Err()).Warn("stopping trigger onceWatcher")
+			return
+
+		case <-trigger:
+			now := w.clock.Now()
+
+			w.triggeredChan <- TriggerEvent{
+				Trigger: w.trigger,
+				Time:    now,
+			}
+			trigger = nil
+			w.statusTracker.TriggerScheduled(w.trigger.RuleID, w.trigger.ID, time.Time{})
+
+			state.Done = true
+			if err := w.triggerStateService.Save(ctx, &state); err != nil {
+				w.errorChan <- fmt.Errorf("failed to save trigger state: %v", err)
+			}
+
+		case <-w.updateChan:
+		}
+	}
+}
+
+func (w *onceWatcher) UpdateLastExecuted(lastExecuted time.Time) error {
+	sendLastExecuted(w.updateChan, lastExecuted)
 
 	return nil
 }
@@ -130,6 +431,8 @@ type eventWatcher struct {
 	streamListenerFactory events.StreamListenerFactory
 	triggerStateService   services.TriggerStateService
 	validator             models.TriggerValidator
+	statusTracker         StatusTracker
+	clock                 clock.Clock
 
 	trigger       models.Trigger
 	targets       []models.Target
@@ -139,6 +442,16 @@ type eventWatcher struct {
 
 	updateChan   chan time.Time
 	lastExecuted time.Time
+
+	targetMatchers []targetMatcher
+	includeFilters []eventFilter
+	excludeFilters []eventFilter
+}
+
+// targetMatcher holds a rule target along with its compiled expression
+type targetMatcher struct {
+	target models.Target
+	re     *regexp.Regexp
 }
 
 func (w *eventWatcher) Start(ctx context.Context) {
@@ -155,7 +468,25 @@ func (w *eventWatcher) Start(ctx context.Context) {
 		return
 	}
 
-	lis := w.streamListenerFactory.Create(events.DefaultListenerBufSize, settings.EventType)
+	// Compile targets expressions once, to match them against every received events
+	targetMatchers, err := compileTargets(w.targets)
+	if err != nil {
+		w.errorChan <- InvalidTrigger{fmt.Errorf("failed to compile rule targets: %v", err)}
+		return
+	}
+	w.targetMatchers = targetMatchers
+
+	if w.includeFilters, err = compileEventFilters(settings.Include); err != nil {
+		w.errorChan <- InvalidTrigger{fmt.Errorf("failed to compile include filters: %v", err)}
+		return
+	}
+
+	if w.excludeFilters, err = compileEventFilters(settings.Exclude); err != nil {
+		w.errorChan <- InvalidTrigger{fmt.Errorf("failed to compile exclude filters: %v", err)}
+		return
+	}
+
+	lis := w.streamListenerFactory.Create(events.DefaultListenerBufSize, settings.WatchedEventTypes()...)
 	defer lis.Close()
 
 	state, err := w.triggerStateService.ByTriggerID(ctx, w.trigger.ID)
@@ -168,10 +499,11 @@ func (w *eventWatcher) Start(ctx context.Context) {
 	logger := w.logger.WithFields(log.Fields{
 		"trigger": w.trigger.ID,
 		"rule":    w.trigger.RuleID,
-		"event":   settings.EventType,
+		"events":  settings.WatchedEventTypes(),
 	})
 
 	logger.Info("started trigger eventWatcher")
+	w.statusTracker.TriggerCounted(w.trigger.RuleID, w.trigger.ID, state.Counter)
 
 	for {
 		select {
@@ -181,25 +513,28 @@ func (w *eventWatcher) Start(ctx context.Context) {
 
 		case evt := <-lis.C():
 			origCounter := state.Counter
+			origOccurrences := state.Occurrences
 
-			if w.matchTargets(evt) {
-				// Increment trigger counter in state
-				state.Counter++
+			now := w.clock.Now()
+			if err := w.countEvent(&state, evt, settings.WindowDuration(), now); err != nil {
+				w.errorChan <- fmt.Errorf("failed to count event: %v", err)
 			}
 
 			if state.Counter >= settings.MaxOccurrence {
 				//Trigger the rule action and reset the counter
-				now := time.Now()
 				w.triggeredChan <- TriggerEvent{
 					Trigger: w.trigger,
 					Time:    now,
+					Event:   &evt,
 				}
 				w.lastExecuted = now
 				state.Counter = 0
+				state.Occurrences = nil
 			}
 
-			// Save state when counter has been modified
-			if state.Counter != origCounter {
+			// Save state when counter or occurrences have been modified
+			if state.Counter != origCounter || !bytes.Equal(state.Occurrences, origOccurrences) {
+				w.statusTracker.TriggerCounted(w.trigger.RuleID, w.trigger.ID, state.Counter)
 				logger.WithField("state", state).Info("saving trigger state")
 				if err := w.triggerStateService.Save(ctx, &state); err != nil {
 					w.errorChan <- fmt.Errorf("failed to save trigger state: %v", err)
@@ -212,30 +547,180 @@ func (w *eventWatcher) Start(ctx context.Context) {
 }
 
 func (w *eventWatcher) UpdateLastExecuted(lastExecuted time.Time) error {
-	w.updateChan <- lastExecuted
+	sendLastExecuted(w.updateChan, lastExecuted)
 
 	return nil
 }
 
+// countEvent increments the state counter when evt passes the trigger filters and matches the rule targets.
+// When window is not 0, the state holds the time of each counted event instead, and the ones
+// older than window are dropped, so the counter only holds the events received during the window.
+func (w *eventWatcher) countEvent(state *models.TriggerState, evt c2pb.Event, window time.Duration, now time.Time) error {
+	matched := w.matchFilters(evt) && w.matchTargets(evt)
+
+	if window == 0 {
+		if matched {
+			state.Counter++
+		}
+
+		return nil
+	}
+
+	times, err := state.OccurrenceTimes()
+	if err != nil {
+		// Start counting again rather than blocking the trigger on a corrupted state
+		times = nil
+		err = fmt.Errorf("failed to decode trigger state occurrences: %v", err)
+	}
+
+	var kept []time.Time
+	for _, t := range times {
+		if now.Sub(t) < window {
+			kept = append(kept, t)
+		}
+	}
+
+	if matched {
+		kept = append(kept, now)
+	}
+
+	if setErr := state.SetOccurrenceTimes(kept); setErr != nil {
+		return setErr
+	}
+
+	return err
+}
+
+// matchFilters returns true when evt matches one of the include filters, if any, and none of the exclude filters
+func (w *eventWatcher) matchFilters(evt c2pb.Event) bool {
+	for _, filter := range w.excludeFilters {
+		if filter.match(evt) {
+			return false
+		}
+	}
+
+	if len(w.includeFilters) == 0 {
+		return true
+	}
+
+	for _, filter := range w.includeFilters {
+		if filter.match(evt) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (w *eventWatcher) matchTargets(evt c2pb.Event) bool {
-	for _, target := range w.targets {
-		switch target.Type {
+	for _, matcher := range w.targetMatchers {
+		switch matcher.target.Type {
 		case pb.TargetType_CLIENT:
-			if target.Expr == evt.Source {
+			if matcher.re.MatchString(evt.Source) {
 				return true
 			}
 		case pb.TargetType_TOPIC:
-			if target.Expr == evt.Target {
+			if matcher.re.MatchString(evt.Target) {
 				return true
 			}
 		case pb.TargetType_ANY:
-			if target.Expr == evt.Source || target.Expr == evt.Target {
+			if matcher.re.MatchString(evt.Source) || matcher.re.MatchString(evt.Target) {
 				return true
 			}
 		default:
-			w.logger.WithField("type", target.Type).Warn("unknown target type")
+			w.logger.WithField("type", matcher.target.Type).Warn("unknown target type")
 		}
 	}
 
 	return false
 }
+
+func compileTargets(targets []models.Target) ([]targetMatcher, error) {
+	var matchers []targetMatcher
+	for _, target := range targets {
+		re, err := target.Regexp()
+		if err != nil {
+			return nil, fmt.Errorf("invalid expression for target %d: %v", target.ID, err)
+		}
+
+		matchers = append(matchers, targetMatcher{target: target, re: re})
+	}
+
+	return matchers, nil
+}
+
+// eventFilter holds a pb.EventFilter, with its expressions compiled and its time range parsed
+type eventFilter struct {
+	source *regexp.Regexp
+	target *regexp.Regexp
+	// from and to are the time of day range bounds, as durations since midnight UTC
+	hasTimeRange bool
+	from         time.Duration
+	to           time.Duration
+}
+
+func compileEventFilters(filters []pb.EventFilter) ([]eventFilter, error) {
+	var compiled []eventFilter
+	for _, filter := range filters {
+		if err := filter.Validate(); err != nil {
+			return nil, err
+		}
+
+		var f eventFilter
+		if len(filter.Source) > 0 {
+			f.source = regexp.MustCompile("^(?:" + filter.Source + ")$")
+		}
+
+		if len(filter.Target) > 0 {
+			f.target = regexp.MustCompile("^(?:" + filter.Target + ")$")
+		}
+
+		if len(filter.From) > 0 {
+			from, _ := time.Parse(pb.EventFilterTimeLayout, filter.From)
+			to, _ := time.Parse(pb.EventFilterTimeLayout, filter.To)
+
+			f.hasTimeRange = true
+			f.from = timeOfDay(from)
+			f.to = timeOfDay(to)
+		}
+
+		compiled = append(compiled, f)
+	}
+
+	return compiled, nil
+}
+
+func (f eventFilter) match(evt c2pb.Event) bool {
+	if f.source != nil && !f.source.MatchString(evt.Source) {
+		return false
+	}
+
+	if f.target != nil && !f.target.MatchString(evt.Target) {
+		return false
+	}
+
+	if f.hasTimeRange {
+		timestamp, err := ptypes.Timestamp(evt.Timestamp)
+		if err != nil {
+			return false
+		}
+
+		t := timeOfDay(timestamp.UTC())
+		if f.from <= f.to {
+			return t >= f.from && t < f.to
+		}
+
+		// The range wraps over midnight
+		return t >= f.from || t < f.to
+	}
+
+	return true
+}
+
+// timeOfDay returns the duration elapsed since the beginning of the day of t
+func timeOfDay(t time.Time) time.Duration {
+	return time.Duration(t.Hour())*time.Hour +
+		time.Duration(t.Minute())*time.Minute +
+		time.Duration(t.Second())*time.Second +
+		time.Duration(t.Nanosecond())
+}
@@ -23,9 +23,13 @@ import (
 	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/gorhill/cronexpr"
 	log "github.com/sirupsen/logrus"
 	c2pb "github.com/teserakt-io/c2/pkg/pb"
 
+	"github.com/teserakt-io/automation-engine/internal/clock"
 	"github.com/teserakt-io/automation-engine/internal/events"
 	"github.com/teserakt-io/automation-engine/internal/models"
 	"github.com/teserakt-io/automation-engine/internal/pb"
@@ -56,6 +60,8 @@ func TestSchedulerTriggerWatcher(t *testing.T) {
 	errorChan := make(chan error)
 
 	watcher := &schedulerWatcher{
+		statusTracker: NewStatusTracker(clock.New()),
+		clock:         clock.New(),
 		validator:     mockValidator,
 		trigger:       trigger,
 		triggeredChan: triggeredChan,
@@ -105,7 +111,9 @@ func TestSchedulerTriggerWatcher(t *testing.T) {
 		}
 
 		invalidWatcher := &schedulerWatcher{
-			validator: mockValidator,
+			statusTracker: NewStatusTracker(clock.New()),
+			clock:         clock.New(),
+			validator:     mockValidator,
 
 			trigger:       invalidTrigger,
 			triggeredChan: triggeredChan,
@@ -148,7 +156,9 @@ func TestSchedulerTriggerWatcher(t *testing.T) {
 		}
 
 		invalidWatcher := &schedulerWatcher{
-			validator: mockValidator,
+			statusTracker: NewStatusTracker(clock.New()),
+			clock:         clock.New(),
+			validator:     mockValidator,
 
 			trigger:       invalidTrigger,
 			triggeredChan: triggeredChan,
@@ -233,6 +243,602 @@ func TestSchedulerTriggerWatcher(t *testing.T) {
 
 }
 
+func TestSchedulerNextRun(t *testing.T) {
+	zurich, err := time.LoadLocation("Europe/Zurich")
+	if err != nil {
+		t.Fatalf("Failed to load location: %v", err)
+	}
+
+	testCases := []struct {
+		name         string
+		expr         string
+		lastExecuted time.Time
+		loc          *time.Location
+		expected     time.Time
+	}{
+		{
+			name:         "local time is kept when entering DST",
+			expr:         "0 9 * * *",
+			lastExecuted: time.Date(2020, 3, 28, 8, 0, 0, 0, time.UTC), // 09:00 CET
+			loc:          zurich,
+			expected:     time.Date(2020, 3, 29, 7, 0, 0, 0, time.UTC), // 09:00 CEST
+		},
+		{
+			name:         "local time is kept when leaving DST",
+			expr:         "0 9 * * *",
+			lastExecuted: time.Date(2020, 10, 24, 7, 0, 0, 0, time.UTC), // 09:00 CEST
+			loc:          zurich,
+			expected:     time.Date(2020, 10, 25, 8, 0, 0, 0, time.UTC), // 09:00 CET
+		},
+		{
+			name:         "skipped local time is shifted forward",
+			expr:         "30 2 * * *",
+			lastExecuted: time.Date(2020, 3, 28, 1, 30, 0, 0, time.UTC), // 02:30 CET
+			loc:          zurich,
+			expected:     time.Date(2020, 3, 29, 1, 30, 0, 0, time.UTC), // 03:30 CEST, as 02:30 does not exist
+		},
+		{
+			name:         "repeated local time is due once",
+			expr:         "30 2 * * *",
+			lastExecuted: time.Date(2020, 10, 25, 1, 30, 0, 0, time.UTC), // second 02:30, CET
+			loc:          zurich,
+			expected:     time.Date(2020, 10, 26, 1, 30, 0, 0, time.UTC),
+		},
+		{
+			name:         "UTC is not affected by DST",
+			expr:         "0 9 * * *",
+			lastExecuted: time.Date(2020, 3, 28, 9, 0, 0, 0, time.UTC),
+			loc:          time.UTC,
+			expected:     time.Date(2020, 3, 29, 9, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			next := nextRun(cronexpr.MustParse(testCase.expr), testCase.lastExecuted, testCase.loc)
+			if !next.Equal(testCase.expected) {
+				t.Errorf("Expected next run to be %v, got %v", testCase.expected, next.UTC())
+			}
+		})
+	}
+}
+
+func TestSchedulerBoundedNextRun(t *testing.T) {
+	expr := cronexpr.MustParse("0 2 * * *")
+	lastExecuted := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name      string
+		notBefore time.Time
+		notAfter  time.Time
+		expected  time.Time
+		ok        bool
+	}{
+		{
+			name:     "no bounds",
+			expected: time.Date(2020, 6, 2, 2, 0, 0, 0, time.UTC),
+			ok:       true,
+		},
+		{
+			name:      "runs are delayed until notBefore",
+			notBefore: time.Date(2020, 6, 10, 12, 0, 0, 0, time.UTC),
+			expected:  time.Date(2020, 6, 11, 2, 0, 0, 0, time.UTC),
+			ok:        true,
+		},
+		{
+			name:      "run due at notBefore exactly is kept",
+			notBefore: time.Date(2020, 6, 10, 2, 0, 0, 0, time.UTC),
+			expected:  time.Date(2020, 6, 10, 2, 0, 0, 0, time.UTC),
+			ok:        true,
+		},
+		{
+			name:      "past notBefore is ignored",
+			notBefore: time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC),
+			expected:  time.Date(2020, 6, 2, 2, 0, 0, 0, time.UTC),
+			ok:        true,
+		},
+		{
+			name:     "run before notAfter is due",
+			notAfter: time.Date(2020, 6, 2, 2, 0, 0, 0, time.UTC),
+			expected: time.Date(2020, 6, 2, 2, 0, 0, 0, time.UTC),
+			ok:       true,
+		},
+		{
+			name:     "run after notAfter is not due",
+			notAfter: time.Date(2020, 6, 2, 1, 0, 0, 0, time.UTC),
+			ok:       false,
+		},
+		{
+			name:     "no run is due after a past notAfter",
+			notAfter: time.Date(2020, 5, 31, 2, 0, 0, 0, time.UTC),
+			ok:       false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			next, ok := boundedNextRun(expr, lastExecuted, time.UTC, testCase.notBefore, testCase.notAfter)
+			if ok != testCase.ok {
+				t.Fatalf("Expected ok to be %v, got %v", testCase.ok, ok)
+			}
+
+			if !next.Equal(testCase.expected) {
+				t.Errorf("Expected next run to be %v, got %v", testCase.expected, next)
+			}
+		})
+	}
+}
+
+func TestScheduleMisfire(t *testing.T) {
+	expr := cronexpr.MustParse("0 * * * *")
+	at := func(hour, min int) time.Time {
+		return time.Date(2020, 6, 1, hour, min, 0, 0, time.UTC)
+	}
+
+	t.Run("missedRuns counts the runs due up to now", func(t *testing.T) {
+		testCases := []struct {
+			name         string
+			sched        schedule
+			lastExecuted time.Time
+			now          time.Time
+			missed       int
+			next         time.Time
+			ok           bool
+		}{
+			{
+				name:         "no missed runs",
+				sched:        schedule{expr: expr, loc: time.UTC},
+				lastExecuted: at(3, 0),
+				now:          at(3, 30),
+				missed:       0,
+				next:         at(4, 0),
+				ok:           true,
+			},
+			{
+				name:         "every missed run is counted",
+				sched:        schedule{expr: expr, loc: time.UTC},
+				lastExecuted: at(0, 0),
+				now:          at(3, 30),
+				missed:       3,
+				next:         at(4, 0),
+				ok:           true,
+			},
+			{
+				name:         "run due now is missed",
+				sched:        schedule{expr: expr, loc: time.UTC},
+				lastExecuted: at(2, 0),
+				now:          at(3, 0),
+				missed:       1,
+				next:         at(4, 0),
+				ok:           true,
+			},
+			{
+				name:         "runs later than maxLateness are dropped",
+				sched:        schedule{expr: expr, loc: time.UTC, maxLateness: time.Hour},
+				lastExecuted: at(0, 0),
+				now:          at(3, 30),
+				missed:       1,
+				next:         at(4, 0),
+				ok:           true,
+			},
+			{
+				name:         "never executed schedule missed a run",
+				sched:        schedule{expr: expr, loc: time.UTC},
+				lastExecuted: time.Time{},
+				now:          at(3, 30),
+				missed:       1,
+				next:         at(4, 0),
+				ok:           true,
+			},
+			{
+				name:         "runs after notAfter are not missed",
+				sched:        schedule{expr: expr, loc: time.UTC, notAfter: at(2, 30)},
+				lastExecuted: at(0, 0),
+				now:          at(3, 30),
+				missed:       2,
+				ok:           false,
+			},
+			{
+				name:         "never executed schedule past notAfter missed no run",
+				sched:        schedule{expr: cronexpr.MustParse("0 2 * * *"), loc: time.UTC, notAfter: at(3, 30).Add(-24 * time.Hour)},
+				lastExecuted: time.Time{},
+				now:          at(3, 30),
+				missed:       0,
+				ok:           false,
+			},
+			{
+				name:         "never executed schedule before notBefore missed no run",
+				sched:        schedule{expr: expr, loc: time.UTC, notBefore: at(5, 30)},
+				lastExecuted: time.Time{},
+				now:          at(3, 30),
+				missed:       0,
+				next:         at(6, 0),
+				ok:           true,
+			},
+		}
+
+		for _, testCase := range testCases {
+			t.Run(testCase.name, func(t *testing.T) {
+				missed, next, ok := testCase.sched.missedRuns(testCase.lastExecuted, testCase.now)
+				if missed != testCase.missed {
+					t.Errorf("Expected %d missed runs, got %d", testCase.missed, missed)
+				}
+
+				if ok != testCase.ok {
+					t.Errorf("Expected ok to be %v, got %v", testCase.ok, ok)
+				}
+
+				if !next.Equal(testCase.next) {
+					t.Errorf("Expected next run to be %v, got %v", testCase.next, next)
+				}
+			})
+		}
+	})
+
+	t.Run("dueRuns applies the misfire policy", func(t *testing.T) {
+		testCases := map[string][]int{
+			// policy: due runs for 0, 1 and 3 missed runs
+			pb.MisfirePolicyFireOnce: []int{0, 1, 1},
+			pb.MisfirePolicySkip:     []int{0, 0, 0},
+			pb.MisfirePolicyFireAll:  []int{0, 1, 3},
+		}
+
+		for policy, expected := range testCases {
+			sched := schedule{misfirePolicy: policy}
+			for i, missed := range []int{0, 1, 3} {
+				if due := sched.dueRuns(missed); due != expected[i] {
+					t.Errorf("Expected %s policy to fire %d runs for %d missed, got %d", policy, expected[i], missed, due)
+				}
+			}
+		}
+	})
+
+	t.Run("Start applies the misfire policy", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mockValidator := models.NewMockTriggerValidator(mockCtrl)
+
+		logger := log.New()
+		logger.SetOutput(ioutil.Discard)
+
+		testCases := map[string]int{
+			pb.MisfirePolicyFireOnce: 1,
+			pb.MisfirePolicySkip:     0,
+			pb.MisfirePolicyFireAll:  3,
+		}
+
+		for policy, expectedFires := range testCases {
+			ctx, cancel := context.WithCancel(context.Background())
+
+			triggerSettings := pb.TriggerSettingsTimeInterval{Expr: "0 * * * *", Timezone: "UTC", MisfirePolicy: policy}
+			encodedSettings, err := triggerSettings.Encode()
+			if err != nil {
+				t.Fatalf("Failed to encode settings: %s", err)
+			}
+
+			trigger := models.Trigger{Settings: encodedSettings}
+			triggeredChan := make(chan TriggerEvent)
+			fakeClock := clock.NewFake(at(3, 30))
+
+			watcher := &schedulerWatcher{
+				statusTracker: NewStatusTracker(clock.New()),
+				validator:     mockValidator,
+				clock:         fakeClock,
+				trigger:       trigger,
+				triggeredChan: triggeredChan,
+				errorChan:     make(chan error),
+				updateChan:    make(chan time.Time, 1),
+				lastExecuted:  at(0, 0),
+				logger:        logger,
+			}
+
+			mockValidator.EXPECT().ValidateTrigger(trigger).Return(nil)
+
+			go watcher.Start(ctx)
+
+			for i := 0; i < expectedFires; i++ {
+				evt := <-triggeredChan
+				if !evt.Time.Equal(at(3, 30)) {
+					t.Errorf("Expected %s policy to fire at %v, got %v", policy, at(3, 30), evt.Time)
+				}
+			}
+
+			// Once waiting for the next run, the watcher can't fire any missed one anymore
+			fakeClock.BlockUntil(1)
+			if next, _ := fakeClock.NextWaiter(); !next.Equal(at(4, 0)) {
+				t.Errorf("Expected %s policy to wait for next run at %v, got %v", policy, at(4, 0), next)
+			}
+
+			cancel()
+		}
+	})
+
+	t.Run("Start applies the resume policy separately from the misfire policy", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mockValidator := models.NewMockTriggerValidator(mockCtrl)
+
+		logger := log.New()
+		logger.SetOutput(ioutil.Discard)
+
+		// The 1:00 and 2:00 runs are missed while paused, and the 3:00 one since the 2:30 resume
+		testCases := []struct {
+			misfirePolicy string
+			maxLateness   string
+			expectedFires int
+		}{
+			{pb.MisfirePolicySkip, "", 1},
+			{pb.MisfirePolicyFireOnce, "", 2},
+			{pb.MisfirePolicyFireAll, "", 2},
+			{pb.MisfirePolicyFireAll, "10m", 1},
+		}
+
+		for _, testCase := range testCases {
+			ctx, cancel := context.WithCancel(context.Background())
+
+			triggerSettings := pb.TriggerSettingsTimeInterval{
+				Expr:          "0 * * * *",
+				Timezone:      "UTC",
+				MisfirePolicy: testCase.misfirePolicy,
+				MaxLateness:   testCase.maxLateness,
+			}
+			encodedSettings, err := triggerSettings.Encode()
+			if err != nil {
+				t.Fatalf("Failed to encode settings: %s", err)
+			}
+
+			trigger := models.Trigger{Settings: encodedSettings}
+			triggeredChan := make(chan TriggerEvent)
+			fakeClock := clock.NewFake(at(3, 30))
+
+			watcher := &schedulerWatcher{
+				statusTracker: NewStatusTracker(clock.New()),
+				validator:     mockValidator,
+				clock:         fakeClock,
+				trigger:       trigger,
+				triggeredChan: triggeredChan,
+				errorChan:     make(chan error),
+				updateChan:    make(chan time.Time, 1),
+				lastExecuted:  at(0, 0),
+				resumedAt:     at(2, 30),
+				logger:        logger,
+			}
+
+			mockValidator.EXPECT().ValidateTrigger(trigger).Return(nil)
+
+			go watcher.Start(ctx)
+
+			for i := 0; i < testCase.expectedFires; i++ {
+				evt := <-triggeredChan
+				if !evt.Time.Equal(at(3, 30)) {
+					t.Errorf("Expected %s policy to fire at %v, got %v", testCase.misfirePolicy, at(3, 30), evt.Time)
+				}
+			}
+
+			fakeClock.BlockUntil(1)
+			if next, _ := fakeClock.NextWaiter(); !next.Equal(at(4, 0)) {
+				t.Errorf(
+					"Expected %s policy with maxLateness %q to fire %d times and wait for next run at %v, got %v",
+					testCase.misfirePolicy,
+					testCase.maxLateness,
+					testCase.expectedFires,
+					at(4, 0),
+					next,
+				)
+			}
+
+			cancel()
+		}
+	})
+}
+
+func TestSchedulerTriggerWatcherSimulation(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockValidator := models.NewMockTriggerValidator(mockCtrl)
+
+	logger := log.New()
+	logger.SetOutput(ioutil.Discard)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	zurich, err := time.LoadLocation("Europe/Zurich")
+	if err != nil {
+		t.Fatalf("Failed to load location: %v", err)
+	}
+
+	triggerSettings := pb.TriggerSettingsTimeInterval{Expr: "0 2 * * *", Timezone: "Europe/Zurich"}
+	encodedSettings, err := triggerSettings.Encode()
+	if err != nil {
+		t.Fatalf("Failed to encode settings: %s", err)
+	}
+
+	trigger := models.Trigger{Settings: encodedSettings}
+	triggeredChan := make(chan TriggerEvent)
+
+	start := time.Date(2020, 3, 15, 12, 0, 0, 0, zurich)
+	fakeClock := clock.NewFake(start)
+
+	statusTracker := NewStatusTracker(fakeClock)
+	watcher := &schedulerWatcher{
+		statusTracker: statusTracker,
+		validator:     mockValidator,
+		clock:         fakeClock,
+		trigger:       trigger,
+		triggeredChan: triggeredChan,
+		errorChan:     make(chan error),
+		updateChan:    make(chan time.Time, 1),
+		lastExecuted:  start,
+		logger:        logger,
+	}
+
+	mockValidator.EXPECT().ValidateTrigger(trigger).Return(nil)
+
+	go watcher.Start(ctx)
+
+	// Simulate a month of daily runs, across the DST transition
+	for day := 16; day < 46; day++ {
+		fakeClock.BlockUntil(1)
+		next, _ := fakeClock.NextWaiter()
+
+		// The next fire time is recorded before waiting for it
+		status, _ := statusTracker.RuleStatus(trigger.RuleID)
+		if len(status.Triggers) != 1 || !status.Triggers[0].NextFire.Equal(next) {
+			t.Fatalf("Expected trigger next fire to be %v, got %#v", next, status.Triggers)
+		}
+
+		fakeClock.Set(next)
+
+		evt := <-triggeredChan
+
+		expected := time.Date(2020, 3, day, 2, 0, 0, 0, zurich)
+		if day == 29 {
+			// 02:00 doesn't exist on DST transition day
+			expected = time.Date(2020, 3, day, 3, 0, 0, 0, zurich)
+		}
+
+		if !evt.Time.Equal(expected) {
+			t.Fatalf("Expected run to happen at %v, got %v", expected, evt.Time.In(zurich))
+		}
+	}
+}
+
+func TestRandomJitter(t *testing.T) {
+	if jitter := randomJitter(0); jitter != 0 {
+		t.Errorf("Expected no jitter, got %v", jitter)
+	}
+
+	max := 30 * time.Second
+	for i := 0; i < 100; i++ {
+		if jitter := randomJitter(max); jitter < 0 || jitter >= max {
+			t.Fatalf("Expected jitter to be within [0, %v), got %v", max, jitter)
+		}
+	}
+}
+
+func TestOnceTriggerWatcher(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockValidator := models.NewMockTriggerValidator(mockCtrl)
+	mockTriggerStateService := services.NewMockTriggerStateService(mockCtrl)
+
+	logger := log.New()
+	logger.SetOutput(ioutil.Discard)
+
+	newWatcher := func(at time.Time) (*onceWatcher, chan TriggerEvent, chan error) {
+		triggerSettings := pb.TriggerSettingsOnce{At: at.Format(time.RFC3339)}
+		encodedSettings, err := triggerSettings.Encode()
+		if err != nil {
+			t.Fatalf("failed to encode trigger settings: %v", err)
+		}
+
+		triggeredChan := make(chan TriggerEvent)
+		errorChan := make(chan error)
+
+		return &onceWatcher{
+			statusTracker:       NewStatusTracker(clock.New()),
+			clock:               clock.New(),
+			triggerStateService: mockTriggerStateService,
+			validator:           mockValidator,
+			trigger:             models.Trigger{ID: 1, TriggerType: pb.TriggerType_ONCE, Settings: encodedSettings},
+			triggeredChan:       triggeredChan,
+			errorChan:           errorChan,
+			updateChan:          make(chan time.Time),
+			logger:              logger,
+		}, triggeredChan, errorChan
+	}
+
+	t.Run("Start properly return errors with invalid trigger", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		watcher, _, errorChan := newWatcher(time.Now())
+
+		mockValidator.EXPECT().ValidateTrigger(watcher.trigger).Return(errors.New("bad trigger"))
+
+		go watcher.Start(ctx)
+
+		select {
+		case err := <-errorChan:
+			if _, ok := err.(InvalidTrigger); !ok {
+				t.Errorf("Expected error to be of type InvalidTrigger, got %T", err)
+			}
+		case <-time.After(100 * time.Millisecond):
+			t.Errorf("Expected an error when starting onceWatcher with invalid trigger")
+		}
+	})
+
+	t.Run("Start fires once when due and marks the trigger done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		watcher, triggeredChan, _ := newWatcher(time.Now().Add(-time.Hour))
+
+		savedChan := make(chan models.TriggerState)
+		mockValidator.EXPECT().ValidateTrigger(watcher.trigger).Return(nil)
+		mockTriggerStateService.EXPECT().ByTriggerID(gomock.Any(), watcher.trigger.ID).Return(models.TriggerState{TriggerID: 1}, nil)
+		mockTriggerStateService.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, state *models.TriggerState) error {
+				savedChan <- *state
+				return nil
+			},
+		)
+
+		go watcher.Start(ctx)
+
+		select {
+		case evt := <-triggeredChan:
+			if reflect.DeepEqual(evt.Trigger, watcher.trigger) == false {
+				t.Errorf("Expected triggered trigger to be %#v, got %#v", watcher.trigger, evt.Trigger)
+			}
+		case <-time.After(100 * time.Millisecond):
+			t.Fatalf("Expected onceWatcher to fire")
+		}
+
+		select {
+		case state := <-savedChan:
+			if !state.Done {
+				t.Errorf("Expected saved state to be done")
+			}
+		case <-time.After(100 * time.Millisecond):
+			t.Fatalf("Expected onceWatcher to save its state")
+		}
+
+		if err := watcher.UpdateLastExecuted(time.Now()); err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+
+		select {
+		case <-triggeredChan:
+			t.Errorf("Expected onceWatcher to not fire twice")
+		case <-time.After(50 * time.Millisecond):
+		}
+	})
+
+	t.Run("Start does not fire when the trigger is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		watcher, triggeredChan, _ := newWatcher(time.Now().Add(-time.Hour))
+
+		mockValidator.EXPECT().ValidateTrigger(watcher.trigger).Return(nil)
+		mockTriggerStateService.EXPECT().ByTriggerID(gomock.Any(), watcher.trigger.ID).Return(models.TriggerState{TriggerID: 1, Done: true}, nil)
+
+		go watcher.Start(ctx)
+
+		select {
+		case <-triggeredChan:
+			t.Errorf("Expected onceWatcher to not fire")
+		case <-time.After(50 * time.Millisecond):
+		}
+	})
+}
+
 func TestEventTriggerWatcher(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer func() {
@@ -268,6 +874,8 @@ func TestEventTriggerWatcher(t *testing.T) {
 		}
 
 		watcher := &eventWatcher{
+			statusTracker:         NewStatusTracker(clock.New()),
+			clock:                 clock.New(),
 			validator:             mockValidator,
 			streamListenerFactory: mockStreamListenerFactory,
 			triggerStateService:   mockTriggerStateService,
@@ -328,7 +936,10 @@ func TestEventTriggerWatcher(t *testing.T) {
 		initialLastExecuted := time.Now().Add(-2 * time.Minute)
 		targetTopic := "testTopic1"
 
+		statusTracker := NewStatusTracker(clock.New())
 		watcher := &eventWatcher{
+			statusTracker:         statusTracker,
+			clock:                 clock.New(),
 			validator:             mockValidator,
 			streamListenerFactory: mockStreamListenerFactory,
 			triggerStateService:   mockTriggerStateService,
@@ -381,6 +992,12 @@ func TestEventTriggerWatcher(t *testing.T) {
 		case <-time.After(10 * time.Millisecond):
 		}
 
+		// The counted events are recorded in the trigger status
+		status, _ := statusTracker.RuleStatus(trigger.RuleID)
+		if len(status.Triggers) != 1 || status.Triggers[0].Counter != 1 {
+			t.Errorf("Expected trigger counter to be 1, got %#v", status.Triggers)
+		}
+
 		// Valid target again, expecting trigger
 		mockTriggerStateService.EXPECT().Save(gomock.Any(), gomock.Any())
 
@@ -442,6 +1059,8 @@ func TestEventTriggerWatcher(t *testing.T) {
 		target := "TargetType_ANY"
 
 		watcher := &eventWatcher{
+			statusTracker:         NewStatusTracker(clock.New()),
+			clock:                 clock.New(),
 			validator:             mockValidator,
 			streamListenerFactory: mockStreamListenerFactory,
 			triggerStateService:   mockTriggerStateService,
@@ -533,6 +1152,8 @@ func TestEventTriggerWatcher(t *testing.T) {
 		target := "client1"
 
 		watcher := &eventWatcher{
+			statusTracker:         NewStatusTracker(clock.New()),
+			clock:                 clock.New(),
 			validator:             mockValidator,
 			streamListenerFactory: mockStreamListenerFactory,
 			triggerStateService:   mockTriggerStateService,
@@ -593,6 +1214,90 @@ func TestEventTriggerWatcher(t *testing.T) {
 		cancel()
 	})
 
+	t.Run("Start counts the events received during the window, restored from the trigger state", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		triggerSettings := pb.TriggerSettingsEvent{
+			EventType:     pb.EventTypeClientUnsubscribed,
+			MaxOccurrence: 2,
+			Window:        "10m",
+		}
+
+		triggeredChan := make(chan TriggerEvent)
+		errorChan := make(chan error)
+
+		encodedSettings, err := triggerSettings.Encode()
+		if err != nil {
+			t.Fatalf("failed to encode trigger settings: %v", err)
+		}
+
+		trigger := models.Trigger{
+			ID:          2,
+			TriggerType: pb.TriggerType_EVENT,
+			Settings:    encodedSettings,
+		}
+
+		// Persisted before a restart: one event out of the window, and one inside it
+		triggerState := models.TriggerState{TriggerID: trigger.ID}
+		if err := triggerState.SetOccurrenceTimes([]time.Time{
+			time.Now().Add(-time.Hour),
+			time.Now().Add(-time.Minute),
+		}); err != nil {
+			t.Fatalf("failed to set trigger state occurrences: %v", err)
+		}
+
+		watcher := &eventWatcher{
+			statusTracker:         NewStatusTracker(clock.New()),
+			clock:                 clock.New(),
+			validator:             mockValidator,
+			streamListenerFactory: mockStreamListenerFactory,
+			triggerStateService:   mockTriggerStateService,
+			trigger:               trigger,
+			targets: []models.Target{
+				models.Target{Type: pb.TargetType_CLIENT, Expr: "client1"},
+			},
+			triggeredChan: triggeredChan,
+			logger:        logger,
+
+			updateChan: make(chan time.Time),
+			errorChan:  errorChan,
+		}
+
+		mockValidator.EXPECT().ValidateTrigger(trigger).Return(nil)
+
+		mockStreamListener := events.NewMockStreamListener(mockCtrl)
+		mockStreamListener.EXPECT().Close()
+
+		eventChan := make(chan c2pb.Event, 1)
+
+		mockStreamListener.EXPECT().C().Return(eventChan).AnyTimes()
+		mockStreamListenerFactory.EXPECT().Create(events.DefaultListenerBufSize, pb.EventTypeClientUnsubscribed).Return(mockStreamListener)
+
+		mockTriggerStateService.EXPECT().ByTriggerID(gomock.Any(), trigger.ID).Return(triggerState, nil)
+		mockTriggerStateService.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, state *models.TriggerState) error {
+				if state.Counter != 0 || state.Occurrences != nil {
+					t.Errorf("Expected trigger state to be reset, got %#v", state)
+				}
+
+				return nil
+			},
+		)
+
+		go watcher.Start(ctx)
+
+		eventChan <- c2pb.Event{Type: c2pb.EventType_CLIENT_UNSUBSCRIBED, Source: "client1", Target: "topic1"}
+		select {
+		case err := <-errorChan:
+			t.Errorf("Expected no error, got %v", err)
+		case <-triggeredChan:
+		case <-time.After(10 * time.Millisecond):
+			t.Errorf("Expected a trigger event, got timeout")
+		}
+
+		cancel() // do not defer, or mockCtrl will miss some calls...
+	})
+
 	t.Run("UpdateLastExecuted properly update the watcher lastExecuted", func(t *testing.T) {
 		ctx, cancel := context.WithCancel(context.Background())
 
@@ -625,6 +1330,8 @@ func TestEventTriggerWatcher(t *testing.T) {
 		target := "client1"
 
 		watcher := &eventWatcher{
+			statusTracker:         NewStatusTracker(clock.New()),
+			clock:                 clock.New(),
 			validator:             mockValidator,
 			streamListenerFactory: mockStreamListenerFactory,
 			triggerStateService:   mockTriggerStateService,
@@ -673,3 +1380,348 @@ func TestEventTriggerWatcher(t *testing.T) {
 	})
 
 }
+
+func TestEventWatcherMatchTargets(t *testing.T) {
+	logger := log.New()
+	logger.SetOutput(ioutil.Discard)
+
+	testCases := []struct {
+		name     string
+		target   models.Target
+		event    c2pb.Event
+		expected bool
+	}{
+		{
+			name:     "CLIENT target matches event source",
+			target:   models.Target{Type: pb.TargetType_CLIENT, Expr: "sensor-.*"},
+			event:    c2pb.Event{Source: "sensor-42", Target: "/topic"},
+			expected: true,
+		},
+		{
+			name:     "CLIENT target doesn't match event target",
+			target:   models.Target{Type: pb.TargetType_CLIENT, Expr: "sensor-.*"},
+			event:    c2pb.Event{Source: "client", Target: "sensor-42"},
+			expected: false,
+		},
+		{
+			name:     "CLIENT target is anchored",
+			target:   models.Target{Type: pb.TargetType_CLIENT, Expr: "sensor-.*"},
+			event:    c2pb.Event{Source: "old-sensor-42"},
+			expected: false,
+		},
+		{
+			name:     "CLIENT literal target matches exact source only",
+			target:   models.Target{Type: pb.TargetType_CLIENT, Expr: "sensor"},
+			event:    c2pb.Event{Source: "sensor-42"},
+			expected: false,
+		},
+		{
+			name:     "TOPIC target matches event target",
+			target:   models.Target{Type: pb.TargetType_TOPIC, Expr: "/devices/(groupA|groupB)"},
+			event:    c2pb.Event{Source: "client", Target: "/devices/groupB"},
+			expected: true,
+		},
+		{
+			name:     "TOPIC target doesn't match event source",
+			target:   models.Target{Type: pb.TargetType_TOPIC, Expr: "/devices/.*"},
+			event:    c2pb.Event{Source: "/devices/groupA", Target: "/other"},
+			expected: false,
+		},
+		{
+			name:     "TOPIC target is anchored",
+			target:   models.Target{Type: pb.TargetType_TOPIC, Expr: "/devices/groupA"},
+			event:    c2pb.Event{Target: "/devices/groupA/sub"},
+			expected: false,
+		},
+		{
+			name:     "ANY target matches event source",
+			target:   models.Target{Type: pb.TargetType_ANY, Expr: "thing-[0-9]+"},
+			event:    c2pb.Event{Source: "thing-1", Target: "/topic"},
+			expected: true,
+		},
+		{
+			name:     "ANY target matches event target",
+			target:   models.Target{Type: pb.TargetType_ANY, Expr: "thing-[0-9]+"},
+			event:    c2pb.Event{Source: "client", Target: "thing-2"},
+			expected: true,
+		},
+		{
+			name:     "ANY target doesn't match unrelated event",
+			target:   models.Target{Type: pb.TargetType_ANY, Expr: "thing-[0-9]+"},
+			event:    c2pb.Event{Source: "thing-a", Target: "thing-"},
+			expected: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			matchers, err := compileTargets([]models.Target{testCase.target})
+			if err != nil {
+				t.Fatalf("Expected no error compiling targets, got %v", err)
+			}
+
+			watcher := &eventWatcher{
+				statusTracker:  NewStatusTracker(clock.New()),
+				clock:          clock.New(),
+				logger:         logger,
+				targetMatchers: matchers,
+			}
+
+			if match := watcher.matchTargets(testCase.event); match != testCase.expected {
+				t.Errorf("Expected matchTargets to return %t, got %t", testCase.expected, match)
+			}
+		})
+	}
+
+	t.Run("compileTargets returns an error on invalid expressions", func(t *testing.T) {
+		_, err := compileTargets([]models.Target{models.Target{Type: pb.TargetType_CLIENT, Expr: "sensor-("}})
+		if err == nil {
+			t.Errorf("Expected an error when compiling an invalid target expression")
+		}
+	})
+}
+
+func TestEventWatcherMatchFilters(t *testing.T) {
+	at := func(hour, min int) *timestamp.Timestamp {
+		ts, _ := ptypes.TimestampProto(time.Date(2020, 1, 1, hour, min, 0, 0, time.UTC))
+		return ts
+	}
+
+	testCases := []struct {
+		name     string
+		include  []pb.EventFilter
+		exclude  []pb.EventFilter
+		event    c2pb.Event
+		expected bool
+	}{
+		{
+			name:     "no filters matches every event",
+			event:    c2pb.Event{Source: "client"},
+			expected: true,
+		},
+		{
+			name:     "exclude source drops matching event",
+			exclude:  []pb.EventFilter{{Source: "test-.*"}},
+			event:    c2pb.Event{Source: "test-client"},
+			expected: false,
+		},
+		{
+			name:     "exclude source is anchored",
+			exclude:  []pb.EventFilter{{Source: "test-.*"}},
+			event:    c2pb.Event{Source: "my-test-client"},
+			expected: true,
+		},
+		{
+			name:     "include requires one matching filter",
+			include:  []pb.EventFilter{{Target: "/a"}, {Target: "/b"}},
+			event:    c2pb.Event{Target: "/b"},
+			expected: true,
+		},
+		{
+			name:     "include drops non matching event",
+			include:  []pb.EventFilter{{Target: "/a"}, {Target: "/b"}},
+			event:    c2pb.Event{Target: "/c"},
+			expected: false,
+		},
+		{
+			name:     "exclude wins over include",
+			include:  []pb.EventFilter{{Target: "/a"}},
+			exclude:  []pb.EventFilter{{Source: "admin"}},
+			event:    c2pb.Event{Source: "admin", Target: "/a"},
+			expected: false,
+		},
+		{
+			name:     "filter fields must all match",
+			include:  []pb.EventFilter{{Source: "client", Target: "/a"}},
+			event:    c2pb.Event{Source: "client", Target: "/b"},
+			expected: false,
+		},
+		{
+			name:     "include time range matches inside range",
+			include:  []pb.EventFilter{{From: "09:00", To: "17:00"}},
+			event:    c2pb.Event{Timestamp: at(12, 0)},
+			expected: true,
+		},
+		{
+			name:     "include time range excludes upper bound",
+			include:  []pb.EventFilter{{From: "09:00", To: "17:00"}},
+			event:    c2pb.Event{Timestamp: at(17, 0)},
+			expected: false,
+		},
+		{
+			name:     "exclude time range wrapping midnight",
+			exclude:  []pb.EventFilter{{From: "22:00", To: "06:00"}},
+			event:    c2pb.Event{Timestamp: at(23, 30)},
+			expected: false,
+		},
+		{
+			name:     "exclude time range wrapping midnight keeps daytime events",
+			exclude:  []pb.EventFilter{{From: "22:00", To: "06:00"}},
+			event:    c2pb.Event{Timestamp: at(12, 0)},
+			expected: true,
+		},
+		{
+			name:     "time range never matches events without timestamp",
+			include:  []pb.EventFilter{{From: "00:00", To: "23:59"}},
+			event:    c2pb.Event{},
+			expected: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			includeFilters, err := compileEventFilters(testCase.include)
+			if err != nil {
+				t.Fatalf("Expected no error compiling include filters, got %v", err)
+			}
+			excludeFilters, err := compileEventFilters(testCase.exclude)
+			if err != nil {
+				t.Fatalf("Expected no error compiling exclude filters, got %v", err)
+			}
+
+			w := &eventWatcher{includeFilters: includeFilters, excludeFilters: excludeFilters}
+			if got := w.matchFilters(testCase.event); got != testCase.expected {
+				t.Errorf("Expected matchFilters to be %v, got %v", testCase.expected, got)
+			}
+		})
+	}
+
+	t.Run("compileEventFilters rejects invalid filters", func(t *testing.T) {
+		if _, err := compileEventFilters([]pb.EventFilter{{Source: "("}}); err == nil {
+			t.Error("Expected an error compiling an invalid filter")
+		}
+	})
+}
+
+func TestEventWatcherCountEvent(t *testing.T) {
+	logger := log.New()
+	logger.SetOutput(ioutil.Discard)
+
+	matchers, err := compileTargets([]models.Target{models.Target{Type: pb.TargetType_TOPIC, Expr: "topic1"}})
+	if err != nil {
+		t.Fatalf("Expected no error compiling targets, got %v", err)
+	}
+
+	watcher := &eventWatcher{
+		statusTracker:  NewStatusTracker(clock.New()),
+		clock:          clock.New(),
+		logger:         logger,
+		targetMatchers: matchers,
+	}
+
+	now := time.Now().UTC().Round(0)
+	matchingEvent := c2pb.Event{Source: "client1", Target: "topic1"}
+	otherEvent := c2pb.Event{Source: "client1", Target: "topic2"}
+
+	newState := func(t *testing.T, times ...time.Time) models.TriggerState {
+		state := models.TriggerState{}
+		if err := state.SetOccurrenceTimes(times); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		return state
+	}
+
+	t.Run("Without window, matching events increment the counter", func(t *testing.T) {
+		state := models.TriggerState{Counter: 3}
+
+		if err := watcher.countEvent(&state, matchingEvent, 0, now); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if err := watcher.countEvent(&state, otherEvent, 0, now); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if state.Counter != 4 || state.Occurrences != nil {
+			t.Errorf("Expected counter to be 4 without occurrences, got %#v", state)
+		}
+	})
+
+	t.Run("With a window, only the events received during the window are counted", func(t *testing.T) {
+		state := newState(t, now.Add(-15*time.Minute), now.Add(-5*time.Minute))
+
+		if err := watcher.countEvent(&state, matchingEvent, 10*time.Minute, now); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		expectedTimes := []time.Time{now.Add(-5 * time.Minute), now}
+		times, err := state.OccurrenceTimes()
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if reflect.DeepEqual(times, expectedTimes) == false {
+			t.Errorf("Expected occurrences to be %v, got %v", expectedTimes, times)
+		}
+
+		if state.Counter != 2 {
+			t.Errorf("Expected counter to be 2, got %d", state.Counter)
+		}
+	})
+
+	t.Run("With a window, expired events are dropped on non matching events", func(t *testing.T) {
+		state := newState(t, now.Add(-15*time.Minute))
+
+		if err := watcher.countEvent(&state, otherEvent, 10*time.Minute, now); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if state.Counter != 0 || state.Occurrences != nil {
+			t.Errorf("Expected expired occurrences to be dropped, got %#v", state)
+		}
+	})
+
+	t.Run("With a window, corrupted occurrences are reset", func(t *testing.T) {
+		state := models.TriggerState{Counter: 2, Occurrences: []byte("not json")}
+
+		if err := watcher.countEvent(&state, matchingEvent, 10*time.Minute, now); err == nil {
+			t.Errorf("Expected an error on corrupted occurrences")
+		}
+
+		if state.Counter != 1 {
+			t.Errorf("Expected counter to restart from the received event, got %d", state.Counter)
+		}
+	})
+}
+
+func TestSendLastExecuted(t *testing.T) {
+	updateChan := make(chan time.Time, 1)
+	now := time.Now()
+
+	// Nothing receives on updateChan, as when the trigger watcher already returned
+	sendLastExecuted(updateChan, now.Add(-time.Minute))
+	sendLastExecuted(updateChan, now)
+
+	select {
+	case lastExecuted := <-updateChan:
+		if !lastExecuted.Equal(now) {
+			t.Errorf("Expected last executed to be %v, got %v", now, lastExecuted)
+		}
+	default:
+		t.Errorf("Expected last executed to be sent")
+	}
+}
+
+func TestScheduleJittered(t *testing.T) {
+	runTime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	max := 30 * time.Second
+
+	t.Run("jittered delays the run by up to the jitter", func(t *testing.T) {
+		sched := schedule{}
+		for i := 0; i < 100; i++ {
+			if next := sched.jittered(runTime, max); next.Before(runTime) || !next.Before(runTime.Add(max)) {
+				t.Fatalf("Expected jittered run to be within [%v, %v), got %v", runTime, runTime.Add(max), next)
+			}
+		}
+	})
+
+	t.Run("jittered never delays the run past notAfter", func(t *testing.T) {
+		notAfter := runTime.Add(time.Second)
+		sched := schedule{notAfter: notAfter}
+		for i := 0; i < 100; i++ {
+			if next := sched.jittered(runTime, max); next.Before(runTime) || next.After(notAfter) {
+				t.Fatalf("Expected jittered run to be within [%v, %v], got %v", runTime, notAfter, next)
+			}
+		}
+	})
+}
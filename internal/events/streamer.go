@@ -21,9 +21,14 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
+	"github.com/golang/protobuf/ptypes"
 	log "github.com/sirupsen/logrus"
+	c2pb "github.com/teserakt-io/c2/pkg/pb"
 
+	"github.com/teserakt-io/automation-engine/internal/clock"
+	"github.com/teserakt-io/automation-engine/internal/models"
 	"github.com/teserakt-io/automation-engine/internal/services"
 )
 
@@ -38,22 +43,30 @@ type Streamer interface {
 	AddListener(listener StreamListener)
 	RemoveListener(listener StreamListener) error
 	Listeners() []StreamListener
+	// Status returns the state of the stream, and the last event received from it
+	Status() models.EventStreamStatus
 }
 
 type streamer struct {
 	c2Client services.C2
+	clock    clock.Clock
 	logger   log.FieldLogger
 
 	listeners []StreamListener
 	lock      sync.RWMutex
+
+	status     models.EventStreamStatus
+	statusLock sync.RWMutex
 }
 
 var _ Streamer = (*streamer)(nil)
 
-// NewStreamer creates a new streamer factory
-func NewStreamer(c2Client services.C2, logger log.FieldLogger) Streamer {
+// NewStreamer creates a new streamer factory.
+// The stream status records when it got connected and received its last event from the given clock.
+func NewStreamer(c2Client services.C2, clock clock.Clock, logger log.FieldLogger) Streamer {
 	return &streamer{
 		c2Client:  c2Client,
+		clock:     clock,
 		logger:    logger,
 		listeners: []StreamListener{},
 	}
@@ -86,28 +99,80 @@ func (s *streamer) Listeners() []StreamListener {
 	return s.listeners
 }
 
+func (s *streamer) Status() models.EventStreamStatus {
+	s.lock.RLock()
+	listeners := len(s.listeners)
+	s.lock.RUnlock()
+
+	s.statusLock.RLock()
+	defer s.statusLock.RUnlock()
+
+	status := s.status
+	status.Listeners = listeners
+
+	return status
+}
+
+// setConnected records the stream as connected, or disconnected because of err
+func (s *streamer) setConnected(connected bool, err error) {
+	s.statusLock.Lock()
+	defer s.statusLock.Unlock()
+
+	s.status.Connected = connected
+	s.status.Since = s.clock.Now()
+	if err != nil {
+		s.status.LastError = err.Error()
+	}
+}
+
+// setLastEvent records evt as the last event received from the stream
+func (s *streamer) setLastEvent(evt *c2pb.Event) {
+	s.statusLock.Lock()
+	defer s.statusLock.Unlock()
+
+	s.status.LastEventType = evt.Type.String()
+	s.status.LastEventSource = evt.Source
+	s.status.LastEventTarget = evt.Target
+	s.status.LastEventTime = time.Time{}
+	if evt.Timestamp != nil {
+		if eventTime, err := ptypes.Timestamp(evt.Timestamp); err == nil {
+			s.status.LastEventTime = eventTime
+		}
+	}
+	s.status.LastEventReceived = s.clock.Now()
+}
+
 // StartStream will open a stream from the C2 clients, and
 // fan out every events it receive to all registered listeners.
 func (s *streamer) StartStream(ctx context.Context) error {
 	stream, err := s.c2Client.SubscribeToEventStream(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to start event stream: %v", err)
+		err = fmt.Errorf("failed to start event stream: %v", err)
+		s.setConnected(false, err)
+
+		return err
 	}
 
+	s.setConnected(true, nil)
 	s.logger.Info("started event streamer")
 
 	for {
 		select {
 		case <-ctx.Done():
 			s.logger.WithError(ctx.Err()).Warn("stopped event stream")
+			s.setConnected(false, ctx.Err())
+
 			return ctx.Err()
 		default:
 		}
 
 		evt, err := stream.Recv()
 		if err != nil {
+			s.setConnected(false, err)
+
 			return err
 		}
+		s.setLastEvent(evt)
 
 		s.lock.Lock()
 		for _, lis := range s.listeners {
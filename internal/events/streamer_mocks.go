@@ -22,6 +22,7 @@ package events
 import (
 	context "context"
 	gomock "github.com/golang/mock/gomock"
+	models "github.com/teserakt-io/automation-engine/internal/models"
 	reflect "reflect"
 )
 
@@ -101,3 +102,17 @@ func (mr *MockStreamerMockRecorder) StartStream(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartStream", reflect.TypeOf((*MockStreamer)(nil).StartStream), arg0)
 }
+
+// Status mocks base method
+func (m *MockStreamer) Status() models.EventStreamStatus {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Status")
+	ret0, _ := ret[0].(models.EventStreamStatus)
+	return ret0
+}
+
+// Status indicates an expected call of Status
+func (mr *MockStreamerMockRecorder) Status() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Status", reflect.TypeOf((*MockStreamer)(nil).Status))
+}
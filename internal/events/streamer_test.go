@@ -16,6 +16,7 @@ package events
 
 import (
 	"context"
+	"errors"
 	"io/ioutil"
 	"reflect"
 	"testing"
@@ -27,6 +28,7 @@ import (
 
 	c2pb "github.com/teserakt-io/c2/pkg/pb"
 
+	"github.com/teserakt-io/automation-engine/internal/clock"
 	"github.com/teserakt-io/automation-engine/internal/services"
 )
 
@@ -45,7 +47,7 @@ func TestStreamer(t *testing.T) {
 	logger.SetOutput(ioutil.Discard)
 
 	t.Run("Add / Remove listeners properly update the streamer", func(t *testing.T) {
-		streamer := NewStreamer(c2ClientMock, logger)
+		streamer := NewStreamer(c2ClientMock, clock.New(), logger)
 
 		if reflect.DeepEqual(streamer.Listeners(), []StreamListener{}) == false {
 			t.Errorf("Expected no listeners, got %#v", streamer.Listeners())
@@ -81,7 +83,7 @@ func TestStreamer(t *testing.T) {
 	})
 
 	t.Run("StartStream start streaming events from c2Client to all listeners", func(t *testing.T) {
-		streamer := NewStreamer(c2ClientMock, logger)
+		streamer := NewStreamer(c2ClientMock, clock.New(), logger)
 
 		ctx, cancel := context.WithCancel(context.Background())
 
@@ -106,4 +108,64 @@ func TestStreamer(t *testing.T) {
 
 		cancel()
 	})
+
+	t.Run("Status reports the stream connection and the last event received", func(t *testing.T) {
+		connectedAt := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+		fakeClock := clock.NewFake(connectedAt)
+
+		streamer := NewStreamer(c2ClientMock, fakeClock, logger)
+		streamer.AddListener(NewMockStreamListener(mockCtrl))
+
+		expectedError := errors.New("stream closed")
+		evt := c2pb.Event{Type: c2pb.EventType_CLIENT_UNSUBSCRIBED, Source: "src1", Target: "target1"}
+
+		streamMock := services.NewMockC2EventStreamClient(mockCtrl)
+		c2ClientMock.EXPECT().SubscribeToEventStream(gomock.Any()).Return(streamMock, nil)
+
+		received := make(chan struct{})
+		gomock.InOrder(
+			streamMock.EXPECT().Recv().DoAndReturn(func() (*c2pb.Event, error) {
+				fakeClock.Advance(time.Minute)
+
+				return &evt, nil
+			}),
+			streamMock.EXPECT().Recv().DoAndReturn(func() (*c2pb.Event, error) {
+				<-received
+				fakeClock.Advance(time.Minute)
+
+				return nil, expectedError
+			}),
+		)
+		streamer.Listeners()[0].(*MockStreamListener).EXPECT().onEvent(evt).Times(1)
+
+		done := make(chan error)
+		go func() {
+			done <- streamer.StartStream(context.Background())
+		}()
+
+		// Let the first event be received
+		time.Sleep(10 * time.Millisecond)
+
+		status := streamer.Status()
+		if !status.Connected || status.Listeners != 1 || !status.Since.Equal(connectedAt) {
+			t.Errorf("Expected stream to be connected since %v with 1 listener, got %#v", connectedAt, status)
+		}
+
+		receivedAt := connectedAt.Add(time.Minute)
+		if status.LastEventType != evt.Type.String() || status.LastEventSource != evt.Source ||
+			status.LastEventTarget != evt.Target || !status.LastEventReceived.Equal(receivedAt) {
+			t.Errorf("Expected last event to be %#v received at %v, got %#v", evt, receivedAt, status)
+		}
+
+		close(received)
+		if err := <-done; err != expectedError {
+			t.Errorf("Expected error to be %v, got %v", expectedError, err)
+		}
+
+		status = streamer.Status()
+		disconnectedAt := receivedAt.Add(time.Minute)
+		if status.Connected || status.LastError != expectedError.Error() || !status.Since.Equal(disconnectedAt) {
+			t.Errorf("Expected stream to be disconnected by %v since %v, got %#v", expectedError, disconnectedAt, status)
+		}
+	})
 }
@@ -17,7 +17,10 @@ package models
 //go:generate mockgen -copyright_file ../../doc/COPYRIGHT_TEMPLATE.txt -destination converters_mocks.go -package=models -self_package github.com/teserakt-io/automation-engine/internal/models github.com/teserakt-io/automation-engine/internal/models Converter
 
 import (
+	"time"
+
 	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/duration"
 
 	"github.com/teserakt-io/automation-engine/internal/pb"
 )
@@ -33,6 +36,16 @@ type Converter interface {
 	TriggerToPb(Trigger) (*pb.Trigger, error)
 	TriggersToPb([]Trigger) ([]*pb.Trigger, error)
 
+	ActionToPb(Action) (*pb.Action, error)
+	ActionsToPb([]Action) ([]*pb.Action, error)
+
+	ExecutionToPb(Execution) (*pb.Execution, error)
+	ExecutionsToPb([]Execution) ([]*pb.Execution, error)
+
+	RuleStatusToPb(RuleStatus) (*pb.RuleStatus, error)
+	RuleStatusesToPb([]RuleStatus) ([]*pb.RuleStatus, error)
+	EventStreamStatusToPb(EventStreamStatus) (*pb.EventStreamStatus, error)
+
 	PbToRule(*pb.Rule) (Rule, error)
 	PbToRules([]*pb.Rule) ([]Rule, error)
 
@@ -41,6 +54,9 @@ type Converter interface {
 
 	PbToTrigger(*pb.Trigger) (Trigger, error)
 	PbToTriggers([]*pb.Trigger) ([]Trigger, error)
+
+	PbToAction(*pb.Action) (Action, error)
+	PbToActions([]*pb.Action) ([]Action, error)
 }
 
 type converter struct{}
@@ -69,13 +85,39 @@ func (c *converter) RuleToPb(rule Rule) (*pb.Rule, error) {
 		return nil, err
 	}
 
+	actions, err := c.ActionsToPb(rule.Actions)
+	if err != nil {
+		return nil, err
+	}
+
+	lastSuppressed, err := ptypes.TimestampProto(rule.LastSuppressed)
+	if err != nil {
+		return nil, err
+	}
+
+	actionSettings, err := pb.RedactActionSettings(rule.ActionType, rule.ActionSettings)
+	if err != nil {
+		return nil, err
+	}
+
 	return &pb.Rule{
-		Id:           int32(rule.ID),
-		Action:       rule.ActionType,
-		Description:  rule.Description,
-		Targets:      targets,
-		Triggers:     triggers,
-		LastExecuted: lastExecuted,
+		Id:               int32(rule.ID),
+		Action:           rule.ActionType,
+		ActionSettings:   actionSettings,
+		Description:      rule.Description,
+		Targets:          targets,
+		Triggers:         triggers,
+		Actions:          actions,
+		LastExecuted:     lastExecuted,
+		Enabled:          rule.Enabled,
+		DryRun:           rule.DryRun,
+		TriggerMode:      rule.TriggerMode,
+		TriggerThreshold: int32(rule.TriggerThreshold),
+		Cooldown:         ptypes.DurationProto(rule.Cooldown),
+		MaxExecutions:    int32(rule.MaxExecutions),
+		ExecutionPeriod:  ptypes.DurationProto(rule.ExecutionPeriod),
+		SuppressedCount:  rule.SuppressedCount,
+		LastSuppressed:   lastSuppressed,
 	}, nil
 }
 
@@ -157,13 +199,47 @@ func (c *converter) PbToRule(rule *pb.Rule) (Rule, error) {
 		return Rule{}, err
 	}
 
+	actions, err := c.PbToActions(rule.Actions)
+	if err != nil {
+		return Rule{}, err
+	}
+
+	cooldown, err := PbToDuration(rule.Cooldown)
+	if err != nil {
+		return Rule{}, err
+	}
+
+	executionPeriod, err := PbToDuration(rule.ExecutionPeriod)
+	if err != nil {
+		return Rule{}, err
+	}
+
+	var lastSuppressed time.Time
+	if rule.LastSuppressed != nil {
+		lastSuppressed, err = ptypes.Timestamp(rule.LastSuppressed)
+		if err != nil {
+			return Rule{}, err
+		}
+	}
+
 	return Rule{
-		ID:           int(rule.Id),
-		ActionType:   rule.Action,
-		Description:  rule.Description,
-		LastExecuted: lastExecuted,
-		Targets:      targets,
-		Triggers:     triggers,
+		ID:               int(rule.Id),
+		ActionType:       rule.Action,
+		ActionSettings:   rule.ActionSettings,
+		Description:      rule.Description,
+		LastExecuted:     lastExecuted,
+		Enabled:          rule.Enabled,
+		DryRun:           rule.DryRun,
+		TriggerMode:      rule.TriggerMode,
+		TriggerThreshold: int(rule.TriggerThreshold),
+		Cooldown:         cooldown,
+		MaxExecutions:    int(rule.MaxExecutions),
+		ExecutionPeriod:  executionPeriod,
+		SuppressedCount:  rule.SuppressedCount,
+		LastSuppressed:   lastSuppressed,
+		Targets:          targets,
+		Triggers:         triggers,
+		Actions:          actions,
 	}, nil
 }
 
@@ -228,3 +304,237 @@ func (c *converter) PbToTargets(targets []*pb.Target) ([]Target, error) {
 
 	return out, nil
 }
+
+// ActionToPb converts a models.Action to a pb.Action
+func (c *converter) ActionToPb(action Action) (*pb.Action, error) {
+	settings, err := pb.RedactActionSettings(action.ActionType, action.Settings)
+	if err != nil {
+		return nil, err
+	}
+
+	compensateSettings, err := pb.RedactActionSettings(action.CompensateType, action.CompensateSettings)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.Action{
+		Id:                 int32(action.ID),
+		Type:               action.ActionType,
+		Settings:           settings,
+		OnFailure:          action.OnFailure,
+		CompensateType:     action.CompensateType,
+		CompensateSettings: compensateSettings,
+	}, nil
+}
+
+// ActionsToPb converts a []models.Action to a []pb.Action
+func (c *converter) ActionsToPb(actions []Action) ([]*pb.Action, error) {
+	var out []*pb.Action
+	for _, a := range actions {
+		ac, err := c.ActionToPb(a)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, ac)
+	}
+
+	return out, nil
+}
+
+// PbToAction converts a pb.Action to a models.Action
+func (c *converter) PbToAction(action *pb.Action) (Action, error) {
+	return Action{
+		ID:                 int(action.Id),
+		ActionType:         action.Type,
+		Settings:           action.Settings,
+		OnFailure:          action.OnFailure,
+		CompensateType:     action.CompensateType,
+		CompensateSettings: action.CompensateSettings,
+	}, nil
+}
+
+// PbToActions converts a []pb.Action to a []models.Action, setting
+// each action Position from its index in the list.
+func (c *converter) PbToActions(actions []*pb.Action) ([]Action, error) {
+	var out []Action
+	for i, a := range actions {
+		ac, err := c.PbToAction(a)
+		if err != nil {
+			return nil, err
+		}
+
+		ac.Position = i
+		out = append(out, ac)
+	}
+
+	return out, nil
+}
+
+// ExecutionToPb converts a models.Execution to a pb.Execution
+func (c *converter) ExecutionToPb(execution Execution) (*pb.Execution, error) {
+	triggeredAt, err := ptypes.TimestampProto(execution.TriggeredAt)
+	if err != nil {
+		return nil, err
+	}
+
+	var event *pb.ExecutionEvent
+	if len(execution.EventType) > 0 {
+		eventTime, err := ptypes.TimestampProto(execution.EventTime)
+		if err != nil {
+			return nil, err
+		}
+
+		event = &pb.ExecutionEvent{
+			Type:      execution.EventType,
+			Source:    execution.EventSource,
+			Target:    execution.EventTarget,
+			Timestamp: eventTime,
+		}
+	}
+
+	var outcomes []*pb.ExecutionOutcome
+	for _, outcome := range execution.Outcomes {
+		outcomes = append(outcomes, &pb.ExecutionOutcome{
+			Action:      outcome.ActionType,
+			TargetType:  outcome.TargetType,
+			Target:      outcome.Target,
+			Error:       outcome.Error,
+			DryRunCalls: outcome.DryRunCalls,
+			Pending:     outcome.Pending,
+		})
+	}
+
+	return &pb.Execution{
+		Id:          int32(execution.ID),
+		RuleId:      int32(execution.RuleID),
+		TriggerId:   int32(execution.TriggerID),
+		TriggeredAt: triggeredAt,
+		Event:       event,
+		Outcomes:    outcomes,
+		Duration:    ptypes.DurationProto(execution.Duration),
+		Error:       execution.Error,
+		DryRun:      execution.DryRun,
+	}, nil
+}
+
+// ExecutionsToPb converts a []models.Execution to a []pb.Execution
+func (c *converter) ExecutionsToPb(executions []Execution) ([]*pb.Execution, error) {
+	var out []*pb.Execution
+	for _, e := range executions {
+		ec, err := c.ExecutionToPb(e)
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, ec)
+	}
+
+	return out, nil
+}
+
+// RuleStatusToPb converts a models.RuleStatus to a pb.RuleStatus
+func (c *converter) RuleStatusToPb(status RuleStatus) (*pb.RuleStatus, error) {
+	lastErrorTime, err := ptypes.TimestampProto(status.LastErrorTime)
+	if err != nil {
+		return nil, err
+	}
+
+	var triggers []*pb.TriggerStatus
+	for _, triggerStatus := range status.Triggers {
+		triggerLastErrorTime, err := ptypes.TimestampProto(triggerStatus.LastErrorTime)
+		if err != nil {
+			return nil, err
+		}
+
+		lastTriggered, err := ptypes.TimestampProto(triggerStatus.LastTriggered)
+		if err != nil {
+			return nil, err
+		}
+
+		nextFire, err := ptypes.TimestampProto(triggerStatus.NextFire)
+		if err != nil {
+			return nil, err
+		}
+
+		triggers = append(triggers, &pb.TriggerStatus{
+			TriggerId:     int32(triggerStatus.TriggerID),
+			State:         triggerStatus.State,
+			LastError:     triggerStatus.LastError,
+			LastErrorTime: triggerLastErrorTime,
+			ErrorCount:    triggerStatus.ErrorCount,
+			LastTriggered: lastTriggered,
+			NextFire:      nextFire,
+			Counter:       int32(triggerStatus.Counter),
+		})
+	}
+
+	return &pb.RuleStatus{
+		RuleId:        int32(status.RuleID),
+		State:         status.State,
+		LastError:     status.LastError,
+		LastErrorTime: lastErrorTime,
+		ErrorCount:    status.ErrorCount,
+		Triggers:      triggers,
+	}, nil
+}
+
+// RuleStatusesToPb converts a []models.RuleStatus to a []pb.RuleStatus
+func (c *converter) RuleStatusesToPb(statuses []RuleStatus) ([]*pb.RuleStatus, error) {
+	var out []*pb.RuleStatus
+	for _, status := range statuses {
+		sc, err := c.RuleStatusToPb(status)
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, sc)
+	}
+
+	return out, nil
+}
+
+// EventStreamStatusToPb converts a models.EventStreamStatus to a pb.EventStreamStatus
+func (c *converter) EventStreamStatusToPb(status EventStreamStatus) (*pb.EventStreamStatus, error) {
+	since, err := ptypes.TimestampProto(status.Since)
+	if err != nil {
+		return nil, err
+	}
+
+	lastEventReceived, err := ptypes.TimestampProto(status.LastEventReceived)
+	if err != nil {
+		return nil, err
+	}
+
+	var lastEvent *pb.ExecutionEvent
+	if len(status.LastEventType) > 0 {
+		lastEventTime, err := ptypes.TimestampProto(status.LastEventTime)
+		if err != nil {
+			return nil, err
+		}
+
+		lastEvent = &pb.ExecutionEvent{
+			Type:      status.LastEventType,
+			Source:    status.LastEventSource,
+			Target:    status.LastEventTarget,
+			Timestamp: lastEventTime,
+		}
+	}
+
+	return &pb.EventStreamStatus{
+		Connected:         status.Connected,
+		Since:             since,
+		LastError:         status.LastError,
+		Listeners:         int32(status.Listeners),
+		LastEvent:         lastEvent,
+		LastEventReceived: lastEventReceived,
+	}, nil
+}
+
+// PbToDuration converts a protobuf duration to a time.Duration, a nil duration being 0
+func PbToDuration(d *duration.Duration) (time.Duration, error) {
+	if d == nil {
+		return 0, nil
+	}
+
+	return ptypes.Duration(d)
+}
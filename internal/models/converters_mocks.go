@@ -48,6 +48,111 @@ func (m *MockConverter) EXPECT() *MockConverterMockRecorder {
 	return m.recorder
 }
 
+// ActionToPb mocks base method
+func (m *MockConverter) ActionToPb(arg0 Action) (*pb.Action, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActionToPb", arg0)
+	ret0, _ := ret[0].(*pb.Action)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActionToPb indicates an expected call of ActionToPb
+func (mr *MockConverterMockRecorder) ActionToPb(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActionToPb", reflect.TypeOf((*MockConverter)(nil).ActionToPb), arg0)
+}
+
+// ActionsToPb mocks base method
+func (m *MockConverter) ActionsToPb(arg0 []Action) ([]*pb.Action, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActionsToPb", arg0)
+	ret0, _ := ret[0].([]*pb.Action)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActionsToPb indicates an expected call of ActionsToPb
+func (mr *MockConverterMockRecorder) ActionsToPb(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActionsToPb", reflect.TypeOf((*MockConverter)(nil).ActionsToPb), arg0)
+}
+
+// EventStreamStatusToPb mocks base method
+func (m *MockConverter) EventStreamStatusToPb(arg0 EventStreamStatus) (*pb.EventStreamStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EventStreamStatusToPb", arg0)
+	ret0, _ := ret[0].(*pb.EventStreamStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EventStreamStatusToPb indicates an expected call of EventStreamStatusToPb
+func (mr *MockConverterMockRecorder) EventStreamStatusToPb(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EventStreamStatusToPb", reflect.TypeOf((*MockConverter)(nil).EventStreamStatusToPb), arg0)
+}
+
+// ExecutionToPb mocks base method
+func (m *MockConverter) ExecutionToPb(arg0 Execution) (*pb.Execution, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExecutionToPb", arg0)
+	ret0, _ := ret[0].(*pb.Execution)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExecutionToPb indicates an expected call of ExecutionToPb
+func (mr *MockConverterMockRecorder) ExecutionToPb(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecutionToPb", reflect.TypeOf((*MockConverter)(nil).ExecutionToPb), arg0)
+}
+
+// ExecutionsToPb mocks base method
+func (m *MockConverter) ExecutionsToPb(arg0 []Execution) ([]*pb.Execution, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExecutionsToPb", arg0)
+	ret0, _ := ret[0].([]*pb.Execution)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExecutionsToPb indicates an expected call of ExecutionsToPb
+func (mr *MockConverterMockRecorder) ExecutionsToPb(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecutionsToPb", reflect.TypeOf((*MockConverter)(nil).ExecutionsToPb), arg0)
+}
+
+// PbToAction mocks base method
+func (m *MockConverter) PbToAction(arg0 *pb.Action) (Action, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PbToAction", arg0)
+	ret0, _ := ret[0].(Action)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PbToAction indicates an expected call of PbToAction
+func (mr *MockConverterMockRecorder) PbToAction(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PbToAction", reflect.TypeOf((*MockConverter)(nil).PbToAction), arg0)
+}
+
+// PbToActions mocks base method
+func (m *MockConverter) PbToActions(arg0 []*pb.Action) ([]Action, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PbToActions", arg0)
+	ret0, _ := ret[0].([]Action)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PbToActions indicates an expected call of PbToActions
+func (mr *MockConverterMockRecorder) PbToActions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PbToActions", reflect.TypeOf((*MockConverter)(nil).PbToActions), arg0)
+}
+
 // PbToRule mocks base method
 func (m *MockConverter) PbToRule(arg0 *pb.Rule) (Rule, error) {
 	m.ctrl.T.Helper()
@@ -138,6 +243,36 @@ func (mr *MockConverterMockRecorder) PbToTriggers(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PbToTriggers", reflect.TypeOf((*MockConverter)(nil).PbToTriggers), arg0)
 }
 
+// RuleStatusToPb mocks base method
+func (m *MockConverter) RuleStatusToPb(arg0 RuleStatus) (*pb.RuleStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RuleStatusToPb", arg0)
+	ret0, _ := ret[0].(*pb.RuleStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RuleStatusToPb indicates an expected call of RuleStatusToPb
+func (mr *MockConverterMockRecorder) RuleStatusToPb(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RuleStatusToPb", reflect.TypeOf((*MockConverter)(nil).RuleStatusToPb), arg0)
+}
+
+// RuleStatusesToPb mocks base method
+func (m *MockConverter) RuleStatusesToPb(arg0 []RuleStatus) ([]*pb.RuleStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RuleStatusesToPb", arg0)
+	ret0, _ := ret[0].([]*pb.RuleStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RuleStatusesToPb indicates an expected call of RuleStatusesToPb
+func (mr *MockConverterMockRecorder) RuleStatusesToPb(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RuleStatusesToPb", reflect.TypeOf((*MockConverter)(nil).RuleStatusesToPb), arg0)
+}
+
 // RuleToPb mocks base method
 func (m *MockConverter) RuleToPb(arg0 Rule) (*pb.Rule, error) {
 	m.ctrl.T.Helper()
@@ -60,21 +60,48 @@ func TestConverter(t *testing.T) {
 		Type: pb.TargetType_CLIENT,
 	}
 
+	action1 := Action{
+		ID:         1,
+		Position:   0,
+		ActionType: pb.ActionType_KEY_ROTATION,
+		OnFailure:  pb.ActionFailurePolicy_CONTINUE,
+	}
+	action2 := Action{
+		ID:                 2,
+		Position:           1,
+		ActionType:         pb.ActionType_WEBHOOK,
+		Settings:           []byte(`{"url":"http://example.com"}`),
+		OnFailure:          pb.ActionFailurePolicy_COMPENSATE,
+		CompensateType:     pb.ActionType_WEBHOOK,
+		CompensateSettings: []byte(`{"url":"http://example.com/failed"}`),
+	}
+
 	rule1 := Rule{
-		ID:           1,
-		Description:  "description1",
-		ActionType:   pb.ActionType_KEY_ROTATION,
-		LastExecuted: time.Now(),
-		Targets:      []Target{target1, target2},
-		Triggers:     []Trigger{trigger1, trigger2},
+		ID:               1,
+		Description:      "description1",
+		ActionType:       pb.ActionType_KEY_ROTATION,
+		LastExecuted:     time.Now(),
+		Enabled:          true,
+		DryRun:           true,
+		TriggerMode:      pb.TriggerMode_THRESHOLD_TRIGGERS,
+		TriggerThreshold: 2,
+		Cooldown:         time.Minute,
+		MaxExecutions:    3,
+		ExecutionPeriod:  time.Hour,
+		SuppressedCount:  4,
+		LastSuppressed:   time.Now(),
+		Targets:          []Target{target1, target2},
+		Triggers:         []Trigger{trigger1, trigger2},
+		Actions:          []Action{action1, action2},
 	}
 	rule2 := Rule{
-		ID:           2,
-		Description:  "description2",
-		ActionType:   pb.ActionType_KEY_ROTATION,
-		LastExecuted: time.Now(),
-		Targets:      []Target{target1, target2, target3},
-		Triggers:     []Trigger{trigger1, trigger2, trigger3},
+		ID:             2,
+		Description:    "description2",
+		ActionType:     pb.ActionType_WEBHOOK,
+		ActionSettings: []byte(`{"url":"http://example.com"}`),
+		LastExecuted:   time.Now(),
+		Targets:        []Target{target1, target2, target3},
+		Triggers:       []Trigger{trigger1, trigger2, trigger3},
 	}
 
 	t.Run("RulesToPb and PbToRules properly converts []models.Rule to []*pb.Rule and back", func(t *testing.T) {
@@ -111,6 +138,48 @@ func TestConverter(t *testing.T) {
 			if rule.ActionType != origRules[i].ActionType {
 				t.Errorf("Expected rule action type to be %v, got %v", rule.ActionType, origRules[i].ActionType)
 			}
+			if bytes.Equal(rule.ActionSettings, origRules[i].ActionSettings) == false {
+				t.Errorf("Expected rule action settings to be %s, got %s", rule.ActionSettings, origRules[i].ActionSettings)
+			}
+			if rule.Enabled != origRules[i].Enabled {
+				t.Errorf("Expected rule enabled to be %t, got %t", rule.Enabled, origRules[i].Enabled)
+			}
+
+			if rule.DryRun != origRules[i].DryRun {
+				t.Errorf("Expected rule dryRun to be %t, got %t", rule.DryRun, origRules[i].DryRun)
+			}
+
+			if rule.TriggerMode != origRules[i].TriggerMode || rule.TriggerThreshold != origRules[i].TriggerThreshold {
+				t.Errorf(
+					"Expected rule trigger mode to be %s/%d, got %s/%d",
+					origRules[i].TriggerMode,
+					origRules[i].TriggerThreshold,
+					rule.TriggerMode,
+					rule.TriggerThreshold,
+				)
+			}
+			if rule.Cooldown != origRules[i].Cooldown ||
+				rule.MaxExecutions != origRules[i].MaxExecutions ||
+				rule.ExecutionPeriod != origRules[i].ExecutionPeriod {
+				t.Errorf(
+					"Expected rule rate limit to be %s, %d per %s, got %s, %d per %s",
+					origRules[i].Cooldown,
+					origRules[i].MaxExecutions,
+					origRules[i].ExecutionPeriod,
+					rule.Cooldown,
+					rule.MaxExecutions,
+					rule.ExecutionPeriod,
+				)
+			}
+			if rule.SuppressedCount != origRules[i].SuppressedCount || !rule.LastSuppressed.Equal(origRules[i].LastSuppressed) {
+				t.Errorf(
+					"Expected rule suppressed count to be %d at %v, got %d at %v",
+					origRules[i].SuppressedCount,
+					origRules[i].LastSuppressed,
+					rule.SuppressedCount,
+					rule.LastSuppressed,
+				)
+			}
 			if rule.LastExecuted.UnixNano() != origRules[i].LastExecuted.UnixNano() {
 				t.Errorf("Expected last executed to be %#v, got %#v", rule.LastExecuted, origRules[i].LastExecuted)
 			}
@@ -120,6 +189,222 @@ func TestConverter(t *testing.T) {
 			if reflect.DeepEqual(rule.Triggers, origRules[i].Triggers) == false {
 				t.Errorf("Expected triggers to be %#v, got %#v", rule.Triggers, origRules[i].Triggers)
 			}
+			if reflect.DeepEqual(rule.Actions, origRules[i].Actions) == false {
+				t.Errorf("Expected actions to be %#v, got %#v", rule.Actions, origRules[i].Actions)
+			}
+		}
+	})
+
+	t.Run("ExecutionsToPb properly converts []models.Execution to []*pb.Execution", func(t *testing.T) {
+		now := time.Now()
+		executions := []Execution{
+			Execution{
+				ID:          1,
+				RuleID:      1,
+				TriggerID:   2,
+				TriggeredAt: now,
+				Duration:    1500 * time.Millisecond,
+				Outcomes: []ExecutionOutcome{
+					ExecutionOutcome{ActionType: pb.ActionType_KEY_ROTATION, TargetType: pb.TargetType_CLIENT, Target: "client1"},
+					ExecutionOutcome{ActionType: pb.ActionType_KEY_ROTATION, TargetType: pb.TargetType_TOPIC, Target: "topic1", Error: "failed"},
+					ExecutionOutcome{ActionType: pb.ActionType_KEY_ROTATION, TargetType: pb.TargetType_TOPIC, Target: "topic2", Pending: true},
+				},
+				Error: "action failed",
+			},
+			Execution{
+				ID:          2,
+				RuleID:      1,
+				TriggerID:   3,
+				TriggeredAt: now,
+				EventType:   "CLIENT_SUBSCRIBED",
+				EventSource: "client1",
+				EventTarget: "topic1",
+				EventTime:   now.Add(-time.Second),
+				DryRun:      true,
+				Outcomes: []ExecutionOutcome{
+					ExecutionOutcome{ActionType: pb.ActionType_RESET_TOPIC, TargetType: pb.TargetType_TOPIC, Target: "topic1", DryRunCalls: "RemoveTopic(topic1), NewTopicKey(topic1)"},
+				},
+			},
+		}
+
+		pbExecutions, err := converter.ExecutionsToPb(executions)
+		if err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		if len(executions) != len(pbExecutions) {
+			t.Fatalf("Expected %d converted executions, got %d", len(executions), len(pbExecutions))
+		}
+
+		for i, execution := range executions {
+			pbExecution := pbExecutions[i]
+			if execution.ID != int(pbExecution.Id) || execution.RuleID != int(pbExecution.RuleId) || execution.TriggerID != int(pbExecution.TriggerId) {
+				t.Errorf("Expected execution ids to be %d/%d/%d, got %#v", execution.ID, execution.RuleID, execution.TriggerID, pbExecution)
+			}
+
+			triggeredAt, err := ptypes.Timestamp(pbExecution.TriggeredAt)
+			if err != nil || !triggeredAt.Equal(execution.TriggeredAt) {
+				t.Errorf("Expected execution triggeredAt to be %v, got %v", execution.TriggeredAt, pbExecution.TriggeredAt)
+			}
+
+			duration, err := ptypes.Duration(pbExecution.Duration)
+			if err != nil || duration != execution.Duration {
+				t.Errorf("Expected execution duration to be %v, got %v", execution.Duration, pbExecution.Duration)
+			}
+
+			if execution.Error != pbExecution.Error {
+				t.Errorf("Expected execution error to be %s, got %s", execution.Error, pbExecution.Error)
+			}
+
+			if execution.DryRun != pbExecution.DryRun {
+				t.Errorf("Expected execution dryRun to be %t, got %t", execution.DryRun, pbExecution.DryRun)
+			}
+
+			if len(execution.Outcomes) != len(pbExecution.Outcomes) {
+				t.Fatalf("Expected %d outcomes, got %d", len(execution.Outcomes), len(pbExecution.Outcomes))
+			}
+			for j, outcome := range execution.Outcomes {
+				expected := &pb.ExecutionOutcome{
+					Action:      outcome.ActionType,
+					TargetType:  outcome.TargetType,
+					Target:      outcome.Target,
+					Error:       outcome.Error,
+					DryRunCalls: outcome.DryRunCalls,
+					Pending:     outcome.Pending,
+				}
+				if reflect.DeepEqual(expected, pbExecution.Outcomes[j]) == false {
+					t.Errorf("Expected outcome to be %#v, got %#v", expected, pbExecution.Outcomes[j])
+				}
+			}
+		}
+
+		if pbExecutions[0].Event != nil {
+			t.Errorf("Expected no event, got %#v", pbExecutions[0].Event)
+		}
+
+		event := pbExecutions[1].Event
+		if event == nil || event.Type != "CLIENT_SUBSCRIBED" || event.Source != "client1" || event.Target != "topic1" {
+			t.Fatalf("Expected execution event to be set, got %#v", event)
+		}
+
+		eventTime, err := ptypes.Timestamp(event.Timestamp)
+		if err != nil || !eventTime.Equal(executions[1].EventTime) {
+			t.Errorf("Expected event time to be %v, got %v", executions[1].EventTime, event.Timestamp)
+		}
+	})
+
+	t.Run("RuleToPb redacts the webhook secrets", func(t *testing.T) {
+		settings := []byte(`{"url":"http://example.com","secret":"s3cr3t"}`)
+		rule := Rule{
+			ActionType:     pb.ActionType_WEBHOOK,
+			ActionSettings: settings,
+			Actions: []Action{
+				Action{
+					ActionType:         pb.ActionType_KEY_ROTATION,
+					OnFailure:          pb.ActionFailurePolicy_COMPENSATE,
+					CompensateType:     pb.ActionType_WEBHOOK,
+					CompensateSettings: settings,
+				},
+			},
+		}
+
+		pbRule, err := converter.RuleToPb(rule)
+		if err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		expectedSettings := []byte(`{"url":"http://example.com","secret":"` + pb.RedactedSecret + `"}`)
+		if bytes.Equal(pbRule.ActionSettings, expectedSettings) == false {
+			t.Errorf("Expected rule action settings to be %s, got %s", expectedSettings, pbRule.ActionSettings)
+		}
+
+		if bytes.Equal(pbRule.Actions[0].CompensateSettings, expectedSettings) == false {
+			t.Errorf("Expected compensate settings to be %s, got %s", expectedSettings, pbRule.Actions[0].CompensateSettings)
+		}
+
+		if bytes.Equal(rule.ActionSettings, settings) == false {
+			t.Errorf("Expected rule action settings to be left unchanged, got %s", rule.ActionSettings)
+		}
+	})
+
+	t.Run("Status conversions properly converts the rules and event stream status", func(t *testing.T) {
+		now := time.Now()
+		statuses := []RuleStatus{
+			RuleStatus{
+				RuleID:        1,
+				State:         pb.RuleWatcherState_RULE_ERRORING,
+				LastError:     "action failed",
+				LastErrorTime: now,
+				ErrorCount:    2,
+				Triggers: []TriggerStatus{
+					TriggerStatus{TriggerID: 1, State: pb.TriggerWatcherState_TRIGGER_RUNNING, NextFire: now.Add(time.Hour)},
+					TriggerStatus{TriggerID: 2, State: pb.TriggerWatcherState_TRIGGER_RUNNING, Counter: 3, LastTriggered: now},
+				},
+			},
+			RuleStatus{RuleID: 2, State: pb.RuleWatcherState_RULE_PAUSED},
+		}
+
+		pbStatuses, err := converter.RuleStatusesToPb(statuses)
+		if err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		if len(pbStatuses) != len(statuses) {
+			t.Fatalf("Expected %d converted statuses, got %d", len(statuses), len(pbStatuses))
+		}
+
+		pbStatus := pbStatuses[0]
+		if pbStatus.RuleId != 1 || pbStatus.State != pb.RuleWatcherState_RULE_ERRORING ||
+			pbStatus.LastError != "action failed" || pbStatus.ErrorCount != 2 || len(pbStatus.Triggers) != 2 {
+			t.Fatalf("Expected rule status to be converted, got %#v", pbStatus)
+		}
+
+		lastErrorTime, err := ptypes.Timestamp(pbStatus.LastErrorTime)
+		if err != nil || !lastErrorTime.Equal(now) {
+			t.Errorf("Expected rule status lastErrorTime to be %v, got %v", now, pbStatus.LastErrorTime)
+		}
+
+		nextFire, err := ptypes.Timestamp(pbStatus.Triggers[0].NextFire)
+		if err != nil || !nextFire.Equal(now.Add(time.Hour)) {
+			t.Errorf("Expected trigger nextFire to be %v, got %v", now.Add(time.Hour), pbStatus.Triggers[0].NextFire)
+		}
+
+		if pbStatus.Triggers[1].TriggerId != 2 || pbStatus.Triggers[1].Counter != 3 {
+			t.Errorf("Expected trigger status to be converted, got %#v", pbStatus.Triggers[1])
+		}
+
+		eventStreamStatus := EventStreamStatus{
+			Connected:         true,
+			Since:             now,
+			Listeners:         2,
+			LastEventType:     "CLIENT_SUBSCRIBED",
+			LastEventSource:   "client1",
+			LastEventTarget:   "topic1",
+			LastEventTime:     now.Add(-time.Second),
+			LastEventReceived: now,
+		}
+
+		pbEventStreamStatus, err := converter.EventStreamStatusToPb(eventStreamStatus)
+		if err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		if !pbEventStreamStatus.Connected || pbEventStreamStatus.Listeners != 2 || pbEventStreamStatus.LastEvent == nil {
+			t.Fatalf("Expected event stream status to be converted, got %#v", pbEventStreamStatus)
+		}
+
+		if pbEventStreamStatus.LastEvent.Type != "CLIENT_SUBSCRIBED" || pbEventStreamStatus.LastEvent.Source != "client1" ||
+			pbEventStreamStatus.LastEvent.Target != "topic1" {
+			t.Errorf("Expected last event to be converted, got %#v", pbEventStreamStatus.LastEvent)
+		}
+
+		pbEventStreamStatus, err = converter.EventStreamStatusToPb(EventStreamStatus{})
+		if err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+
+		if pbEventStreamStatus.LastEvent != nil {
+			t.Errorf("Expected no last event, got %#v", pbEventStreamStatus.LastEvent)
 		}
 	})
 }
@@ -132,6 +417,44 @@ func assertSameRule(t *testing.T, rule Rule, pbRule *pb.Rule) {
 	if rule.Description != pbRule.Description {
 		t.Errorf("Expected rule description to be %s, got %s", rule.Description, pbRule.Description)
 	}
+
+	if rule.Enabled != pbRule.Enabled {
+		t.Errorf("Expected rule enabled to be %t, got %t", rule.Enabled, pbRule.Enabled)
+	}
+
+	if rule.DryRun != pbRule.DryRun {
+		t.Errorf("Expected rule dryRun to be %t, got %t", rule.DryRun, pbRule.DryRun)
+	}
+
+	if rule.TriggerMode != pbRule.TriggerMode || int32(rule.TriggerThreshold) != pbRule.TriggerThreshold {
+		t.Errorf(
+			"Expected rule trigger mode to be %s/%d, got %s/%d",
+			rule.TriggerMode,
+			rule.TriggerThreshold,
+			pbRule.TriggerMode,
+			pbRule.TriggerThreshold,
+		)
+	}
+
+	cooldown, err := PbToDuration(pbRule.Cooldown)
+	if err != nil || rule.Cooldown != cooldown {
+		t.Errorf("Expected rule cooldown to be %s, got %s", rule.Cooldown, pbRule.Cooldown)
+	}
+
+	executionPeriod, err := PbToDuration(pbRule.ExecutionPeriod)
+	if err != nil || int32(rule.MaxExecutions) != pbRule.MaxExecutions || rule.ExecutionPeriod != executionPeriod {
+		t.Errorf(
+			"Expected rule max executions to be %d per %s, got %d per %s",
+			rule.MaxExecutions,
+			rule.ExecutionPeriod,
+			pbRule.MaxExecutions,
+			pbRule.ExecutionPeriod,
+		)
+	}
+
+	if rule.SuppressedCount != pbRule.SuppressedCount {
+		t.Errorf("Expected rule suppressed count to be %d, got %d", rule.SuppressedCount, pbRule.SuppressedCount)
+	}
 	time, err := ptypes.Timestamp(pbRule.LastExecuted)
 	if err != nil {
 		t.Errorf("Converted rule have an invalid timestamp: %s", err)
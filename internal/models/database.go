@@ -97,6 +97,9 @@ func (gdb *gormDB) Migrate() error {
 		Trigger{},
 		TriggerState{},
 		Target{},
+		Action{},
+		Execution{},
+		ExecutionOutcome{},
 	)
 
 	if result.Error != nil {
@@ -15,6 +15,9 @@
 package models
 
 import (
+	"encoding/json"
+	"regexp"
+	"sort"
 	"time"
 
 	"github.com/teserakt-io/automation-engine/internal/pb"
@@ -22,12 +25,81 @@ import (
 
 // Rule holds database information of a rule.
 type Rule struct {
-	ID           int `gorm:"primary_key:true"`
-	Description  string
-	ActionType   pb.ActionType
-	LastExecuted time.Time
-	Triggers     []Trigger
-	Targets      []Target
+	ID             int `gorm:"primary_key:true"`
+	Description    string
+	ActionType     pb.ActionType
+	ActionSettings []byte
+	LastExecuted   time.Time
+	// Enabled is false when the rule is paused, its triggers are then not watched
+	Enabled bool `gorm:"not null;default:true"`
+	// ResumedAt is the last time the rule has been resumed after being paused
+	ResumedAt time.Time
+	// DryRun makes the rule actions record the C2 calls they would make instead of issuing them
+	DryRun bool
+	// TriggerMode defines how the rule triggers are combined, TriggerThreshold being
+	// the number of triggers which must have fired with the THRESHOLD_TRIGGERS mode.
+	TriggerMode      pb.TriggerMode
+	TriggerThreshold int
+	// ArmedTriggers holds the json encoded times at which the rule triggers fired, by trigger ID,
+	// when their combination was not yet satisfied to execute the rule.
+	ArmedTriggers []byte
+	// Cooldown is the minimum duration between two executions of the rule from its triggers
+	Cooldown time.Duration
+	// MaxExecutions, when positive, is the maximum number of executions of the rule during ExecutionPeriod
+	MaxExecutions   int
+	ExecutionPeriod time.Duration
+	// SuppressedCount is the number of trigger firings suppressed by the cooldown or the executions budget,
+	// LastSuppressed being the time of the last one.
+	SuppressedCount int64
+	LastSuppressed  time.Time
+	Triggers        []Trigger
+	Targets         []Target
+	// Actions, when not empty, replaces ActionType and ActionSettings
+	// with a list of actions executed in sequence, sorted by their Position.
+	Actions []Action
+}
+
+// ArmedTriggerTimes decodes the rule ArmedTriggers
+func (r Rule) ArmedTriggerTimes() (map[int]time.Time, error) {
+	armed := make(map[int]time.Time)
+	if len(r.ArmedTriggers) == 0 {
+		return armed, nil
+	}
+
+	if err := json.Unmarshal(r.ArmedTriggers, &armed); err != nil {
+		return nil, err
+	}
+
+	return armed, nil
+}
+
+// SetArmedTriggerTimes encodes armed into the rule ArmedTriggers
+func (r *Rule) SetArmedTriggerTimes(armed map[int]time.Time) error {
+	if len(armed) == 0 {
+		r.ArmedTriggers = nil
+
+		return nil
+	}
+
+	encoded, err := json.Marshal(armed)
+	if err != nil {
+		return err
+	}
+	r.ArmedTriggers = encoded
+
+	return nil
+}
+
+// Action holds database informations for one of the rule actions
+type Action struct {
+	ID                 int `gorm:"primary_key"`
+	RuleID             int `gorm:"type:int REFERENCES rules(id) ON DELETE CASCADE; index;"`
+	Position           int
+	ActionType         pb.ActionType
+	Settings           []byte
+	OnFailure          pb.ActionFailurePolicy
+	CompensateType     pb.ActionType
+	CompensateSettings []byte
 }
 
 // Target holds database informations for a rule target
@@ -38,6 +110,18 @@ type Target struct {
 	Expr   string
 }
 
+// Regexp compiles the target expression to a regular expression anchored
+// on both ends, so it must match a whole client or topic name.
+func (t Target) Regexp() (*regexp.Regexp, error) {
+	return regexp.Compile("^(?:" + t.Expr + ")$")
+}
+
+// IsLiteral returns true when the target expression doesn't contain any
+// regular expression meta characters, and thus only match itself.
+func (t Target) IsLiteral() bool {
+	return regexp.QuoteMeta(t.Expr) == t.Expr
+}
+
 // Trigger holds database informations for a rule trigger
 type Trigger struct {
 	ID          int `gorm:"primary_key"`
@@ -51,6 +135,130 @@ type TriggerState struct {
 	ID        int `gorm:"primary_key"`
 	TriggerID int `gorm:"type:int REFERENCES triggers(id) ON DELETE CASCADE; unique_index; NOT NULL;"`
 	Counter   int
+	// Occurrences holds the json encoded times of the events counted by
+	// triggers having a time window, Counter being then their number.
+	Occurrences []byte
+	// Done is set once a one-shot trigger has fired
+	Done bool
+}
+
+// OccurrenceTimes decodes the state Occurrences
+func (s TriggerState) OccurrenceTimes() ([]time.Time, error) {
+	if len(s.Occurrences) == 0 {
+		return nil, nil
+	}
+
+	var times []time.Time
+	if err := json.Unmarshal(s.Occurrences, &times); err != nil {
+		return nil, err
+	}
+
+	return times, nil
+}
+
+// SetOccurrenceTimes encodes times to the state Occurrences, and updates the Counter accordingly
+func (s *TriggerState) SetOccurrenceTimes(times []time.Time) error {
+	s.Counter = len(times)
+	if len(times) == 0 {
+		s.Occurrences = nil
+
+		return nil
+	}
+
+	occurrences, err := json.Marshal(times)
+	if err != nil {
+		return err
+	}
+	s.Occurrences = occurrences
+
+	return nil
+}
+
+// Execution holds database informations of a rule execution.
+// It doesn't reference the rule and trigger tables, so the history
+// is kept when they get deleted.
+type Execution struct {
+	ID          int `gorm:"primary_key"`
+	RuleID      int `gorm:"index"`
+	TriggerID   int
+	TriggeredAt time.Time `gorm:"index"`
+	// Event* fields describe the C2 event which caused the trigger to fire, and are empty otherwise
+	EventType   string
+	EventSource string
+	EventTarget string
+	EventTime   time.Time
+	Duration    time.Duration
+	Error       string
+	// DryRun is true when the rule actions were executed in dry-run mode
+	DryRun   bool
+	Outcomes []ExecutionOutcome
+}
+
+// ExecutionOutcome holds database informations of the result of
+// a rule action on one of its targets
+type ExecutionOutcome struct {
+	ID          int `gorm:"primary_key"`
+	ExecutionID int `gorm:"type:int REFERENCES executions(id) ON DELETE CASCADE; index;"`
+	ActionType  pb.ActionType
+	TargetType  pb.TargetType
+	// Target is the resolved client or topic name, or the webhook URL
+	Target string
+	Error  string
+	// DryRunCalls lists, comma separated, the C2 calls the action would
+	// have issued on the target when executed in dry-run mode
+	DryRunCalls string
+	// Pending is true while the action has not completed on the target yet,
+	// like a key rotation spread over its trigger splay
+	Pending bool
+}
+
+// RuleStatus holds the state of a rule in the automation engine. It is not persisted,
+// and only lives as long as the engine.
+type RuleStatus struct {
+	RuleID        int
+	State         pb.RuleWatcherState
+	LastError     string
+	LastErrorTime time.Time
+	ErrorCount    int64
+	// Triggers holds the status of the rule triggers, sorted by trigger ID
+	Triggers []TriggerStatus
+}
+
+// TriggerStatus holds the state of a rule trigger in the automation engine
+type TriggerStatus struct {
+	TriggerID     int
+	State         pb.TriggerWatcherState
+	LastError     string
+	LastErrorTime time.Time
+	ErrorCount    int64
+	LastTriggered time.Time
+	// NextFire is the time a scheduled trigger will next fire, zero when it has no more runs
+	NextFire time.Time
+	// Counter is the number of events an EVENT trigger counted toward its maxOccurrence
+	Counter int
+}
+
+// EventStreamStatus holds the state of the C2 event stream
+type EventStreamStatus struct {
+	Connected bool
+	// Since is the time the stream last connected or disconnected
+	Since     time.Time
+	LastError string
+	// Listeners is the number of listeners receiving the streamed events
+	Listeners int
+	// LastEvent* fields describe the last event received from the stream, at LastEventReceived
+	LastEventType     string
+	LastEventSource   string
+	LastEventTarget   string
+	LastEventTime     time.Time
+	LastEventReceived time.Time
+}
+
+// EngineStatus holds the state of the automation engine
+type EngineStatus struct {
+	// Rules holds the status of the rules known to the engine, sorted by ID
+	Rules       []RuleStatus
+	EventStream EventStreamStatus
 }
 
 // FilterNonExistingTriggers will returns a slice of Triggers
@@ -76,6 +284,80 @@ func containsTrigger(needle Trigger, haystack []Trigger) bool {
 	return false
 }
 
+// FilterNonExistingActions will returns a slice of Actions
+// from `old` which does not exists in `new`
+func FilterNonExistingActions(old []Action, new []Action) []Action {
+	filtered := []Action{}
+	for _, oldAction := range old {
+		if !containsAction(oldAction, new) {
+			filtered = append(filtered, oldAction)
+		}
+	}
+
+	return filtered
+}
+
+func containsAction(needle Action, haystack []Action) bool {
+	for _, action := range haystack {
+		if action.ID == needle.ID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// RestoreSecrets replaces the redacted secrets of the rule action settings, as returned by the api,
+// with the secrets of the stored rule. Actions are matched to the stored ones by their ID.
+func (r *Rule) RestoreSecrets(stored Rule) error {
+	settings, err := pb.RestoreActionSettings(r.ActionType, r.ActionSettings, stored.ActionType, stored.ActionSettings)
+	if err != nil {
+		return err
+	}
+	r.ActionSettings = settings
+
+	for i, action := range r.Actions {
+		if action.ID == 0 {
+			continue
+		}
+
+		for _, storedAction := range stored.Actions {
+			if storedAction.ID != action.ID {
+				continue
+			}
+
+			r.Actions[i].Settings, err = pb.RestoreActionSettings(
+				action.ActionType,
+				action.Settings,
+				storedAction.ActionType,
+				storedAction.Settings,
+			)
+			if err != nil {
+				return err
+			}
+
+			r.Actions[i].CompensateSettings, err = pb.RestoreActionSettings(
+				action.CompensateType,
+				action.CompensateSettings,
+				storedAction.CompensateType,
+				storedAction.CompensateSettings,
+			)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// SortActions orders the rule actions by their Position
+func (r *Rule) SortActions() {
+	sort.SliceStable(r.Actions, func(i, j int) bool {
+		return r.Actions[i].Position < r.Actions[j].Position
+	})
+}
+
 // FilterNonExistingTargets will returns a slice of Targets
 // from `old` which does not exists in `new`
 func FilterNonExistingTargets(old []Target, new []Target) []Target {
@@ -15,8 +15,12 @@
 package models
 
 import (
+	"bytes"
 	"reflect"
 	"testing"
+	"time"
+
+	"github.com/teserakt-io/automation-engine/internal/pb"
 )
 
 func TestFilterNonExistingTriggers(t *testing.T) {
@@ -80,3 +84,153 @@ func TestFilterNonExistingTargets(t *testing.T) {
 		}
 	}
 }
+
+func TestTargetRegexp(t *testing.T) {
+	testDataSet := []struct {
+		Expr      string
+		Name      string
+		Match     bool
+		IsLiteral bool
+	}{
+		{Expr: "client1", Name: "client1", Match: true, IsLiteral: true},
+		{Expr: "client1", Name: "client10", Match: false, IsLiteral: true},
+		{Expr: "client1", Name: "aclient1", Match: false, IsLiteral: true},
+		{Expr: "weather-station-.*", Name: "weather-station-east", Match: true, IsLiteral: false},
+		{Expr: "weather-station-.*", Name: "old-weather-station-east", Match: false, IsLiteral: false},
+		{Expr: "a|b", Name: "a", Match: true, IsLiteral: false},
+		{Expr: "a|b", Name: "ab", Match: false, IsLiteral: false},
+	}
+
+	for _, testData := range testDataSet {
+		target := Target{Expr: testData.Expr}
+		re, err := target.Regexp()
+		if err != nil {
+			t.Fatalf("Expected no error compiling %s, got %v", testData.Expr, err)
+		}
+
+		if match := re.MatchString(testData.Name); match != testData.Match {
+			t.Errorf("Expected %s matching %s to be %t, got %t", testData.Expr, testData.Name, testData.Match, match)
+		}
+
+		if isLiteral := target.IsLiteral(); isLiteral != testData.IsLiteral {
+			t.Errorf("Expected %s IsLiteral to be %t, got %t", testData.Expr, testData.IsLiteral, isLiteral)
+		}
+	}
+}
+
+func TestTriggerStateOccurrences(t *testing.T) {
+	t.Run("Occurrence times are encoded and decoded", func(t *testing.T) {
+		now := time.Now().UTC().Round(0)
+		times := []time.Time{now.Add(-time.Minute), now}
+
+		state := &TriggerState{}
+		if err := state.SetOccurrenceTimes(times); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if state.Counter != len(times) {
+			t.Errorf("Expected counter to be %d, got %d", len(times), state.Counter)
+		}
+
+		decoded, err := state.OccurrenceTimes()
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if reflect.DeepEqual(decoded, times) == false {
+			t.Errorf("Expected occurrence times to be %v, got %v", times, decoded)
+		}
+	})
+
+	t.Run("Empty occurrences reset the state", func(t *testing.T) {
+		state := &TriggerState{Counter: 2, Occurrences: []byte(`["2020-01-01T00:00:00Z"]`)}
+		if err := state.SetOccurrenceTimes(nil); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if state.Counter != 0 || state.Occurrences != nil {
+			t.Errorf("Expected state to be reset, got %#v", state)
+		}
+
+		times, err := state.OccurrenceTimes()
+		if err != nil || times != nil {
+			t.Errorf("Expected no occurrence times and no error, got %v, %v", times, err)
+		}
+	})
+}
+
+func TestRuleArmedTriggers(t *testing.T) {
+	t.Run("Armed trigger times are encoded and decoded", func(t *testing.T) {
+		now := time.Now().UTC().Round(0)
+		armed := map[int]time.Time{1: now.Add(-time.Minute), 3: now}
+
+		rule := &Rule{}
+		if err := rule.SetArmedTriggerTimes(armed); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		decoded, err := rule.ArmedTriggerTimes()
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if reflect.DeepEqual(decoded, armed) == false {
+			t.Errorf("Expected armed triggers to be %v, got %v", armed, decoded)
+		}
+	})
+
+	t.Run("Empty armed triggers reset the rule", func(t *testing.T) {
+		rule := &Rule{ArmedTriggers: []byte(`{"1":"2020-01-01T00:00:00Z"}`)}
+		if err := rule.SetArmedTriggerTimes(nil); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if rule.ArmedTriggers != nil {
+			t.Errorf("Expected armed triggers to be reset, got %s", rule.ArmedTriggers)
+		}
+
+		armed, err := rule.ArmedTriggerTimes()
+		if err != nil || len(armed) != 0 {
+			t.Errorf("Expected no armed triggers and no error, got %v, %v", armed, err)
+		}
+	})
+}
+
+func TestRuleRestoreSecrets(t *testing.T) {
+	stored := Rule{
+		ActionType:     pb.ActionType_WEBHOOK,
+		ActionSettings: []byte(`{"url":"http://example.com","secret":"s3cr3t"}`),
+		Actions: []Action{
+			Action{ID: 1, ActionType: pb.ActionType_WEBHOOK, Settings: []byte(`{"url":"http://example.com","secret":"other"}`)},
+		},
+	}
+
+	redacted := []byte(`{"url":"http://example.com/new","secret":"` + pb.RedactedSecret + `"}`)
+	rule := Rule{
+		ActionType:     pb.ActionType_WEBHOOK,
+		ActionSettings: redacted,
+		Actions: []Action{
+			Action{ID: 1, ActionType: pb.ActionType_WEBHOOK, Settings: redacted},
+			Action{ActionType: pb.ActionType_WEBHOOK, Settings: redacted},
+		},
+	}
+
+	if err := rule.RestoreSecrets(stored); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expectedSettings := []byte(`{"url":"http://example.com/new","secret":"s3cr3t"}`)
+	if bytes.Equal(rule.ActionSettings, expectedSettings) == false {
+		t.Errorf("Expected rule action settings to be %s, got %s", expectedSettings, rule.ActionSettings)
+	}
+
+	expectedSettings = []byte(`{"url":"http://example.com/new","secret":"other"}`)
+	if bytes.Equal(rule.Actions[0].Settings, expectedSettings) == false {
+		t.Errorf("Expected action settings to be %s, got %s", expectedSettings, rule.Actions[0].Settings)
+	}
+
+	// New actions have no stored secret to restore
+	if bytes.Equal(rule.Actions[1].Settings, redacted) == false {
+		t.Errorf("Expected new action settings to be %s, got %s", redacted, rule.Actions[1].Settings)
+	}
+}
@@ -31,6 +31,16 @@ var (
 	ErrUndefinedTriggerType   = errors.New("trigger type is undefined")
 	ErrUnsupportedTriggerType = errors.New("trigger type is not supported")
 	ErrTargetExprRequired     = errors.New("target expr is required")
+	ErrUnsupportedTargetType  = errors.New("target type is not supported by the rule action")
+	ErrActionSettingsRequired = errors.New("rule action settings are required")
+	ErrActionsConflict        = errors.New("rule cannot define both an action type and a list of actions")
+	ErrUnknownFailurePolicy   = errors.New("action failure policy is unknown")
+	ErrCompensationRequired   = errors.New("compensating action is required with the COMPENSATE failure policy")
+	ErrUnexpectedCompensation = errors.New("compensating action is only allowed with the COMPENSATE failure policy")
+	ErrUnknownTriggerMode     = errors.New("rule trigger mode is unknown")
+	ErrTriggerThreshold       = errors.New("rule trigger threshold must be at least 1 with the THRESHOLD_TRIGGERS mode")
+	ErrNegativeCooldown       = errors.New("rule cooldown cannot be negative")
+	ErrExecutionsBudget       = errors.New("rule max executions cannot be negative, and requires a positive execution period")
 )
 
 // TriggerValidator defines interface for trigger validators
@@ -42,6 +52,7 @@ type TriggerValidator interface {
 type Validator interface {
 	TriggerValidator
 	ValidateRule(rule Rule) error
+	ValidateAction(action Action) error
 	ValidateTarget(target Target) error
 }
 
@@ -57,12 +68,36 @@ func NewValidator() Validator {
 
 // ValidateRule will check if given rule is valid, and returns an error when not.
 func (v *validator) ValidateRule(rule Rule) error {
-	if rule.ActionType == pb.ActionType_UNDEFINED_ACTION {
-		return ErrUndefinedAction
+	if len(rule.Actions) == 0 {
+		if err := v.validateActionType(rule.ActionType, rule.ActionSettings); err != nil {
+			return err
+		}
+	} else {
+		if rule.ActionType != pb.ActionType_UNDEFINED_ACTION || len(rule.ActionSettings) > 0 {
+			return ErrActionsConflict
+		}
+
+		for i, action := range rule.Actions {
+			if err := v.ValidateAction(action); err != nil {
+				return fmt.Errorf("action #%d validation failed: %v", i, err)
+			}
+		}
 	}
 
-	if _, ok := pb.ActionType_name[int32(rule.ActionType)]; !ok {
-		return ErrUnknownActionType
+	if _, ok := pb.TriggerMode_name[int32(rule.TriggerMode)]; !ok {
+		return ErrUnknownTriggerMode
+	}
+
+	if rule.TriggerMode == pb.TriggerMode_THRESHOLD_TRIGGERS && rule.TriggerThreshold < 1 {
+		return ErrTriggerThreshold
+	}
+
+	if rule.Cooldown < 0 {
+		return ErrNegativeCooldown
+	}
+
+	if rule.MaxExecutions < 0 || (rule.MaxExecutions > 0 && rule.ExecutionPeriod <= 0) {
+		return ErrExecutionsBudget
 	}
 
 	for _, trigger := range rule.Triggers {
@@ -75,6 +110,97 @@ func (v *validator) ValidateRule(rule Rule) error {
 		if err := v.ValidateTarget(target); err != nil {
 			return fmt.Errorf("target validation failed: %v", err)
 		}
+
+		for _, actionType := range ruleActionTypes(rule) {
+			// Action types are known once the rule actions have been validated
+			definition, _ := pb.LookupAction(actionType)
+			if !definition.SupportsTargetType(target.Type) {
+				return fmt.Errorf(
+					"target validation failed: %v (action %s, target type %s)",
+					ErrUnsupportedTargetType,
+					actionType,
+					target.Type,
+				)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ValidateAction will check if given rule action is valid, and returns an error when not.
+func (v *validator) ValidateAction(action Action) error {
+	if err := v.validateActionType(action.ActionType, action.Settings); err != nil {
+		return err
+	}
+
+	switch action.OnFailure {
+	case pb.ActionFailurePolicy_ABORT, pb.ActionFailurePolicy_CONTINUE:
+		if action.CompensateType != pb.ActionType_UNDEFINED_ACTION || len(action.CompensateSettings) > 0 {
+			return ErrUnexpectedCompensation
+		}
+	case pb.ActionFailurePolicy_COMPENSATE:
+		if action.CompensateType == pb.ActionType_UNDEFINED_ACTION {
+			return ErrCompensationRequired
+		}
+
+		if err := v.validateActionType(action.CompensateType, action.CompensateSettings); err != nil {
+			return fmt.Errorf("compensating action validation failed: %v", err)
+		}
+	default:
+		return ErrUnknownFailurePolicy
+	}
+
+	return nil
+}
+
+func (v *validator) validateActionType(actionType pb.ActionType, settings []byte) error {
+	if actionType == pb.ActionType_UNDEFINED_ACTION {
+		return ErrUndefinedAction
+	}
+
+	if _, ok := pb.LookupAction(actionType); !ok {
+		return ErrUnknownActionType
+	}
+
+	return v.validateActionSettings(actionType, settings)
+}
+
+// ruleActionTypes returns the types of all the actions the rule may execute
+func ruleActionTypes(rule Rule) []pb.ActionType {
+	if len(rule.Actions) == 0 {
+		return []pb.ActionType{rule.ActionType}
+	}
+
+	var actionTypes []pb.ActionType
+	for _, action := range rule.Actions {
+		actionTypes = append(actionTypes, action.ActionType)
+		if action.OnFailure == pb.ActionFailurePolicy_COMPENSATE {
+			actionTypes = append(actionTypes, action.CompensateType)
+		}
+	}
+
+	return actionTypes
+}
+
+// validateActionSettings checks the settings of actions requiring them,
+// and makes sure no settings are given to actions not supporting any.
+func (v *validator) validateActionSettings(actionType pb.ActionType, settings []byte) error {
+	if !pb.HasActionSettings(actionType) && len(settings) == 0 {
+		return nil
+	}
+
+	if len(settings) == 0 {
+		return ErrActionSettingsRequired
+	}
+
+	actionSettings, err := pb.DecodeActionSettings(actionType, settings)
+	if err != nil {
+		return fmt.Errorf("action settings validation failed: %v", err)
+	}
+
+	if err := actionSettings.Validate(); err != nil {
+		return fmt.Errorf("action settings validation failed: %v", err)
 	}
 
 	return nil
@@ -47,6 +47,20 @@ func (m *MockValidator) EXPECT() *MockValidatorMockRecorder {
 	return m.recorder
 }
 
+// ValidateAction mocks base method
+func (m *MockValidator) ValidateAction(arg0 Action) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateAction", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateAction indicates an expected call of ValidateAction
+func (mr *MockValidatorMockRecorder) ValidateAction(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateAction", reflect.TypeOf((*MockValidator)(nil).ValidateAction), arg0)
+}
+
 // ValidateRule mocks base method
 func (m *MockValidator) ValidateRule(arg0 Rule) error {
 	m.ctrl.T.Helper()
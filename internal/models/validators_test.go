@@ -16,6 +16,7 @@ package models
 
 import (
 	"testing"
+	"time"
 
 	"github.com/teserakt-io/automation-engine/internal/pb"
 )
@@ -117,6 +118,29 @@ func TestValidator(t *testing.T) {
 			{Rule: Rule{ActionType: pb.ActionType(-1)}, ExpectedError: ErrUnknownActionType},
 			{Rule: Rule{ActionType: pb.ActionType_KEY_ROTATION, Triggers: []Trigger{Trigger{}}}},
 			{Rule: Rule{ActionType: pb.ActionType_KEY_ROTATION, Targets: []Target{Target{}}}},
+			{Rule: Rule{ActionType: pb.ActionType_REMOVE_CLIENT, Targets: []Target{Target{Type: pb.TargetType_TOPIC, Expr: "abc"}}}},
+			{Rule: Rule{ActionType: pb.ActionType_RESET_TOPIC, Targets: []Target{Target{Type: pb.TargetType_CLIENT, Expr: "abc"}}}},
+			{Rule: Rule{ActionType: pb.ActionType_WEBHOOK}, ExpectedError: ErrActionSettingsRequired},
+			{Rule: Rule{ActionType: pb.ActionType_WEBHOOK, ActionSettings: []byte(`{"url":"not a url"}`)}},
+			{Rule: Rule{ActionType: pb.ActionType_WEBHOOK, ActionSettings: []byte(`not_even_json`)}},
+			{Rule: Rule{ActionType: pb.ActionType_KEY_ROTATION, ActionSettings: []byte(`{"url":"http://example.com"}`)}},
+			{
+				Rule:          Rule{ActionType: pb.ActionType_KEY_ROTATION, Actions: []Action{Action{ActionType: pb.ActionType_KEY_ROTATION}}},
+				ExpectedError: ErrActionsConflict,
+			},
+			{Rule: Rule{Actions: []Action{Action{}}}},
+			{Rule: Rule{ActionType: pb.ActionType_KEY_ROTATION, TriggerMode: pb.TriggerMode(-1)}, ExpectedError: ErrUnknownTriggerMode},
+			{Rule: Rule{ActionType: pb.ActionType_KEY_ROTATION, TriggerMode: pb.TriggerMode_THRESHOLD_TRIGGERS}, ExpectedError: ErrTriggerThreshold},
+			{Rule: Rule{ActionType: pb.ActionType_KEY_ROTATION, Cooldown: -time.Second}, ExpectedError: ErrNegativeCooldown},
+			{Rule: Rule{ActionType: pb.ActionType_KEY_ROTATION, MaxExecutions: -1}, ExpectedError: ErrExecutionsBudget},
+			{Rule: Rule{ActionType: pb.ActionType_KEY_ROTATION, MaxExecutions: 3}, ExpectedError: ErrExecutionsBudget},
+			{Rule: Rule{Actions: []Action{Action{ActionType: pb.ActionType_KEY_ROTATION}, Action{ActionType: pb.ActionType_WEBHOOK}}}},
+			{
+				Rule: Rule{
+					Actions: []Action{Action{ActionType: pb.ActionType_KEY_ROTATION}, Action{ActionType: pb.ActionType_REMOVE_CLIENT}},
+					Targets: []Target{Target{Type: pb.TargetType_TOPIC, Expr: "abc"}},
+				},
+			},
 		}
 
 		for _, testData := range badRuleDataset {
@@ -131,6 +155,65 @@ func TestValidator(t *testing.T) {
 		}
 	})
 
+	t.Run("ValidateAction properly returns error with bad actions", func(t *testing.T) {
+		badActionDataset := []struct {
+			Action        Action
+			ExpectedError error
+		}{
+			{Action: Action{}, ExpectedError: ErrUndefinedAction},
+			{Action: Action{ActionType: pb.ActionType(-1)}, ExpectedError: ErrUnknownActionType},
+			{Action: Action{ActionType: pb.ActionType_WEBHOOK}, ExpectedError: ErrActionSettingsRequired},
+			{Action: Action{ActionType: pb.ActionType_KEY_ROTATION, OnFailure: pb.ActionFailurePolicy(-1)}, ExpectedError: ErrUnknownFailurePolicy},
+			{
+				Action:        Action{ActionType: pb.ActionType_KEY_ROTATION, OnFailure: pb.ActionFailurePolicy_COMPENSATE},
+				ExpectedError: ErrCompensationRequired,
+			},
+			{
+				Action:        Action{ActionType: pb.ActionType_KEY_ROTATION, CompensateType: pb.ActionType_RESET_TOPIC},
+				ExpectedError: ErrUnexpectedCompensation,
+			},
+			{
+				Action: Action{
+					ActionType:     pb.ActionType_KEY_ROTATION,
+					OnFailure:      pb.ActionFailurePolicy_COMPENSATE,
+					CompensateType: pb.ActionType_WEBHOOK,
+				},
+			},
+		}
+
+		for _, testData := range badActionDataset {
+			err := validator.ValidateAction(testData.Action)
+			if err == nil {
+				t.Errorf("Expected action %#v to produce a validation error, got nil", testData.Action)
+			}
+
+			if testData.ExpectedError != nil && err != testData.ExpectedError {
+				t.Errorf("Expected error to be %v, got %v", testData.ExpectedError, err)
+			}
+		}
+	})
+
+	t.Run("ValidateAction does not returns error with valid actions", func(t *testing.T) {
+		validActions := []Action{
+			Action{ActionType: pb.ActionType_KEY_ROTATION},
+			Action{ActionType: pb.ActionType_KEY_ROTATION, OnFailure: pb.ActionFailurePolicy_CONTINUE},
+			Action{ActionType: pb.ActionType_WEBHOOK, Settings: []byte(`{"url":"http://example.com"}`)},
+			Action{
+				ActionType:         pb.ActionType_RESET_TOPIC,
+				OnFailure:          pb.ActionFailurePolicy_COMPENSATE,
+				CompensateType:     pb.ActionType_WEBHOOK,
+				CompensateSettings: []byte(`{"url":"http://example.com"}`),
+			},
+		}
+
+		for _, action := range validActions {
+			err := validator.ValidateAction(action)
+			if err != nil {
+				t.Errorf("Expected no error when validating action %#v, got %v", action, err)
+			}
+		}
+	})
+
 	t.Run("ValidateRule does not returns error with valid rules", func(t *testing.T) {
 		validRules := []Rule{
 			Rule{ActionType: pb.ActionType_KEY_ROTATION},
@@ -141,6 +224,23 @@ func TestValidator(t *testing.T) {
 				Triggers:   []Trigger{Trigger{TriggerType: pb.TriggerType_EVENT, Settings: []byte(`{"eventType": "CLIENT_SUBSCRIBED", "maxOccurrence": 1}`)}},
 				Targets:    []Target{Target{Expr: "abc"}},
 			},
+			Rule{ActionType: pb.ActionType_REMOVE_CLIENT, Targets: []Target{Target{Type: pb.TargetType_CLIENT, Expr: "abc"}}},
+			Rule{
+				ActionType: pb.ActionType_UNLINK_CLIENT_TOPIC,
+				Targets:    []Target{Target{Type: pb.TargetType_CLIENT, Expr: "abc"}, Target{Type: pb.TargetType_TOPIC, Expr: "def"}},
+			},
+			Rule{ActionType: pb.ActionType_RESET_TOPIC, Targets: []Target{Target{Type: pb.TargetType_TOPIC, Expr: "abc"}}},
+			Rule{ActionType: pb.ActionType_KEY_ROTATION, TriggerMode: pb.TriggerMode_ALL_TRIGGERS},
+			Rule{ActionType: pb.ActionType_KEY_ROTATION, TriggerMode: pb.TriggerMode_THRESHOLD_TRIGGERS, TriggerThreshold: 2},
+			Rule{ActionType: pb.ActionType_KEY_ROTATION, Cooldown: time.Minute, MaxExecutions: 3, ExecutionPeriod: time.Hour},
+			Rule{ActionType: pb.ActionType_WEBHOOK, ActionSettings: []byte(`{"url":"http://example.com/hook","maxRetries":3}`)},
+			Rule{
+				Actions: []Action{
+					Action{ActionType: pb.ActionType_KEY_ROTATION},
+					Action{ActionType: pb.ActionType_WEBHOOK, Settings: []byte(`{"url":"http://example.com/hook"}`)},
+				},
+				Targets: []Target{Target{Type: pb.TargetType_TOPIC, Expr: "abc"}},
+			},
 		}
 
 		for _, Rule := range validRules {
@@ -0,0 +1,151 @@
+// Copyright 2020 Teserakt AG
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pb
+
+import (
+	fmt "fmt"
+	"sort"
+	"sync"
+)
+
+// ActionDefinition describes an action type, as registered with RegisterAction
+type ActionDefinition struct {
+	Type ActionType
+	Name string
+	// NewSettings returns empty settings for the action type, which are decoded
+	// and validated when the action is saved. It is nil when the action type doesn't
+	// support any settings.
+	NewSettings func() ActionSettings
+	// TargetTypes lists the target types supported by the action type
+	TargetTypes []TargetType
+}
+
+var actionRegistry = struct {
+	sync.RWMutex
+	definitions map[ActionType]ActionDefinition
+}{
+	definitions: make(map[ActionType]ActionDefinition),
+}
+
+func init() {
+	// ANY targets are accepted by every action, as they are used to match EVENT triggers.
+	RegisterAction(ActionDefinition{
+		Type:        ActionType_KEY_ROTATION,
+		Name:        ActionType_KEY_ROTATION.String(),
+		TargetTypes: []TargetType{TargetType_ANY, TargetType_CLIENT, TargetType_TOPIC},
+	})
+	RegisterAction(ActionDefinition{
+		Type:        ActionType_REMOVE_CLIENT,
+		Name:        ActionType_REMOVE_CLIENT.String(),
+		TargetTypes: []TargetType{TargetType_ANY, TargetType_CLIENT},
+	})
+	RegisterAction(ActionDefinition{
+		Type:        ActionType_UNLINK_CLIENT_TOPIC,
+		Name:        ActionType_UNLINK_CLIENT_TOPIC.String(),
+		TargetTypes: []TargetType{TargetType_ANY, TargetType_CLIENT, TargetType_TOPIC},
+	})
+	RegisterAction(ActionDefinition{
+		Type:        ActionType_RESET_TOPIC,
+		Name:        ActionType_RESET_TOPIC.String(),
+		TargetTypes: []TargetType{TargetType_ANY, TargetType_TOPIC},
+	})
+	RegisterAction(ActionDefinition{
+		Type:        ActionType_WEBHOOK,
+		Name:        ActionType_WEBHOOK.String(),
+		NewSettings: func() ActionSettings { return &ActionSettingsWebhook{} },
+		TargetTypes: []TargetType{TargetType_ANY, TargetType_CLIENT, TargetType_TOPIC},
+	})
+}
+
+// RegisterAction registers an action type, making it known to the rules validation and the cli.
+// Action types not defined in the api.proto ActionType enum get their name added to it.
+// It panics when the type is undefined, or when its type or name are already registered,
+// and is meant to be called from init functions.
+func RegisterAction(definition ActionDefinition) {
+	actionRegistry.Lock()
+	defer actionRegistry.Unlock()
+
+	if definition.Type == ActionType_UNDEFINED_ACTION || len(definition.Name) == 0 {
+		panic("pb: RegisterAction requires an action type and name")
+	}
+
+	if _, ok := actionRegistry.definitions[definition.Type]; ok {
+		panic(fmt.Sprintf("pb: action type %d is already registered", definition.Type))
+	}
+
+	if name, ok := ActionType_name[int32(definition.Type)]; ok && name != definition.Name {
+		panic(fmt.Sprintf("pb: action type %d is already named %s", definition.Type, name))
+	}
+
+	if value, ok := ActionType_value[definition.Name]; ok && value != int32(definition.Type) {
+		panic(fmt.Sprintf("pb: action name %s is already used by type %d", definition.Name, value))
+	}
+
+	ActionType_name[int32(definition.Type)] = definition.Name
+	ActionType_value[definition.Name] = int32(definition.Type)
+	actionRegistry.definitions[definition.Type] = definition
+}
+
+// LookupAction returns the definition of given action type, and false when it is not registered
+func LookupAction(t ActionType) (ActionDefinition, bool) {
+	actionRegistry.RLock()
+	defer actionRegistry.RUnlock()
+
+	definition, ok := actionRegistry.definitions[t]
+
+	return definition, ok
+}
+
+// LookupActionName returns the definition of the action type registered with given name,
+// and false when none are.
+func LookupActionName(name string) (ActionDefinition, bool) {
+	actionRegistry.RLock()
+	value, ok := ActionType_value[name]
+	actionRegistry.RUnlock()
+
+	if !ok {
+		return ActionDefinition{}, false
+	}
+
+	return LookupAction(ActionType(value))
+}
+
+// RegisteredActions returns the definitions of every registered action types, sorted by type
+func RegisteredActions() []ActionDefinition {
+	actionRegistry.RLock()
+	defer actionRegistry.RUnlock()
+
+	var definitions []ActionDefinition
+	for _, definition := range actionRegistry.definitions {
+		definitions = append(definitions, definition)
+	}
+
+	sort.Slice(definitions, func(i, j int) bool {
+		return definitions[i].Type < definitions[j].Type
+	})
+
+	return definitions
+}
+
+// SupportsTargetType returns true when the action type supports given target type
+func (d ActionDefinition) SupportsTargetType(targetType TargetType) bool {
+	for _, t := range d.TargetTypes {
+		if t == targetType {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,95 @@
+// Copyright 2020 Teserakt AG
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pb
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestActionRegistry(t *testing.T) {
+	t.Run("Built-in action types are registered", func(t *testing.T) {
+		var names []string
+		for _, definition := range RegisteredActions() {
+			names = append(names, definition.Name)
+		}
+
+		expectedNames := []string{"KEY_ROTATION", "REMOVE_CLIENT", "UNLINK_CLIENT_TOPIC", "RESET_TOPIC", "WEBHOOK"}
+		if reflect.DeepEqual(names[:len(expectedNames)], expectedNames) == false {
+			t.Errorf("Expected registered actions to start with %v, got %v", expectedNames, names)
+		}
+
+		if _, ok := LookupAction(ActionType_UNDEFINED_ACTION); ok {
+			t.Errorf("Expected UNDEFINED_ACTION to not be registered")
+		}
+
+		definition, ok := LookupActionName("RESET_TOPIC")
+		if !ok || definition.Type != ActionType_RESET_TOPIC {
+			t.Errorf("Expected RESET_TOPIC to be registered, got %#v", definition)
+		}
+
+		if definition.SupportsTargetType(TargetType_CLIENT) {
+			t.Errorf("Expected RESET_TOPIC to not support CLIENT targets")
+		}
+	})
+
+	t.Run("RegisterAction adds the action type name", func(t *testing.T) {
+		customType := ActionType(1000)
+		RegisterAction(ActionDefinition{
+			Type:        customType,
+			Name:        "CUSTOM_REGISTRY_TEST",
+			NewSettings: func() ActionSettings { return &ActionSettingsWebhook{} },
+			TargetTypes: []TargetType{TargetType_CLIENT},
+		})
+
+		if customType.String() != "CUSTOM_REGISTRY_TEST" {
+			t.Errorf("Expected action type name to be CUSTOM_REGISTRY_TEST, got %s", customType)
+		}
+
+		definition, ok := LookupActionName("CUSTOM_REGISTRY_TEST")
+		if !ok || definition.Type != customType {
+			t.Errorf("Expected custom action to be registered, got %#v", definition)
+		}
+
+		if !HasActionSettings(customType) {
+			t.Errorf("Expected custom action to have settings")
+		}
+
+		if _, err := DecodeActionSettings(customType, []byte(`{"url":"http://example.com"}`)); err != nil {
+			t.Errorf("Expected no error decoding custom action settings, got %v", err)
+		}
+	})
+
+	t.Run("RegisterAction panics on already registered types or names", func(t *testing.T) {
+		testData := []ActionDefinition{
+			ActionDefinition{Type: ActionType_WEBHOOK, Name: "WEBHOOK"},
+			ActionDefinition{Type: ActionType(1001), Name: "WEBHOOK"},
+			ActionDefinition{Type: ActionType(1002)},
+			ActionDefinition{Name: "UNDEFINED_REGISTRY_TEST"},
+		}
+
+		for _, definition := range testData {
+			func() {
+				defer func() {
+					if recover() == nil {
+						t.Errorf("Expected RegisterAction to panic with %#v", definition)
+					}
+				}()
+
+				RegisterAction(definition)
+			}()
+		}
+	})
+}
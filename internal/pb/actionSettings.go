@@ -0,0 +1,289 @@
+// Copyright 2020 Teserakt AG
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pb
+
+import (
+	"errors"
+	fmt "fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"text/template"
+	"time"
+)
+
+// Default values applied to webhook action settings left empty
+const (
+	DefaultWebhookMethod      = http.MethodPost
+	DefaultWebhookTimeout     = 10 * time.Second
+	DefaultWebhookContentType = "application/json"
+)
+
+// RedactedSecret replaces the secrets of the action settings returned by the api.
+// Sending it back when updating a rule keeps the stored secret.
+const RedactedSecret = "********"
+
+// ActionSettings defines a generic action settings structure
+type ActionSettings interface {
+	Validate() error
+	Encode() ([]byte, error)
+	Decode([]byte) error
+}
+
+// SecretActionSettings is implemented by the action settings holding secrets,
+// which must not be returned by the api
+type SecretActionSettings interface {
+	ActionSettings
+	// Redact replaces the secrets of the settings with RedactedSecret
+	Redact()
+	// Restore replaces the RedactedSecret values of the settings with the secrets of stored
+	Restore(stored ActionSettings)
+}
+
+// ActionSettingsWebhook holds settings for pb.ActionType_WEBHOOK actions
+type ActionSettingsWebhook struct {
+	URL          string            `json:"url,omitempty"`
+	Method       string            `json:"method,omitempty"`
+	Headers      map[string]string `json:"headers,omitempty"`
+	BodyTemplate string            `json:"bodyTemplate,omitempty"`
+	ContentType  string            `json:"contentType,omitempty"`
+	Timeout      string            `json:"timeout,omitempty"`
+	MaxRetries   int               `json:"maxRetries,omitempty"`
+	Secret       string            `json:"secret,omitempty"`
+}
+
+var _ SecretActionSettings = &ActionSettingsWebhook{}
+
+// DecodeActionSettings will attempt to turn []byte settings into matching struct given the action type
+func DecodeActionSettings(t ActionType, settings []byte) (ActionSettings, error) {
+	definition, ok := LookupAction(t)
+	if !ok || definition.NewSettings == nil {
+		return nil, fmt.Errorf("action type %s does not support settings", t)
+	}
+
+	actionSettings := definition.NewSettings()
+	if err := actionSettings.Decode(settings); err != nil {
+		return nil, err
+	}
+
+	return actionSettings, nil
+}
+
+// HasActionSettings returns true when given action type requires settings
+func HasActionSettings(t ActionType) bool {
+	definition, ok := LookupAction(t)
+
+	return ok && definition.NewSettings != nil
+}
+
+// RedactActionSettings returns the settings of given action type with their secrets
+// replaced by RedactedSecret. Settings not holding secrets are returned as is.
+func RedactActionSettings(t ActionType, settings []byte) ([]byte, error) {
+	if len(settings) == 0 || !HasActionSettings(t) {
+		return settings, nil
+	}
+
+	actionSettings, err := DecodeActionSettings(t, settings)
+	if err != nil {
+		return nil, err
+	}
+
+	secretSettings, ok := actionSettings.(SecretActionSettings)
+	if !ok {
+		return settings, nil
+	}
+
+	secretSettings.Redact()
+
+	return secretSettings.Encode()
+}
+
+// RestoreActionSettings returns the settings of given action type with their RedactedSecret values
+// replaced by the secrets of the stored settings, so updating a rule with the settings returned
+// by the api keeps its secrets. Settings are returned as is when the stored action type differs.
+func RestoreActionSettings(t ActionType, settings []byte, storedType ActionType, stored []byte) ([]byte, error) {
+	if t != storedType || len(settings) == 0 || len(stored) == 0 || !HasActionSettings(t) {
+		return settings, nil
+	}
+
+	actionSettings, err := DecodeActionSettings(t, settings)
+	if err != nil {
+		// Let the rule validation report the invalid settings
+		return settings, nil
+	}
+
+	secretSettings, ok := actionSettings.(SecretActionSettings)
+	if !ok {
+		return settings, nil
+	}
+
+	storedSettings, err := DecodeActionSettings(storedType, stored)
+	if err != nil {
+		return nil, err
+	}
+
+	secretSettings.Restore(storedSettings)
+
+	return secretSettings.Encode()
+}
+
+// Validate implements ActionSettings and returns an error when the settings are invalid
+func (s *ActionSettingsWebhook) Validate() error {
+	if len(s.URL) == 0 {
+		return errors.New("URL is required")
+	}
+
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		return fmt.Errorf("failed to parse URL: %s", err)
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errors.New("URL scheme must be http or https")
+	}
+
+	switch s.Method {
+	case "", http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+	default:
+		return fmt.Errorf("Method must be one of %v", []string{
+			http.MethodGet,
+			http.MethodPost,
+			http.MethodPut,
+			http.MethodPatch,
+			http.MethodDelete,
+		})
+	}
+
+	for name, value := range s.Headers {
+		if len(name) == 0 {
+			return errors.New("Headers cannot contain an empty name")
+		}
+
+		if value == RedactedSecret {
+			return fmt.Errorf("Headers cannot contain the redacted placeholder for %s", name)
+		}
+	}
+
+	if _, err := s.Template(); err != nil {
+		return fmt.Errorf("failed to parse BodyTemplate: %s", err)
+	}
+
+	if len(s.ContentType) > 0 {
+		if _, _, err := mime.ParseMediaType(s.ContentType); err != nil {
+			return fmt.Errorf("failed to parse ContentType: %s", err)
+		}
+	}
+
+	if s.Secret == RedactedSecret {
+		return errors.New("Secret cannot be the redacted placeholder")
+	}
+
+	if len(s.Timeout) > 0 {
+		timeout, err := time.ParseDuration(s.Timeout)
+		if err != nil {
+			return fmt.Errorf("failed to parse Timeout: %s", err)
+		}
+
+		if timeout <= 0 {
+			return errors.New("Timeout must be greater than 0")
+		}
+	}
+
+	if s.MaxRetries < 0 {
+		return errors.New("MaxRetries must be greater or equal to 0")
+	}
+
+	return nil
+}
+
+// HTTPMethod returns the configured method, or DefaultWebhookMethod when empty
+func (s *ActionSettingsWebhook) HTTPMethod() string {
+	if len(s.Method) == 0 {
+		return DefaultWebhookMethod
+	}
+
+	return s.Method
+}
+
+// ContentTypeHeader returns the configured content type, or DefaultWebhookContentType when empty
+func (s *ActionSettingsWebhook) ContentTypeHeader() string {
+	if len(s.ContentType) == 0 {
+		return DefaultWebhookContentType
+	}
+
+	return s.ContentType
+}
+
+// TimeoutDuration returns the configured timeout, or DefaultWebhookTimeout when empty or invalid
+func (s *ActionSettingsWebhook) TimeoutDuration() time.Duration {
+	timeout, err := time.ParseDuration(s.Timeout)
+	if err != nil || timeout <= 0 {
+		return DefaultWebhookTimeout
+	}
+
+	return timeout
+}
+
+// Template parses the BodyTemplate. It returns a nil template when no BodyTemplate is set.
+func (s *ActionSettingsWebhook) Template() (*template.Template, error) {
+	if len(s.BodyTemplate) == 0 {
+		return nil, nil
+	}
+
+	return template.New("body").Option("missingkey=error").Parse(s.BodyTemplate)
+}
+
+// Redact implements SecretActionSettings and replaces the secret and the header values with RedactedSecret, when set.
+// Header values often hold credentials, like an Authorization bearer token.
+func (s *ActionSettingsWebhook) Redact() {
+	if len(s.Secret) > 0 {
+		s.Secret = RedactedSecret
+	}
+
+	for name, value := range s.Headers {
+		if len(value) > 0 {
+			s.Headers[name] = RedactedSecret
+		}
+	}
+}
+
+// Restore implements SecretActionSettings and replaces the RedactedSecret values
+// of the secret and headers with the stored webhook ones
+func (s *ActionSettingsWebhook) Restore(stored ActionSettings) {
+	storedWebhook, ok := stored.(*ActionSettingsWebhook)
+	if !ok {
+		return
+	}
+
+	if s.Secret == RedactedSecret {
+		s.Secret = storedWebhook.Secret
+	}
+
+	for name, value := range s.Headers {
+		if storedValue, ok := storedWebhook.Headers[name]; ok && value == RedactedSecret {
+			s.Headers[name] = storedValue
+		}
+	}
+}
+
+// Encode json encode settings to []byte
+func (s *ActionSettingsWebhook) Encode() ([]byte, error) {
+	return jsonEncode(s)
+}
+
+// Decode json decode bytes to settings
+func (s *ActionSettingsWebhook) Decode(b []byte) error {
+	return jsonDecode(s, b)
+}
@@ -0,0 +1,182 @@
+// Copyright 2020 Teserakt AG
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pb
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestActionSettings(t *testing.T) {
+	t.Run("DecodeActionSettings properly decodes settings", func(t *testing.T) {
+		expectedSettings := &ActionSettingsWebhook{
+			URL:        "https://example.com/hook",
+			Headers:    map[string]string{"Authorization": "Bearer abc"},
+			MaxRetries: 3,
+		}
+
+		encoded, err := expectedSettings.Encode()
+		if err != nil {
+			t.Fatalf("Expected err to be nil, got %s", err)
+		}
+
+		settings, err := DecodeActionSettings(ActionType_WEBHOOK, encoded)
+		if err != nil {
+			t.Fatalf("Expected err to be nil, got %s", err)
+		}
+
+		if reflect.DeepEqual(settings, expectedSettings) == false {
+			t.Errorf("Expected settings to be %#v, got %#v", expectedSettings, settings)
+		}
+	})
+
+	t.Run("DecodeActionSettings returns an error on action types without settings", func(t *testing.T) {
+		if _, err := DecodeActionSettings(ActionType_KEY_ROTATION, []byte(`{}`)); err == nil {
+			t.Errorf("Expected err to be not nil")
+		}
+	})
+}
+
+func TestActionSettingsWebhook(t *testing.T) {
+	t.Run("Validate properly checks settings", func(t *testing.T) {
+		testData := map[*ActionSettingsWebhook]bool{
+			&ActionSettingsWebhook{}:                                                                                       false,
+			&ActionSettingsWebhook{URL: "example.com"}:                                                                     false,
+			&ActionSettingsWebhook{URL: "ftp://example.com"}:                                                               false,
+			&ActionSettingsWebhook{URL: "http://example.com", Method: "CONNECT"}:                                           false,
+			&ActionSettingsWebhook{URL: "http://example.com", Headers: map[string]string{"": "a"}}:                         false,
+			&ActionSettingsWebhook{URL: "http://example.com", BodyTemplate: "{{.Rule"}:                                     false,
+			&ActionSettingsWebhook{URL: "http://example.com", Timeout: "10"}:                                               false,
+			&ActionSettingsWebhook{URL: "http://example.com", Timeout: "-1s"}:                                              false,
+			&ActionSettingsWebhook{URL: "http://example.com", MaxRetries: -1}:                                              false,
+			&ActionSettingsWebhook{URL: "http://example.com", ContentType: "text/"}:                                        false,
+			&ActionSettingsWebhook{URL: "http://example.com", Secret: RedactedSecret}:                                      false,
+			&ActionSettingsWebhook{URL: "http://example.com", Headers: map[string]string{"Authorization": RedactedSecret}}: false,
+			&ActionSettingsWebhook{URL: "http://example.com"}:                                                              true,
+			&ActionSettingsWebhook{URL: "https://example.com/hook", Method: "PUT"}:                                         true,
+			&ActionSettingsWebhook{
+				URL:          "https://example.com/hook",
+				Headers:      map[string]string{"Content-Type": "text/plain"},
+				BodyTemplate: "rule {{.Rule.ID}} triggered",
+				ContentType:  "text/plain; charset=utf-8",
+				Timeout:      "500ms",
+				MaxRetries:   5,
+				Secret:       "s3cr3t",
+			}: true,
+		}
+
+		for settings, valid := range testData {
+			err := settings.Validate()
+
+			if valid && err != nil {
+				t.Errorf("Expected err to be nil, got %s with settings: %#v", err, settings)
+			} else if !valid && err == nil {
+				t.Errorf("Expected err to be not nil with settings: %#v", settings)
+			}
+		}
+	})
+
+	t.Run("Secrets are redacted and restored", func(t *testing.T) {
+		settings, err := RedactActionSettings(ActionType_WEBHOOK, []byte(`{"url":"http://example.com","secret":"s3cr3t"}`))
+		if err != nil {
+			t.Fatalf("Expected err to be nil, got %s", err)
+		}
+
+		expectedSettings := `{"url":"http://example.com","secret":"` + RedactedSecret + `"}`
+		if string(settings) != expectedSettings {
+			t.Errorf("Expected redacted settings to be %s, got %s", expectedSettings, settings)
+		}
+
+		restored, err := RestoreActionSettings(ActionType_WEBHOOK, settings, ActionType_WEBHOOK, []byte(`{"url":"http://old.example.com","secret":"s3cr3t"}`))
+		if err != nil {
+			t.Fatalf("Expected err to be nil, got %s", err)
+		}
+
+		expectedSettings = `{"url":"http://example.com","secret":"s3cr3t"}`
+		if string(restored) != expectedSettings {
+			t.Errorf("Expected restored settings to be %s, got %s", expectedSettings, restored)
+		}
+
+		// A new secret replaces the stored one
+		settings = []byte(`{"url":"http://example.com","secret":"new"}`)
+		restored, err = RestoreActionSettings(ActionType_WEBHOOK, settings, ActionType_WEBHOOK, []byte(`{"url":"http://example.com","secret":"s3cr3t"}`))
+		if err != nil {
+			t.Fatalf("Expected err to be nil, got %s", err)
+		}
+
+		if string(restored) != string(settings) {
+			t.Errorf("Expected settings to be %s, got %s", settings, restored)
+		}
+	})
+
+	t.Run("Header values are redacted and restored", func(t *testing.T) {
+		stored := []byte(`{"url":"http://example.com","headers":{"Authorization":"Bearer t0k3n","X-Tenant":"acme"}}`)
+
+		settings, err := RedactActionSettings(ActionType_WEBHOOK, stored)
+		if err != nil {
+			t.Fatalf("Expected err to be nil, got %s", err)
+		}
+
+		expectedSettings := `{"url":"http://example.com","headers":{"Authorization":"` + RedactedSecret + `","X-Tenant":"` + RedactedSecret + `"}}`
+		if string(settings) != expectedSettings {
+			t.Errorf("Expected redacted settings to be %s, got %s", expectedSettings, settings)
+		}
+
+		restored, err := RestoreActionSettings(ActionType_WEBHOOK, settings, ActionType_WEBHOOK, stored)
+		if err != nil {
+			t.Fatalf("Expected err to be nil, got %s", err)
+		}
+
+		if string(restored) != string(stored) {
+			t.Errorf("Expected restored settings to be %s, got %s", stored, restored)
+		}
+
+		// Updated header values replace the stored ones, and unknown redacted headers are kept as is
+		settings = []byte(`{"url":"http://example.com","headers":{"Authorization":"Bearer n3w","X-Other":"` + RedactedSecret + `"}}`)
+		restored, err = RestoreActionSettings(ActionType_WEBHOOK, settings, ActionType_WEBHOOK, stored)
+		if err != nil {
+			t.Fatalf("Expected err to be nil, got %s", err)
+		}
+
+		if string(restored) != string(settings) {
+			t.Errorf("Expected settings to be %s, got %s", settings, restored)
+		}
+	})
+
+	t.Run("Defaults are applied on empty settings", func(t *testing.T) {
+		settings := &ActionSettingsWebhook{}
+		if settings.HTTPMethod() != DefaultWebhookMethod {
+			t.Errorf("Expected method to be %s, got %s", DefaultWebhookMethod, settings.HTTPMethod())
+		}
+
+		if settings.TimeoutDuration() != DefaultWebhookTimeout {
+			t.Errorf("Expected timeout to be %s, got %s", DefaultWebhookTimeout, settings.TimeoutDuration())
+		}
+
+		if settings.ContentTypeHeader() != DefaultWebhookContentType {
+			t.Errorf("Expected content type to be %s, got %s", DefaultWebhookContentType, settings.ContentTypeHeader())
+		}
+
+		settings = &ActionSettingsWebhook{Method: "PUT", Timeout: "2s"}
+		if settings.HTTPMethod() != "PUT" {
+			t.Errorf("Expected method to be PUT, got %s", settings.HTTPMethod())
+		}
+
+		if settings.TimeoutDuration() != 2*time.Second {
+			t.Errorf("Expected timeout to be 2s, got %s", settings.TimeoutDuration())
+		}
+	})
+}
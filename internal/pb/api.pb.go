@@ -7,6 +7,7 @@ import (
 	context "context"
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
+	duration "github.com/golang/protobuf/ptypes/duration"
 	timestamp "github.com/golang/protobuf/ptypes/timestamp"
 	_ "github.com/grpc-ecosystem/grpc-gateway/protoc-gen-swagger/options"
 	_ "google.golang.org/genproto/googleapis/api/annotations"
@@ -31,18 +32,30 @@ const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 type ActionType int32
 
 const (
-	ActionType_UNDEFINED_ACTION ActionType = 0
-	ActionType_KEY_ROTATION     ActionType = 1
+	ActionType_UNDEFINED_ACTION    ActionType = 0
+	ActionType_KEY_ROTATION        ActionType = 1
+	ActionType_REMOVE_CLIENT       ActionType = 2
+	ActionType_UNLINK_CLIENT_TOPIC ActionType = 3
+	ActionType_RESET_TOPIC         ActionType = 4
+	ActionType_WEBHOOK             ActionType = 5
 )
 
 var ActionType_name = map[int32]string{
 	0: "UNDEFINED_ACTION",
 	1: "KEY_ROTATION",
+	2: "REMOVE_CLIENT",
+	3: "UNLINK_CLIENT_TOPIC",
+	4: "RESET_TOPIC",
+	5: "WEBHOOK",
 }
 
 var ActionType_value = map[string]int32{
-	"UNDEFINED_ACTION": 0,
-	"KEY_ROTATION":     1,
+	"UNDEFINED_ACTION":    0,
+	"KEY_ROTATION":        1,
+	"REMOVE_CLIENT":       2,
+	"UNLINK_CLIENT_TOPIC": 3,
+	"RESET_TOPIC":         4,
+	"WEBHOOK":             5,
 }
 
 func (x ActionType) String() string {
@@ -53,6 +66,38 @@ func (ActionType) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_00212fb1f9d3bf1c, []int{0}
 }
 
+// List of policies applied when one of the rule actions fails
+type ActionFailurePolicy int32
+
+const (
+	// Stop executing the following rule actions
+	ActionFailurePolicy_ABORT ActionFailurePolicy = 0
+	// Execute the following rule actions anyway
+	ActionFailurePolicy_CONTINUE ActionFailurePolicy = 1
+	// Execute the compensating action, then stop executing the following rule actions
+	ActionFailurePolicy_COMPENSATE ActionFailurePolicy = 2
+)
+
+var ActionFailurePolicy_name = map[int32]string{
+	0: "ABORT",
+	1: "CONTINUE",
+	2: "COMPENSATE",
+}
+
+var ActionFailurePolicy_value = map[string]int32{
+	"ABORT":      0,
+	"CONTINUE":   1,
+	"COMPENSATE": 2,
+}
+
+func (x ActionFailurePolicy) String() string {
+	return proto.EnumName(ActionFailurePolicy_name, int32(x))
+}
+
+func (ActionFailurePolicy) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{1}
+}
+
 // List of supported TargetType
 type TargetType int32
 
@@ -79,7 +124,39 @@ func (x TargetType) String() string {
 }
 
 func (TargetType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{1}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{2}
+}
+
+// TriggerMode defines how the triggers of a rule are combined
+type TriggerMode int32
+
+const (
+	// the rule is executed as soon as any of its triggers fires
+	TriggerMode_ANY_TRIGGER TriggerMode = 0
+	// the rule is executed once all of its triggers have fired
+	TriggerMode_ALL_TRIGGERS TriggerMode = 1
+	// the rule is executed once triggerThreshold of its triggers have fired
+	TriggerMode_THRESHOLD_TRIGGERS TriggerMode = 2
+)
+
+var TriggerMode_name = map[int32]string{
+	0: "ANY_TRIGGER",
+	1: "ALL_TRIGGERS",
+	2: "THRESHOLD_TRIGGERS",
+}
+
+var TriggerMode_value = map[string]int32{
+	"ANY_TRIGGER":        0,
+	"ALL_TRIGGERS":       1,
+	"THRESHOLD_TRIGGERS": 2,
+}
+
+func (x TriggerMode) String() string {
+	return proto.EnumName(TriggerMode_name, int32(x))
+}
+
+func (TriggerMode) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{3}
 }
 
 // List of supported TriggerType
@@ -89,18 +166,21 @@ const (
 	TriggerType_UNDEFINED_TRIGGER TriggerType = 0
 	TriggerType_TIME_INTERVAL     TriggerType = 1
 	TriggerType_EVENT             TriggerType = 2
+	TriggerType_ONCE              TriggerType = 3
 )
 
 var TriggerType_name = map[int32]string{
 	0: "UNDEFINED_TRIGGER",
 	1: "TIME_INTERVAL",
 	2: "EVENT",
+	3: "ONCE",
 }
 
 var TriggerType_value = map[string]int32{
 	"UNDEFINED_TRIGGER": 0,
 	"TIME_INTERVAL":     1,
 	"EVENT":             2,
+	"ONCE":              3,
 }
 
 func (x TriggerType) String() string {
@@ -108,16 +188,103 @@ func (x TriggerType) String() string {
 }
 
 func (TriggerType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{2}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{4}
+}
+
+// RuleWatcherState defines the state of a rule in the automation engine
+type RuleWatcherState int32
+
+const (
+	// the rule triggers are not watched
+	RuleWatcherState_RULE_STOPPED RuleWatcherState = 0
+	RuleWatcherState_RULE_RUNNING RuleWatcherState = 1
+	// the rule is paused, its triggers are not watched
+	RuleWatcherState_RULE_PAUSED RuleWatcherState = 2
+	// the rule triggers are watched, but the rule failed since it was started or last executed
+	RuleWatcherState_RULE_ERRORING RuleWatcherState = 3
+)
+
+var RuleWatcherState_name = map[int32]string{
+	0: "RULE_STOPPED",
+	1: "RULE_RUNNING",
+	2: "RULE_PAUSED",
+	3: "RULE_ERRORING",
+}
+
+var RuleWatcherState_value = map[string]int32{
+	"RULE_STOPPED":  0,
+	"RULE_RUNNING":  1,
+	"RULE_PAUSED":   2,
+	"RULE_ERRORING": 3,
+}
+
+func (x RuleWatcherState) String() string {
+	return proto.EnumName(RuleWatcherState_name, int32(x))
+}
+
+func (RuleWatcherState) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{5}
+}
+
+// TriggerWatcherState defines the state of a rule trigger in the automation engine
+type TriggerWatcherState int32
+
+const (
+	// the trigger is not watched
+	TriggerWatcherState_TRIGGER_STOPPED TriggerWatcherState = 0
+	TriggerWatcherState_TRIGGER_RUNNING TriggerWatcherState = 1
+	// the trigger cannot be watched, and will not fire until its rule is updated
+	TriggerWatcherState_TRIGGER_INVALID TriggerWatcherState = 2
+	// the trigger is watched, but failed since it was started or last fired
+	TriggerWatcherState_TRIGGER_ERRORING TriggerWatcherState = 3
+)
+
+var TriggerWatcherState_name = map[int32]string{
+	0: "TRIGGER_STOPPED",
+	1: "TRIGGER_RUNNING",
+	2: "TRIGGER_INVALID",
+	3: "TRIGGER_ERRORING",
+}
+
+var TriggerWatcherState_value = map[string]int32{
+	"TRIGGER_STOPPED":  0,
+	"TRIGGER_RUNNING":  1,
+	"TRIGGER_INVALID":  2,
+	"TRIGGER_ERRORING": 3,
+}
+
+func (x TriggerWatcherState) String() string {
+	return proto.EnumName(TriggerWatcherState_name, int32(x))
+}
+
+func (TriggerWatcherState) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{6}
 }
 
 type Rule struct {
-	Id                   int32                `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Description          string               `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
-	Action               ActionType           `protobuf:"varint,3,opt,name=action,proto3,enum=pb.ActionType" json:"action,omitempty"`
-	LastExecuted         *timestamp.Timestamp `protobuf:"bytes,4,opt,name=lastExecuted,proto3" json:"lastExecuted,omitempty"`
-	Triggers             []*Trigger           `protobuf:"bytes,5,rep,name=triggers,proto3" json:"triggers,omitempty"`
-	Targets              []*Target            `protobuf:"bytes,6,rep,name=targets,proto3" json:"targets,omitempty"`
+	Id             int32                `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Description    string               `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Action         ActionType           `protobuf:"varint,3,opt,name=action,proto3,enum=pb.ActionType" json:"action,omitempty"`
+	LastExecuted   *timestamp.Timestamp `protobuf:"bytes,4,opt,name=lastExecuted,proto3" json:"lastExecuted,omitempty"`
+	Triggers       []*Trigger           `protobuf:"bytes,5,rep,name=triggers,proto3" json:"triggers,omitempty"`
+	Targets        []*Target            `protobuf:"bytes,6,rep,name=targets,proto3" json:"targets,omitempty"`
+	ActionSettings []byte               `protobuf:"bytes,7,opt,name=actionSettings,proto3" json:"actionSettings,omitempty"`
+	Actions        []*Action            `protobuf:"bytes,8,rep,name=actions,proto3" json:"actions,omitempty"`
+	// false when the rule is paused
+	Enabled bool `protobuf:"varint,9,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	// when true, the rule actions record the C2 calls they would make instead of issuing them
+	DryRun      bool        `protobuf:"varint,10,opt,name=dryRun,proto3" json:"dryRun,omitempty"`
+	TriggerMode TriggerMode `protobuf:"varint,11,opt,name=triggerMode,proto3,enum=pb.TriggerMode" json:"triggerMode,omitempty"`
+	// number of triggers which must have fired before executing the rule, with the THRESHOLD_TRIGGERS mode
+	TriggerThreshold int32 `protobuf:"varint,12,opt,name=triggerThreshold,proto3" json:"triggerThreshold,omitempty"`
+	// minimum duration between two executions of the rule from its triggers
+	Cooldown *duration.Duration `protobuf:"bytes,13,opt,name=cooldown,proto3" json:"cooldown,omitempty"`
+	// maximum number of executions of the rule during executionPeriod, unlimited when 0
+	MaxExecutions   int32              `protobuf:"varint,14,opt,name=maxExecutions,proto3" json:"maxExecutions,omitempty"`
+	ExecutionPeriod *duration.Duration `protobuf:"bytes,15,opt,name=executionPeriod,proto3" json:"executionPeriod,omitempty"`
+	// number of trigger firings suppressed by the cooldown or the executions budget
+	SuppressedCount      int64                `protobuf:"varint,16,opt,name=suppressedCount,proto3" json:"suppressedCount,omitempty"`
+	LastSuppressed       *timestamp.Timestamp `protobuf:"bytes,17,opt,name=lastSuppressed,proto3" json:"lastSuppressed,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
 	XXX_unrecognized     []byte               `json:"-"`
 	XXX_sizecache        int32                `json:"-"`
@@ -190,6 +357,164 @@ func (m *Rule) GetTargets() []*Target {
 	return nil
 }
 
+func (m *Rule) GetActionSettings() []byte {
+	if m != nil {
+		return m.ActionSettings
+	}
+	return nil
+}
+
+func (m *Rule) GetActions() []*Action {
+	if m != nil {
+		return m.Actions
+	}
+	return nil
+}
+
+func (m *Rule) GetEnabled() bool {
+	if m != nil {
+		return m.Enabled
+	}
+	return false
+}
+
+func (m *Rule) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+func (m *Rule) GetTriggerMode() TriggerMode {
+	if m != nil {
+		return m.TriggerMode
+	}
+	return TriggerMode_ANY_TRIGGER
+}
+
+func (m *Rule) GetTriggerThreshold() int32 {
+	if m != nil {
+		return m.TriggerThreshold
+	}
+	return 0
+}
+
+func (m *Rule) GetCooldown() *duration.Duration {
+	if m != nil {
+		return m.Cooldown
+	}
+	return nil
+}
+
+func (m *Rule) GetMaxExecutions() int32 {
+	if m != nil {
+		return m.MaxExecutions
+	}
+	return 0
+}
+
+func (m *Rule) GetExecutionPeriod() *duration.Duration {
+	if m != nil {
+		return m.ExecutionPeriod
+	}
+	return nil
+}
+
+func (m *Rule) GetSuppressedCount() int64 {
+	if m != nil {
+		return m.SuppressedCount
+	}
+	return 0
+}
+
+func (m *Rule) GetLastSuppressed() *timestamp.Timestamp {
+	if m != nil {
+		return m.LastSuppressed
+	}
+	return nil
+}
+
+// Action is one of the actions a rule executes in sequence
+type Action struct {
+	Id        int32               `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type      ActionType          `protobuf:"varint,2,opt,name=type,proto3,enum=pb.ActionType" json:"type,omitempty"`
+	Settings  []byte              `protobuf:"bytes,3,opt,name=settings,proto3" json:"settings,omitempty"`
+	OnFailure ActionFailurePolicy `protobuf:"varint,4,opt,name=onFailure,proto3,enum=pb.ActionFailurePolicy" json:"onFailure,omitempty"`
+	// Action executed when this action fails and onFailure is COMPENSATE
+	CompensateType       ActionType `protobuf:"varint,5,opt,name=compensateType,proto3,enum=pb.ActionType" json:"compensateType,omitempty"`
+	CompensateSettings   []byte     `protobuf:"bytes,6,opt,name=compensateSettings,proto3" json:"compensateSettings,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *Action) Reset()         { *m = Action{} }
+func (m *Action) String() string { return proto.CompactTextString(m) }
+func (*Action) ProtoMessage()    {}
+func (*Action) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{1}
+}
+
+func (m *Action) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Action.Unmarshal(m, b)
+}
+func (m *Action) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Action.Marshal(b, m, deterministic)
+}
+func (m *Action) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Action.Merge(m, src)
+}
+func (m *Action) XXX_Size() int {
+	return xxx_messageInfo_Action.Size(m)
+}
+func (m *Action) XXX_DiscardUnknown() {
+	xxx_messageInfo_Action.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Action proto.InternalMessageInfo
+
+func (m *Action) GetId() int32 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *Action) GetType() ActionType {
+	if m != nil {
+		return m.Type
+	}
+	return ActionType_UNDEFINED_ACTION
+}
+
+func (m *Action) GetSettings() []byte {
+	if m != nil {
+		return m.Settings
+	}
+	return nil
+}
+
+func (m *Action) GetOnFailure() ActionFailurePolicy {
+	if m != nil {
+		return m.OnFailure
+	}
+	return ActionFailurePolicy_ABORT
+}
+
+func (m *Action) GetCompensateType() ActionType {
+	if m != nil {
+		return m.CompensateType
+	}
+	return ActionType_UNDEFINED_ACTION
+}
+
+func (m *Action) GetCompensateSettings() []byte {
+	if m != nil {
+		return m.CompensateSettings
+	}
+	return nil
+}
+
 type Target struct {
 	Id                   int32      `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	Type                 TargetType `protobuf:"varint,2,opt,name=type,proto3,enum=pb.TargetType" json:"type,omitempty"`
@@ -203,7 +528,7 @@ func (m *Target) Reset()         { *m = Target{} }
 func (m *Target) String() string { return proto.CompactTextString(m) }
 func (*Target) ProtoMessage()    {}
 func (*Target) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{1}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{2}
 }
 
 func (m *Target) XXX_Unmarshal(b []byte) error {
@@ -258,7 +583,7 @@ func (m *Trigger) Reset()         { *m = Trigger{} }
 func (m *Trigger) String() string { return proto.CompactTextString(m) }
 func (*Trigger) ProtoMessage()    {}
 func (*Trigger) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{2}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{3}
 }
 
 func (m *Trigger) XXX_Unmarshal(b []byte) error {
@@ -311,7 +636,7 @@ func (m *RulesResponse) Reset()         { *m = RulesResponse{} }
 func (m *RulesResponse) String() string { return proto.CompactTextString(m) }
 func (*RulesResponse) ProtoMessage()    {}
 func (*RulesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{3}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{4}
 }
 
 func (m *RulesResponse) XXX_Unmarshal(b []byte) error {
@@ -350,7 +675,7 @@ func (m *RuleResponse) Reset()         { *m = RuleResponse{} }
 func (m *RuleResponse) String() string { return proto.CompactTextString(m) }
 func (*RuleResponse) ProtoMessage()    {}
 func (*RuleResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{4}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{5}
 }
 
 func (m *RuleResponse) XXX_Unmarshal(b []byte) error {
@@ -388,7 +713,7 @@ func (m *ListRulesRequest) Reset()         { *m = ListRulesRequest{} }
 func (m *ListRulesRequest) String() string { return proto.CompactTextString(m) }
 func (*ListRulesRequest) ProtoMessage()    {}
 func (*ListRulesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{5}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{6}
 }
 
 func (m *ListRulesRequest) XXX_Unmarshal(b []byte) error {
@@ -420,7 +745,7 @@ func (m *GetRuleRequest) Reset()         { *m = GetRuleRequest{} }
 func (m *GetRuleRequest) String() string { return proto.CompactTextString(m) }
 func (*GetRuleRequest) ProtoMessage()    {}
 func (*GetRuleRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{6}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{7}
 }
 
 func (m *GetRuleRequest) XXX_Unmarshal(b []byte) error {
@@ -449,20 +774,28 @@ func (m *GetRuleRequest) GetRuleId() int32 {
 }
 
 type AddRuleRequest struct {
-	Description          string     `protobuf:"bytes,1,opt,name=description,proto3" json:"description,omitempty"`
-	Action               ActionType `protobuf:"varint,2,opt,name=action,proto3,enum=pb.ActionType" json:"action,omitempty"`
-	Triggers             []*Trigger `protobuf:"bytes,3,rep,name=triggers,proto3" json:"triggers,omitempty"`
-	Targets              []*Target  `protobuf:"bytes,4,rep,name=targets,proto3" json:"targets,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+	Description          string             `protobuf:"bytes,1,opt,name=description,proto3" json:"description,omitempty"`
+	Action               ActionType         `protobuf:"varint,2,opt,name=action,proto3,enum=pb.ActionType" json:"action,omitempty"`
+	Triggers             []*Trigger         `protobuf:"bytes,3,rep,name=triggers,proto3" json:"triggers,omitempty"`
+	Targets              []*Target          `protobuf:"bytes,4,rep,name=targets,proto3" json:"targets,omitempty"`
+	ActionSettings       []byte             `protobuf:"bytes,5,opt,name=actionSettings,proto3" json:"actionSettings,omitempty"`
+	Actions              []*Action          `protobuf:"bytes,6,rep,name=actions,proto3" json:"actions,omitempty"`
+	DryRun               bool               `protobuf:"varint,7,opt,name=dryRun,proto3" json:"dryRun,omitempty"`
+	TriggerMode          TriggerMode        `protobuf:"varint,8,opt,name=triggerMode,proto3,enum=pb.TriggerMode" json:"triggerMode,omitempty"`
+	TriggerThreshold     int32              `protobuf:"varint,9,opt,name=triggerThreshold,proto3" json:"triggerThreshold,omitempty"`
+	Cooldown             *duration.Duration `protobuf:"bytes,10,opt,name=cooldown,proto3" json:"cooldown,omitempty"`
+	MaxExecutions        int32              `protobuf:"varint,11,opt,name=maxExecutions,proto3" json:"maxExecutions,omitempty"`
+	ExecutionPeriod      *duration.Duration `protobuf:"bytes,12,opt,name=executionPeriod,proto3" json:"executionPeriod,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
 }
 
 func (m *AddRuleRequest) Reset()         { *m = AddRuleRequest{} }
 func (m *AddRuleRequest) String() string { return proto.CompactTextString(m) }
 func (*AddRuleRequest) ProtoMessage()    {}
 func (*AddRuleRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{7}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{8}
 }
 
 func (m *AddRuleRequest) XXX_Unmarshal(b []byte) error {
@@ -511,25 +844,89 @@ func (m *AddRuleRequest) GetTargets() []*Target {
 	return nil
 }
 
+func (m *AddRuleRequest) GetActionSettings() []byte {
+	if m != nil {
+		return m.ActionSettings
+	}
+	return nil
+}
+
+func (m *AddRuleRequest) GetActions() []*Action {
+	if m != nil {
+		return m.Actions
+	}
+	return nil
+}
+
+func (m *AddRuleRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+func (m *AddRuleRequest) GetTriggerMode() TriggerMode {
+	if m != nil {
+		return m.TriggerMode
+	}
+	return TriggerMode_ANY_TRIGGER
+}
+
+func (m *AddRuleRequest) GetTriggerThreshold() int32 {
+	if m != nil {
+		return m.TriggerThreshold
+	}
+	return 0
+}
+
+func (m *AddRuleRequest) GetCooldown() *duration.Duration {
+	if m != nil {
+		return m.Cooldown
+	}
+	return nil
+}
+
+func (m *AddRuleRequest) GetMaxExecutions() int32 {
+	if m != nil {
+		return m.MaxExecutions
+	}
+	return 0
+}
+
+func (m *AddRuleRequest) GetExecutionPeriod() *duration.Duration {
+	if m != nil {
+		return m.ExecutionPeriod
+	}
+	return nil
+}
+
 // UpdateRuleRequest will fetch the rule identified by ruleId,
-// and override its description, action, triggers and targets values
-// with those provided.
+// and override its description, action, action settings, actions, triggers, targets, dry run,
+// trigger mode and rate limiting values with those provided.
 type UpdateRuleRequest struct {
-	RuleId               int32      `protobuf:"varint,1,opt,name=ruleId,proto3" json:"ruleId,omitempty"`
-	Description          string     `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
-	Action               ActionType `protobuf:"varint,3,opt,name=action,proto3,enum=pb.ActionType" json:"action,omitempty"`
-	Triggers             []*Trigger `protobuf:"bytes,4,rep,name=triggers,proto3" json:"triggers,omitempty"`
-	Targets              []*Target  `protobuf:"bytes,5,rep,name=targets,proto3" json:"targets,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+	RuleId               int32              `protobuf:"varint,1,opt,name=ruleId,proto3" json:"ruleId,omitempty"`
+	Description          string             `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Action               ActionType         `protobuf:"varint,3,opt,name=action,proto3,enum=pb.ActionType" json:"action,omitempty"`
+	Triggers             []*Trigger         `protobuf:"bytes,4,rep,name=triggers,proto3" json:"triggers,omitempty"`
+	Targets              []*Target          `protobuf:"bytes,5,rep,name=targets,proto3" json:"targets,omitempty"`
+	ActionSettings       []byte             `protobuf:"bytes,6,opt,name=actionSettings,proto3" json:"actionSettings,omitempty"`
+	Actions              []*Action          `protobuf:"bytes,7,rep,name=actions,proto3" json:"actions,omitempty"`
+	DryRun               bool               `protobuf:"varint,8,opt,name=dryRun,proto3" json:"dryRun,omitempty"`
+	TriggerMode          TriggerMode        `protobuf:"varint,9,opt,name=triggerMode,proto3,enum=pb.TriggerMode" json:"triggerMode,omitempty"`
+	TriggerThreshold     int32              `protobuf:"varint,10,opt,name=triggerThreshold,proto3" json:"triggerThreshold,omitempty"`
+	Cooldown             *duration.Duration `protobuf:"bytes,11,opt,name=cooldown,proto3" json:"cooldown,omitempty"`
+	MaxExecutions        int32              `protobuf:"varint,12,opt,name=maxExecutions,proto3" json:"maxExecutions,omitempty"`
+	ExecutionPeriod      *duration.Duration `protobuf:"bytes,13,opt,name=executionPeriod,proto3" json:"executionPeriod,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
 }
 
 func (m *UpdateRuleRequest) Reset()         { *m = UpdateRuleRequest{} }
 func (m *UpdateRuleRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateRuleRequest) ProtoMessage()    {}
 func (*UpdateRuleRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{8}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{9}
 }
 
 func (m *UpdateRuleRequest) XXX_Unmarshal(b []byte) error {
@@ -585,6 +982,62 @@ func (m *UpdateRuleRequest) GetTargets() []*Target {
 	return nil
 }
 
+func (m *UpdateRuleRequest) GetActionSettings() []byte {
+	if m != nil {
+		return m.ActionSettings
+	}
+	return nil
+}
+
+func (m *UpdateRuleRequest) GetActions() []*Action {
+	if m != nil {
+		return m.Actions
+	}
+	return nil
+}
+
+func (m *UpdateRuleRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+func (m *UpdateRuleRequest) GetTriggerMode() TriggerMode {
+	if m != nil {
+		return m.TriggerMode
+	}
+	return TriggerMode_ANY_TRIGGER
+}
+
+func (m *UpdateRuleRequest) GetTriggerThreshold() int32 {
+	if m != nil {
+		return m.TriggerThreshold
+	}
+	return 0
+}
+
+func (m *UpdateRuleRequest) GetCooldown() *duration.Duration {
+	if m != nil {
+		return m.Cooldown
+	}
+	return nil
+}
+
+func (m *UpdateRuleRequest) GetMaxExecutions() int32 {
+	if m != nil {
+		return m.MaxExecutions
+	}
+	return 0
+}
+
+func (m *UpdateRuleRequest) GetExecutionPeriod() *duration.Duration {
+	if m != nil {
+		return m.ExecutionPeriod
+	}
+	return nil
+}
+
 type DeleteRuleRequest struct {
 	RuleId               int32    `protobuf:"varint,1,opt,name=ruleId,proto3" json:"ruleId,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -596,7 +1049,7 @@ func (m *DeleteRuleRequest) Reset()         { *m = DeleteRuleRequest{} }
 func (m *DeleteRuleRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteRuleRequest) ProtoMessage()    {}
 func (*DeleteRuleRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{9}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{10}
 }
 
 func (m *DeleteRuleRequest) XXX_Unmarshal(b []byte) error {
@@ -635,34 +1088,972 @@ func (m *DeleteRuleResponse) Reset()         { *m = DeleteRuleResponse{} }
 func (m *DeleteRuleResponse) String() string { return proto.CompactTextString(m) }
 func (*DeleteRuleResponse) ProtoMessage()    {}
 func (*DeleteRuleResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{10}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{11}
+}
+
+func (m *DeleteRuleResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteRuleResponse.Unmarshal(m, b)
+}
+func (m *DeleteRuleResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteRuleResponse.Marshal(b, m, deterministic)
+}
+func (m *DeleteRuleResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteRuleResponse.Merge(m, src)
+}
+func (m *DeleteRuleResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteRuleResponse.Size(m)
+}
+func (m *DeleteRuleResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteRuleResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteRuleResponse proto.InternalMessageInfo
+
+func (m *DeleteRuleResponse) GetRuleId() int32 {
+	if m != nil {
+		return m.RuleId
+	}
+	return 0
+}
+
+type PauseRuleRequest struct {
+	RuleId               int32    `protobuf:"varint,1,opt,name=ruleId,proto3" json:"ruleId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PauseRuleRequest) Reset()         { *m = PauseRuleRequest{} }
+func (m *PauseRuleRequest) String() string { return proto.CompactTextString(m) }
+func (*PauseRuleRequest) ProtoMessage()    {}
+func (*PauseRuleRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{12}
+}
+
+func (m *PauseRuleRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PauseRuleRequest.Unmarshal(m, b)
+}
+func (m *PauseRuleRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PauseRuleRequest.Marshal(b, m, deterministic)
+}
+func (m *PauseRuleRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PauseRuleRequest.Merge(m, src)
+}
+func (m *PauseRuleRequest) XXX_Size() int {
+	return xxx_messageInfo_PauseRuleRequest.Size(m)
+}
+func (m *PauseRuleRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PauseRuleRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PauseRuleRequest proto.InternalMessageInfo
+
+func (m *PauseRuleRequest) GetRuleId() int32 {
+	if m != nil {
+		return m.RuleId
+	}
+	return 0
+}
+
+type ResumeRuleRequest struct {
+	RuleId               int32    `protobuf:"varint,1,opt,name=ruleId,proto3" json:"ruleId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ResumeRuleRequest) Reset()         { *m = ResumeRuleRequest{} }
+func (m *ResumeRuleRequest) String() string { return proto.CompactTextString(m) }
+func (*ResumeRuleRequest) ProtoMessage()    {}
+func (*ResumeRuleRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{13}
+}
+
+func (m *ResumeRuleRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResumeRuleRequest.Unmarshal(m, b)
+}
+func (m *ResumeRuleRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResumeRuleRequest.Marshal(b, m, deterministic)
+}
+func (m *ResumeRuleRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResumeRuleRequest.Merge(m, src)
+}
+func (m *ResumeRuleRequest) XXX_Size() int {
+	return xxx_messageInfo_ResumeRuleRequest.Size(m)
+}
+func (m *ResumeRuleRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResumeRuleRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResumeRuleRequest proto.InternalMessageInfo
+
+func (m *ResumeRuleRequest) GetRuleId() int32 {
+	if m != nil {
+		return m.RuleId
+	}
+	return 0
+}
+
+// ExecuteRuleRequest executes the action of the rule identified by ruleId.
+// When dryRun is set, the action is executed in dry-run mode, whatever the rule setting.
+type ExecuteRuleRequest struct {
+	RuleId               int32    `protobuf:"varint,1,opt,name=ruleId,proto3" json:"ruleId,omitempty"`
+	DryRun               bool     `protobuf:"varint,2,opt,name=dryRun,proto3" json:"dryRun,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExecuteRuleRequest) Reset()         { *m = ExecuteRuleRequest{} }
+func (m *ExecuteRuleRequest) String() string { return proto.CompactTextString(m) }
+func (*ExecuteRuleRequest) ProtoMessage()    {}
+func (*ExecuteRuleRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{14}
+}
+
+func (m *ExecuteRuleRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExecuteRuleRequest.Unmarshal(m, b)
+}
+func (m *ExecuteRuleRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExecuteRuleRequest.Marshal(b, m, deterministic)
+}
+func (m *ExecuteRuleRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExecuteRuleRequest.Merge(m, src)
+}
+func (m *ExecuteRuleRequest) XXX_Size() int {
+	return xxx_messageInfo_ExecuteRuleRequest.Size(m)
+}
+func (m *ExecuteRuleRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExecuteRuleRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExecuteRuleRequest proto.InternalMessageInfo
+
+func (m *ExecuteRuleRequest) GetRuleId() int32 {
+	if m != nil {
+		return m.RuleId
+	}
+	return 0
+}
+
+func (m *ExecuteRuleRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+// ListExecutionsRequest retrieves the executions of the rule identified by ruleId,
+// most recent first. When set, only executions triggered after since are returned.
+// limit defaults to 100 when not set.
+type ListExecutionsRequest struct {
+	RuleId               int32                `protobuf:"varint,1,opt,name=ruleId,proto3" json:"ruleId,omitempty"`
+	Since                *timestamp.Timestamp `protobuf:"bytes,2,opt,name=since,proto3" json:"since,omitempty"`
+	Limit                int32                `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ListExecutionsRequest) Reset()         { *m = ListExecutionsRequest{} }
+func (m *ListExecutionsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListExecutionsRequest) ProtoMessage()    {}
+func (*ListExecutionsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{15}
+}
+
+func (m *ListExecutionsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListExecutionsRequest.Unmarshal(m, b)
+}
+func (m *ListExecutionsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListExecutionsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListExecutionsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListExecutionsRequest.Merge(m, src)
+}
+func (m *ListExecutionsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListExecutionsRequest.Size(m)
+}
+func (m *ListExecutionsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListExecutionsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListExecutionsRequest proto.InternalMessageInfo
+
+func (m *ListExecutionsRequest) GetRuleId() int32 {
+	if m != nil {
+		return m.RuleId
+	}
+	return 0
+}
+
+func (m *ListExecutionsRequest) GetSince() *timestamp.Timestamp {
+	if m != nil {
+		return m.Since
+	}
+	return nil
+}
+
+func (m *ListExecutionsRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type ExecutionsResponse struct {
+	Executions           []*Execution `protobuf:"bytes,1,rep,name=executions,proto3" json:"executions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *ExecutionsResponse) Reset()         { *m = ExecutionsResponse{} }
+func (m *ExecutionsResponse) String() string { return proto.CompactTextString(m) }
+func (*ExecutionsResponse) ProtoMessage()    {}
+func (*ExecutionsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{16}
+}
+
+func (m *ExecutionsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExecutionsResponse.Unmarshal(m, b)
+}
+func (m *ExecutionsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExecutionsResponse.Marshal(b, m, deterministic)
+}
+func (m *ExecutionsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExecutionsResponse.Merge(m, src)
+}
+func (m *ExecutionsResponse) XXX_Size() int {
+	return xxx_messageInfo_ExecutionsResponse.Size(m)
+}
+func (m *ExecutionsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExecutionsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExecutionsResponse proto.InternalMessageInfo
+
+func (m *ExecutionsResponse) GetExecutions() []*Execution {
+	if m != nil {
+		return m.Executions
+	}
+	return nil
+}
+
+type ExecutionResponse struct {
+	Execution            *Execution `protobuf:"bytes,1,opt,name=execution,proto3" json:"execution,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *ExecutionResponse) Reset()         { *m = ExecutionResponse{} }
+func (m *ExecutionResponse) String() string { return proto.CompactTextString(m) }
+func (*ExecutionResponse) ProtoMessage()    {}
+func (*ExecutionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{17}
+}
+
+func (m *ExecutionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExecutionResponse.Unmarshal(m, b)
+}
+func (m *ExecutionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExecutionResponse.Marshal(b, m, deterministic)
+}
+func (m *ExecutionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExecutionResponse.Merge(m, src)
+}
+func (m *ExecutionResponse) XXX_Size() int {
+	return xxx_messageInfo_ExecutionResponse.Size(m)
+}
+func (m *ExecutionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExecutionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExecutionResponse proto.InternalMessageInfo
+
+func (m *ExecutionResponse) GetExecution() *Execution {
+	if m != nil {
+		return m.Execution
+	}
+	return nil
+}
+
+// Execution describes a rule execution
+type Execution struct {
+	Id     int32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	RuleId int32 `protobuf:"varint,2,opt,name=ruleId,proto3" json:"ruleId,omitempty"`
+	// triggerId is 0 when the execution was manually requested
+	TriggerId   int32                `protobuf:"varint,3,opt,name=triggerId,proto3" json:"triggerId,omitempty"`
+	TriggeredAt *timestamp.Timestamp `protobuf:"bytes,4,opt,name=triggeredAt,proto3" json:"triggeredAt,omitempty"`
+	// C2 event which caused the trigger to fire, when triggered by an event
+	Event    *ExecutionEvent     `protobuf:"bytes,5,opt,name=event,proto3" json:"event,omitempty"`
+	Outcomes []*ExecutionOutcome `protobuf:"bytes,6,rep,name=outcomes,proto3" json:"outcomes,omitempty"`
+	Duration *duration.Duration  `protobuf:"bytes,7,opt,name=duration,proto3" json:"duration,omitempty"`
+	Error    string              `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`
+	// true when the actions were executed in dry-run mode
+	DryRun               bool     `protobuf:"varint,9,opt,name=dryRun,proto3" json:"dryRun,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Execution) Reset()         { *m = Execution{} }
+func (m *Execution) String() string { return proto.CompactTextString(m) }
+func (*Execution) ProtoMessage()    {}
+func (*Execution) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{18}
+}
+
+func (m *Execution) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Execution.Unmarshal(m, b)
+}
+func (m *Execution) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Execution.Marshal(b, m, deterministic)
+}
+func (m *Execution) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Execution.Merge(m, src)
+}
+func (m *Execution) XXX_Size() int {
+	return xxx_messageInfo_Execution.Size(m)
+}
+func (m *Execution) XXX_DiscardUnknown() {
+	xxx_messageInfo_Execution.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Execution proto.InternalMessageInfo
+
+func (m *Execution) GetId() int32 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *Execution) GetRuleId() int32 {
+	if m != nil {
+		return m.RuleId
+	}
+	return 0
+}
+
+func (m *Execution) GetTriggerId() int32 {
+	if m != nil {
+		return m.TriggerId
+	}
+	return 0
+}
+
+func (m *Execution) GetTriggeredAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.TriggeredAt
+	}
+	return nil
+}
+
+func (m *Execution) GetEvent() *ExecutionEvent {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+func (m *Execution) GetOutcomes() []*ExecutionOutcome {
+	if m != nil {
+		return m.Outcomes
+	}
+	return nil
+}
+
+func (m *Execution) GetDuration() *duration.Duration {
+	if m != nil {
+		return m.Duration
+	}
+	return nil
+}
+
+func (m *Execution) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *Execution) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+// ExecutionEvent describes the C2 event which caused a rule execution
+type ExecutionEvent struct {
+	Type                 string               `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Source               string               `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	Target               string               `protobuf:"bytes,3,opt,name=target,proto3" json:"target,omitempty"`
+	Timestamp            *timestamp.Timestamp `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ExecutionEvent) Reset()         { *m = ExecutionEvent{} }
+func (m *ExecutionEvent) String() string { return proto.CompactTextString(m) }
+func (*ExecutionEvent) ProtoMessage()    {}
+func (*ExecutionEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{19}
+}
+
+func (m *ExecutionEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExecutionEvent.Unmarshal(m, b)
+}
+func (m *ExecutionEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExecutionEvent.Marshal(b, m, deterministic)
+}
+func (m *ExecutionEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExecutionEvent.Merge(m, src)
+}
+func (m *ExecutionEvent) XXX_Size() int {
+	return xxx_messageInfo_ExecutionEvent.Size(m)
+}
+func (m *ExecutionEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExecutionEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExecutionEvent proto.InternalMessageInfo
+
+func (m *ExecutionEvent) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *ExecutionEvent) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+func (m *ExecutionEvent) GetTarget() string {
+	if m != nil {
+		return m.Target
+	}
+	return ""
+}
+
+func (m *ExecutionEvent) GetTimestamp() *timestamp.Timestamp {
+	if m != nil {
+		return m.Timestamp
+	}
+	return nil
+}
+
+// ExecutionOutcome describes the result of a rule action on one of its targets
+type ExecutionOutcome struct {
+	Action     ActionType `protobuf:"varint,1,opt,name=action,proto3,enum=pb.ActionType" json:"action,omitempty"`
+	TargetType TargetType `protobuf:"varint,2,opt,name=targetType,proto3,enum=pb.TargetType" json:"targetType,omitempty"`
+	Target     string     `protobuf:"bytes,3,opt,name=target,proto3" json:"target,omitempty"`
+	Error      string     `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	// comma separated C2 calls the action would have issued on the target, when executed in dry-run mode
+	DryRunCalls string `protobuf:"bytes,5,opt,name=dryRunCalls,proto3" json:"dryRunCalls,omitempty"`
+	// true while the action has not completed on the target yet, the outcome being updated once it does
+	Pending              bool     `protobuf:"varint,6,opt,name=pending,proto3" json:"pending,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExecutionOutcome) Reset()         { *m = ExecutionOutcome{} }
+func (m *ExecutionOutcome) String() string { return proto.CompactTextString(m) }
+func (*ExecutionOutcome) ProtoMessage()    {}
+func (*ExecutionOutcome) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{20}
+}
+
+func (m *ExecutionOutcome) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExecutionOutcome.Unmarshal(m, b)
+}
+func (m *ExecutionOutcome) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExecutionOutcome.Marshal(b, m, deterministic)
+}
+func (m *ExecutionOutcome) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExecutionOutcome.Merge(m, src)
+}
+func (m *ExecutionOutcome) XXX_Size() int {
+	return xxx_messageInfo_ExecutionOutcome.Size(m)
+}
+func (m *ExecutionOutcome) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExecutionOutcome.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExecutionOutcome proto.InternalMessageInfo
+
+func (m *ExecutionOutcome) GetAction() ActionType {
+	if m != nil {
+		return m.Action
+	}
+	return ActionType_UNDEFINED_ACTION
+}
+
+func (m *ExecutionOutcome) GetTargetType() TargetType {
+	if m != nil {
+		return m.TargetType
+	}
+	return TargetType_ANY
+}
+
+func (m *ExecutionOutcome) GetTarget() string {
+	if m != nil {
+		return m.Target
+	}
+	return ""
+}
+
+func (m *ExecutionOutcome) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *ExecutionOutcome) GetDryRunCalls() string {
+	if m != nil {
+		return m.DryRunCalls
+	}
+	return ""
+}
+
+func (m *ExecutionOutcome) GetPending() bool {
+	if m != nil {
+		return m.Pending
+	}
+	return false
+}
+
+type GetRuleStatusRequest struct {
+	RuleId               int32    `protobuf:"varint,1,opt,name=ruleId,proto3" json:"ruleId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetRuleStatusRequest) Reset()         { *m = GetRuleStatusRequest{} }
+func (m *GetRuleStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*GetRuleStatusRequest) ProtoMessage()    {}
+func (*GetRuleStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{21}
+}
+
+func (m *GetRuleStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetRuleStatusRequest.Unmarshal(m, b)
+}
+func (m *GetRuleStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetRuleStatusRequest.Marshal(b, m, deterministic)
+}
+func (m *GetRuleStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetRuleStatusRequest.Merge(m, src)
+}
+func (m *GetRuleStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_GetRuleStatusRequest.Size(m)
+}
+func (m *GetRuleStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetRuleStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetRuleStatusRequest proto.InternalMessageInfo
+
+func (m *GetRuleStatusRequest) GetRuleId() int32 {
+	if m != nil {
+		return m.RuleId
+	}
+	return 0
+}
+
+type RuleStatusResponse struct {
+	Status               *RuleStatus `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *RuleStatusResponse) Reset()         { *m = RuleStatusResponse{} }
+func (m *RuleStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*RuleStatusResponse) ProtoMessage()    {}
+func (*RuleStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{22}
+}
+
+func (m *RuleStatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RuleStatusResponse.Unmarshal(m, b)
+}
+func (m *RuleStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RuleStatusResponse.Marshal(b, m, deterministic)
+}
+func (m *RuleStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RuleStatusResponse.Merge(m, src)
+}
+func (m *RuleStatusResponse) XXX_Size() int {
+	return xxx_messageInfo_RuleStatusResponse.Size(m)
+}
+func (m *RuleStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RuleStatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RuleStatusResponse proto.InternalMessageInfo
+
+func (m *RuleStatusResponse) GetStatus() *RuleStatus {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+// RuleStatus describes the state of a rule in the automation engine, and its last error.
+// errorCount is the number of errors since the engine started.
+type RuleStatus struct {
+	RuleId               int32                `protobuf:"varint,1,opt,name=ruleId,proto3" json:"ruleId,omitempty"`
+	State                RuleWatcherState     `protobuf:"varint,2,opt,name=state,proto3,enum=pb.RuleWatcherState" json:"state,omitempty"`
+	LastError            string               `protobuf:"bytes,3,opt,name=lastError,proto3" json:"lastError,omitempty"`
+	LastErrorTime        *timestamp.Timestamp `protobuf:"bytes,4,opt,name=lastErrorTime,proto3" json:"lastErrorTime,omitempty"`
+	ErrorCount           int64                `protobuf:"varint,5,opt,name=errorCount,proto3" json:"errorCount,omitempty"`
+	Triggers             []*TriggerStatus     `protobuf:"bytes,6,rep,name=triggers,proto3" json:"triggers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *RuleStatus) Reset()         { *m = RuleStatus{} }
+func (m *RuleStatus) String() string { return proto.CompactTextString(m) }
+func (*RuleStatus) ProtoMessage()    {}
+func (*RuleStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{23}
+}
+
+func (m *RuleStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RuleStatus.Unmarshal(m, b)
+}
+func (m *RuleStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RuleStatus.Marshal(b, m, deterministic)
+}
+func (m *RuleStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RuleStatus.Merge(m, src)
+}
+func (m *RuleStatus) XXX_Size() int {
+	return xxx_messageInfo_RuleStatus.Size(m)
+}
+func (m *RuleStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_RuleStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RuleStatus proto.InternalMessageInfo
+
+func (m *RuleStatus) GetRuleId() int32 {
+	if m != nil {
+		return m.RuleId
+	}
+	return 0
+}
+
+func (m *RuleStatus) GetState() RuleWatcherState {
+	if m != nil {
+		return m.State
+	}
+	return RuleWatcherState_RULE_STOPPED
+}
+
+func (m *RuleStatus) GetLastError() string {
+	if m != nil {
+		return m.LastError
+	}
+	return ""
+}
+
+func (m *RuleStatus) GetLastErrorTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.LastErrorTime
+	}
+	return nil
+}
+
+func (m *RuleStatus) GetErrorCount() int64 {
+	if m != nil {
+		return m.ErrorCount
+	}
+	return 0
+}
+
+func (m *RuleStatus) GetTriggers() []*TriggerStatus {
+	if m != nil {
+		return m.Triggers
+	}
+	return nil
+}
+
+// TriggerStatus describes the state of a rule trigger in the automation engine, and its last error.
+// errorCount is the number of errors since the trigger rule was started.
+type TriggerStatus struct {
+	TriggerId     int32                `protobuf:"varint,1,opt,name=triggerId,proto3" json:"triggerId,omitempty"`
+	State         TriggerWatcherState  `protobuf:"varint,2,opt,name=state,proto3,enum=pb.TriggerWatcherState" json:"state,omitempty"`
+	LastError     string               `protobuf:"bytes,3,opt,name=lastError,proto3" json:"lastError,omitempty"`
+	LastErrorTime *timestamp.Timestamp `protobuf:"bytes,4,opt,name=lastErrorTime,proto3" json:"lastErrorTime,omitempty"`
+	ErrorCount    int64                `protobuf:"varint,5,opt,name=errorCount,proto3" json:"errorCount,omitempty"`
+	LastTriggered *timestamp.Timestamp `protobuf:"bytes,6,opt,name=lastTriggered,proto3" json:"lastTriggered,omitempty"`
+	// time the trigger will next fire, for scheduled triggers still having runs
+	NextFire *timestamp.Timestamp `protobuf:"bytes,7,opt,name=nextFire,proto3" json:"nextFire,omitempty"`
+	// number of events counted toward maxOccurrence, for EVENT triggers
+	Counter              int32    `protobuf:"varint,8,opt,name=counter,proto3" json:"counter,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TriggerStatus) Reset()         { *m = TriggerStatus{} }
+func (m *TriggerStatus) String() string { return proto.CompactTextString(m) }
+func (*TriggerStatus) ProtoMessage()    {}
+func (*TriggerStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{24}
+}
+
+func (m *TriggerStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TriggerStatus.Unmarshal(m, b)
+}
+func (m *TriggerStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TriggerStatus.Marshal(b, m, deterministic)
+}
+func (m *TriggerStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TriggerStatus.Merge(m, src)
+}
+func (m *TriggerStatus) XXX_Size() int {
+	return xxx_messageInfo_TriggerStatus.Size(m)
+}
+func (m *TriggerStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_TriggerStatus.DiscardUnknown(m)
 }
 
-func (m *DeleteRuleResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_DeleteRuleResponse.Unmarshal(m, b)
+var xxx_messageInfo_TriggerStatus proto.InternalMessageInfo
+
+func (m *TriggerStatus) GetTriggerId() int32 {
+	if m != nil {
+		return m.TriggerId
+	}
+	return 0
 }
-func (m *DeleteRuleResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_DeleteRuleResponse.Marshal(b, m, deterministic)
+
+func (m *TriggerStatus) GetState() TriggerWatcherState {
+	if m != nil {
+		return m.State
+	}
+	return TriggerWatcherState_TRIGGER_STOPPED
 }
-func (m *DeleteRuleResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DeleteRuleResponse.Merge(m, src)
+
+func (m *TriggerStatus) GetLastError() string {
+	if m != nil {
+		return m.LastError
+	}
+	return ""
 }
-func (m *DeleteRuleResponse) XXX_Size() int {
-	return xxx_messageInfo_DeleteRuleResponse.Size(m)
+
+func (m *TriggerStatus) GetLastErrorTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.LastErrorTime
+	}
+	return nil
 }
-func (m *DeleteRuleResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_DeleteRuleResponse.DiscardUnknown(m)
+
+func (m *TriggerStatus) GetErrorCount() int64 {
+	if m != nil {
+		return m.ErrorCount
+	}
+	return 0
 }
 
-var xxx_messageInfo_DeleteRuleResponse proto.InternalMessageInfo
+func (m *TriggerStatus) GetLastTriggered() *timestamp.Timestamp {
+	if m != nil {
+		return m.LastTriggered
+	}
+	return nil
+}
 
-func (m *DeleteRuleResponse) GetRuleId() int32 {
+func (m *TriggerStatus) GetNextFire() *timestamp.Timestamp {
 	if m != nil {
-		return m.RuleId
+		return m.NextFire
+	}
+	return nil
+}
+
+func (m *TriggerStatus) GetCounter() int32 {
+	if m != nil {
+		return m.Counter
+	}
+	return 0
+}
+
+type GetEngineStatusRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetEngineStatusRequest) Reset()         { *m = GetEngineStatusRequest{} }
+func (m *GetEngineStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*GetEngineStatusRequest) ProtoMessage()    {}
+func (*GetEngineStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{25}
+}
+
+func (m *GetEngineStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetEngineStatusRequest.Unmarshal(m, b)
+}
+func (m *GetEngineStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetEngineStatusRequest.Marshal(b, m, deterministic)
+}
+func (m *GetEngineStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetEngineStatusRequest.Merge(m, src)
+}
+func (m *GetEngineStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_GetEngineStatusRequest.Size(m)
+}
+func (m *GetEngineStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetEngineStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetEngineStatusRequest proto.InternalMessageInfo
+
+// EngineStatusResponse holds the status of every rule known to the engine, sorted by ID,
+// and the status of the C2 event stream. Rules having active watchers are RULE_RUNNING or RULE_ERRORING.
+type EngineStatusResponse struct {
+	Rules                []*RuleStatus      `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
+	EventStream          *EventStreamStatus `protobuf:"bytes,2,opt,name=eventStream,proto3" json:"eventStream,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *EngineStatusResponse) Reset()         { *m = EngineStatusResponse{} }
+func (m *EngineStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*EngineStatusResponse) ProtoMessage()    {}
+func (*EngineStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{26}
+}
+
+func (m *EngineStatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EngineStatusResponse.Unmarshal(m, b)
+}
+func (m *EngineStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EngineStatusResponse.Marshal(b, m, deterministic)
+}
+func (m *EngineStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EngineStatusResponse.Merge(m, src)
+}
+func (m *EngineStatusResponse) XXX_Size() int {
+	return xxx_messageInfo_EngineStatusResponse.Size(m)
+}
+func (m *EngineStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_EngineStatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EngineStatusResponse proto.InternalMessageInfo
+
+func (m *EngineStatusResponse) GetRules() []*RuleStatus {
+	if m != nil {
+		return m.Rules
+	}
+	return nil
+}
+
+func (m *EngineStatusResponse) GetEventStream() *EventStreamStatus {
+	if m != nil {
+		return m.EventStream
+	}
+	return nil
+}
+
+// EventStreamStatus describes the state of the C2 event stream
+type EventStreamStatus struct {
+	Connected bool `protobuf:"varint,1,opt,name=connected,proto3" json:"connected,omitempty"`
+	// time the stream last connected or disconnected
+	Since *timestamp.Timestamp `protobuf:"bytes,2,opt,name=since,proto3" json:"since,omitempty"`
+	// error which closed the stream, or prevented it to connect
+	LastError string `protobuf:"bytes,3,opt,name=lastError,proto3" json:"lastError,omitempty"`
+	// number of listeners receiving the streamed events
+	Listeners int32 `protobuf:"varint,4,opt,name=listeners,proto3" json:"listeners,omitempty"`
+	// last event received from the stream, and when
+	LastEvent            *ExecutionEvent      `protobuf:"bytes,5,opt,name=lastEvent,proto3" json:"lastEvent,omitempty"`
+	LastEventReceived    *timestamp.Timestamp `protobuf:"bytes,6,opt,name=lastEventReceived,proto3" json:"lastEventReceived,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *EventStreamStatus) Reset()         { *m = EventStreamStatus{} }
+func (m *EventStreamStatus) String() string { return proto.CompactTextString(m) }
+func (*EventStreamStatus) ProtoMessage()    {}
+func (*EventStreamStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{27}
+}
+
+func (m *EventStreamStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EventStreamStatus.Unmarshal(m, b)
+}
+func (m *EventStreamStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EventStreamStatus.Marshal(b, m, deterministic)
+}
+func (m *EventStreamStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EventStreamStatus.Merge(m, src)
+}
+func (m *EventStreamStatus) XXX_Size() int {
+	return xxx_messageInfo_EventStreamStatus.Size(m)
+}
+func (m *EventStreamStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_EventStreamStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EventStreamStatus proto.InternalMessageInfo
+
+func (m *EventStreamStatus) GetConnected() bool {
+	if m != nil {
+		return m.Connected
+	}
+	return false
+}
+
+func (m *EventStreamStatus) GetSince() *timestamp.Timestamp {
+	if m != nil {
+		return m.Since
+	}
+	return nil
+}
+
+func (m *EventStreamStatus) GetLastError() string {
+	if m != nil {
+		return m.LastError
+	}
+	return ""
+}
+
+func (m *EventStreamStatus) GetListeners() int32 {
+	if m != nil {
+		return m.Listeners
 	}
 	return 0
 }
 
+func (m *EventStreamStatus) GetLastEvent() *ExecutionEvent {
+	if m != nil {
+		return m.LastEvent
+	}
+	return nil
+}
+
+func (m *EventStreamStatus) GetLastEventReceived() *timestamp.Timestamp {
+	if m != nil {
+		return m.LastEventReceived
+	}
+	return nil
+}
+
 type HealthCheckRequest struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -673,7 +2064,7 @@ func (m *HealthCheckRequest) Reset()         { *m = HealthCheckRequest{} }
 func (m *HealthCheckRequest) String() string { return proto.CompactTextString(m) }
 func (*HealthCheckRequest) ProtoMessage()    {}
 func (*HealthCheckRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{11}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{28}
 }
 
 func (m *HealthCheckRequest) XXX_Unmarshal(b []byte) error {
@@ -706,7 +2097,7 @@ func (m *HealthCheckResponse) Reset()         { *m = HealthCheckResponse{} }
 func (m *HealthCheckResponse) String() string { return proto.CompactTextString(m) }
 func (*HealthCheckResponse) ProtoMessage()    {}
 func (*HealthCheckResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{12}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{29}
 }
 
 func (m *HealthCheckResponse) XXX_Unmarshal(b []byte) error {
@@ -743,9 +2134,14 @@ func (m *HealthCheckResponse) GetStatus() string {
 
 func init() {
 	proto.RegisterEnum("pb.ActionType", ActionType_name, ActionType_value)
+	proto.RegisterEnum("pb.ActionFailurePolicy", ActionFailurePolicy_name, ActionFailurePolicy_value)
 	proto.RegisterEnum("pb.TargetType", TargetType_name, TargetType_value)
+	proto.RegisterEnum("pb.TriggerMode", TriggerMode_name, TriggerMode_value)
 	proto.RegisterEnum("pb.TriggerType", TriggerType_name, TriggerType_value)
+	proto.RegisterEnum("pb.RuleWatcherState", RuleWatcherState_name, RuleWatcherState_value)
+	proto.RegisterEnum("pb.TriggerWatcherState", TriggerWatcherState_name, TriggerWatcherState_value)
 	proto.RegisterType((*Rule)(nil), "pb.Rule")
+	proto.RegisterType((*Action)(nil), "pb.Action")
 	proto.RegisterType((*Target)(nil), "pb.Target")
 	proto.RegisterType((*Trigger)(nil), "pb.Trigger")
 	proto.RegisterType((*RulesResponse)(nil), "pb.RulesResponse")
@@ -756,6 +2152,22 @@ func init() {
 	proto.RegisterType((*UpdateRuleRequest)(nil), "pb.UpdateRuleRequest")
 	proto.RegisterType((*DeleteRuleRequest)(nil), "pb.DeleteRuleRequest")
 	proto.RegisterType((*DeleteRuleResponse)(nil), "pb.DeleteRuleResponse")
+	proto.RegisterType((*PauseRuleRequest)(nil), "pb.PauseRuleRequest")
+	proto.RegisterType((*ResumeRuleRequest)(nil), "pb.ResumeRuleRequest")
+	proto.RegisterType((*ExecuteRuleRequest)(nil), "pb.ExecuteRuleRequest")
+	proto.RegisterType((*ListExecutionsRequest)(nil), "pb.ListExecutionsRequest")
+	proto.RegisterType((*ExecutionsResponse)(nil), "pb.ExecutionsResponse")
+	proto.RegisterType((*ExecutionResponse)(nil), "pb.ExecutionResponse")
+	proto.RegisterType((*Execution)(nil), "pb.Execution")
+	proto.RegisterType((*ExecutionEvent)(nil), "pb.ExecutionEvent")
+	proto.RegisterType((*ExecutionOutcome)(nil), "pb.ExecutionOutcome")
+	proto.RegisterType((*GetRuleStatusRequest)(nil), "pb.GetRuleStatusRequest")
+	proto.RegisterType((*RuleStatusResponse)(nil), "pb.RuleStatusResponse")
+	proto.RegisterType((*RuleStatus)(nil), "pb.RuleStatus")
+	proto.RegisterType((*TriggerStatus)(nil), "pb.TriggerStatus")
+	proto.RegisterType((*GetEngineStatusRequest)(nil), "pb.GetEngineStatusRequest")
+	proto.RegisterType((*EngineStatusResponse)(nil), "pb.EngineStatusResponse")
+	proto.RegisterType((*EventStreamStatus)(nil), "pb.EventStreamStatus")
 	proto.RegisterType((*HealthCheckRequest)(nil), "pb.HealthCheckRequest")
 	proto.RegisterType((*HealthCheckResponse)(nil), "pb.HealthCheckResponse")
 }
@@ -765,61 +2177,141 @@ func init() {
 }
 
 var fileDescriptor_00212fb1f9d3bf1c = []byte{
-	// 850 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x55, 0xdd, 0x6e, 0xe3, 0x44,
-	0x18, 0xad, 0xf3, 0x9f, 0x2f, 0x6d, 0xea, 0x7c, 0xb4, 0xbb, 0x91, 0xb5, 0x82, 0xc8, 0x20, 0x88,
-	0x42, 0x6b, 0xef, 0x06, 0x04, 0x55, 0x2f, 0x90, 0xdc, 0xd4, 0xdb, 0x46, 0x94, 0x74, 0x35, 0xb8,
-	0x2b, 0x2d, 0x37, 0x95, 0x9b, 0x0c, 0xa9, 0x21, 0xb5, 0x4d, 0x66, 0x22, 0x76, 0x85, 0xb8, 0xe1,
-	0x11, 0xe0, 0x29, 0x78, 0x0f, 0xde, 0x80, 0x07, 0xe0, 0x86, 0x7b, 0x5e, 0x01, 0xf9, 0xb3, 0x9d,
-	0x38, 0x35, 0x5d, 0xe5, 0x82, 0xab, 0x7a, 0xce, 0x77, 0x7c, 0x66, 0x8e, 0xe7, 0x9c, 0x06, 0xea,
-	0x6e, 0xe8, 0x19, 0xe1, 0x3c, 0x90, 0x01, 0x16, 0xc2, 0x1b, 0xed, 0xbd, 0x69, 0x10, 0x4c, 0x67,
-	0xdc, 0x24, 0xe4, 0x66, 0xf1, 0xad, 0x29, 0xbd, 0x3b, 0x2e, 0xa4, 0x7b, 0x17, 0xc6, 0x24, 0xed,
-	0x49, 0x42, 0x70, 0x43, 0xcf, 0x74, 0x7d, 0x3f, 0x90, 0xae, 0xf4, 0x02, 0x5f, 0x24, 0xd3, 0x03,
-	0xfa, 0x33, 0x3e, 0x9c, 0x72, 0xff, 0x50, 0xfc, 0xe8, 0x4e, 0xa7, 0x7c, 0x6e, 0x06, 0x21, 0x31,
-	0xf2, 0x6c, 0xfd, 0x1f, 0x05, 0x4a, 0x6c, 0x31, 0xe3, 0xd8, 0x84, 0x82, 0x37, 0x69, 0x2b, 0x1d,
-	0xa5, 0x5b, 0x66, 0x05, 0x6f, 0x82, 0x1d, 0x68, 0x4c, 0xb8, 0x18, 0xcf, 0x3d, 0x7a, 0xb5, 0x5d,
-	0xe8, 0x28, 0xdd, 0x3a, 0xcb, 0x42, 0xf8, 0x21, 0x54, 0xdc, 0x31, 0x0d, 0x8b, 0x1d, 0xa5, 0xdb,
-	0xec, 0x37, 0x8d, 0xf0, 0xc6, 0xb0, 0x08, 0x71, 0xde, 0x84, 0x9c, 0x25, 0x53, 0xfc, 0x02, 0xb6,
-	0x67, 0xae, 0x90, 0xf6, 0x6b, 0x3e, 0x5e, 0x48, 0x3e, 0x69, 0x97, 0x3a, 0x4a, 0xb7, 0xd1, 0xd7,
-	0x8c, 0xd8, 0x85, 0x91, 0xda, 0x34, 0x9c, 0xd4, 0x26, 0x5b, 0xe3, 0xe3, 0x47, 0x50, 0x93, 0x73,
-	0x2f, 0xf2, 0x21, 0xda, 0xe5, 0x4e, 0xb1, 0xdb, 0xe8, 0x37, 0xa2, 0x9d, 0x9c, 0x18, 0x63, 0xcb,
-	0x21, 0x7e, 0x00, 0x55, 0xe9, 0xce, 0xa7, 0x5c, 0x8a, 0x76, 0x85, 0x78, 0x40, 0x3c, 0x82, 0x58,
-	0x3a, 0xd2, 0x5f, 0x40, 0x25, 0x86, 0x72, 0x96, 0x75, 0x28, 0xc9, 0x37, 0x21, 0x27, 0xaf, 0x89,
-	0x9d, 0x98, 0x49, 0x76, 0x68, 0x86, 0x08, 0x25, 0xfe, 0x3a, 0x9c, 0x93, 0xe5, 0x3a, 0xa3, 0x67,
-	0xfd, 0x1b, 0xa8, 0x26, 0x87, 0xc9, 0x49, 0xbe, 0xbf, 0x26, 0xb9, 0x9b, 0x39, 0x77, 0x46, 0x53,
-	0x83, 0x9a, 0xe0, 0x52, 0x7a, 0xfe, 0x54, 0x90, 0xee, 0x36, 0x5b, 0xae, 0x75, 0x13, 0x76, 0xa2,
-	0xeb, 0x11, 0x8c, 0x8b, 0x30, 0xf0, 0x05, 0xc7, 0x77, 0xa1, 0x3c, 0x8f, 0x80, 0xb6, 0x42, 0x16,
-	0x6b, 0x91, 0x64, 0xc4, 0x60, 0x31, 0xac, 0x1f, 0xc0, 0x36, 0x2d, 0x53, 0xfe, 0x13, 0x28, 0x45,
-	0x03, 0x3a, 0x53, 0x96, 0x4e, 0xa8, 0x8e, 0xa0, 0x5e, 0x78, 0x42, 0x26, 0x5b, 0xfc, 0xb0, 0xe0,
-	0x42, 0xea, 0x5d, 0x68, 0x9e, 0x71, 0x19, 0x8b, 0x10, 0x82, 0x8f, 0xa0, 0x12, 0xb1, 0x87, 0xa9,
-	0xb3, 0x64, 0xa5, 0xff, 0xae, 0x40, 0xd3, 0x9a, 0x4c, 0xb2, 0xd4, 0x7b, 0xb1, 0x51, 0xde, 0x16,
-	0x9b, 0xc2, 0x5b, 0x63, 0x93, 0xbd, 0xf6, 0xe2, 0x86, 0xd7, 0x5e, 0x7a, 0xf8, 0xda, 0xff, 0x50,
-	0xa0, 0x75, 0x15, 0x4e, 0x5c, 0xc9, 0x37, 0x70, 0xf6, 0x3f, 0xa6, 0x3f, 0x6b, 0xa3, 0xb4, 0xa1,
-	0x8d, 0xf2, 0xc3, 0x36, 0x3e, 0x86, 0xd6, 0x29, 0x9f, 0xf1, 0x8d, 0x5c, 0xe8, 0x07, 0x80, 0x59,
-	0x72, 0x92, 0x88, 0x87, 0xd8, 0x7b, 0x80, 0xe7, 0xdc, 0x9d, 0xc9, 0xdb, 0xc1, 0x2d, 0x1f, 0x7f,
-	0x9f, 0xa6, 0xc1, 0x82, 0x77, 0xd6, 0xd0, 0x44, 0x04, 0xa1, 0x34, 0x08, 0x26, 0x71, 0xac, 0x8a,
-	0x8c, 0x9e, 0x23, 0xe1, 0xaf, 0xa5, 0x2b, 0x17, 0x22, 0xf9, 0x5e, 0xc9, 0xaa, 0xf7, 0x29, 0xc0,
-	0xea, 0xc3, 0xe0, 0x1e, 0xa8, 0x57, 0xa3, 0x53, 0xfb, 0xf9, 0x70, 0x64, 0x9f, 0x5e, 0x5b, 0x03,
-	0x67, 0x78, 0x39, 0x52, 0xb7, 0x50, 0x85, 0xed, 0x2f, 0xed, 0x57, 0xd7, 0xec, 0xd2, 0xb1, 0x08,
-	0x51, 0x7a, 0x07, 0x00, 0xab, 0xf6, 0x61, 0x15, 0x8a, 0xd6, 0xe8, 0x95, 0xba, 0x85, 0x75, 0x28,
-	0x3b, 0x97, 0x2f, 0x86, 0x03, 0x55, 0x41, 0x80, 0xca, 0xe0, 0x62, 0x68, 0x8f, 0x1c, 0xb5, 0xd0,
-	0x3b, 0x81, 0x46, 0xa6, 0x58, 0xb8, 0x0f, 0xad, 0xd5, 0x26, 0x0e, 0x1b, 0x9e, 0x9d, 0xd9, 0x4c,
-	0xdd, 0xc2, 0x16, 0xec, 0x38, 0xc3, 0xaf, 0xec, 0xeb, 0xe1, 0xc8, 0xb1, 0xd9, 0x4b, 0xeb, 0x42,
-	0x55, 0x22, 0x3d, 0xfb, 0x25, 0x69, 0xf4, 0xff, 0x2a, 0x02, 0x0e, 0xfa, 0xd6, 0x42, 0x06, 0x77,
-	0xf4, 0x3f, 0xd2, 0xf6, 0xa7, 0x9e, 0xcf, 0xf1, 0x14, 0xea, 0xcb, 0x8e, 0xe0, 0x5e, 0x74, 0x29,
-	0xf7, 0x2b, 0xa3, 0xb5, 0xd2, 0x5a, 0x2d, 0x7b, 0xaa, 0x37, 0x7f, 0xf9, 0xf3, 0xef, 0xdf, 0x0a,
-	0x35, 0xac, 0x98, 0xd4, 0x4b, 0x3c, 0x87, 0x6a, 0xd2, 0x2a, 0xc4, 0x88, 0xbd, 0x5e, 0x31, 0x4d,
-	0x5d, 0x16, 0x33, 0x15, 0x78, 0x4c, 0x02, 0x2d, 0xdc, 0x8d, 0x05, 0xcc, 0x9f, 0xe2, 0x6b, 0xfa,
-	0x19, 0x4f, 0xa0, 0x9a, 0x94, 0x2e, 0x56, 0x5a, 0x6f, 0xe0, 0x7f, 0x28, 0xb5, 0x48, 0xa9, 0xa1,
-	0x27, 0x47, 0x39, 0x56, 0x7a, 0x78, 0x0e, 0xb0, 0x2a, 0x03, 0xee, 0x47, 0xaf, 0xe4, 0xca, 0xf1,
-	0xb0, 0x92, 0x96, 0x51, 0x72, 0x00, 0x56, 0x19, 0x8b, 0x95, 0x72, 0x01, 0xd5, 0x1e, 0xdd, 0x87,
-	0xd7, 0x3d, 0xf6, 0x72, 0x1e, 0xaf, 0xa0, 0x91, 0x49, 0x1d, 0xd2, 0xfb, 0xf9, 0x70, 0x6a, 0x8f,
-	0x73, 0x78, 0x22, 0xbc, 0x4f, 0xc2, 0xbb, 0xb8, 0x63, 0xde, 0xd2, 0xf4, 0x70, 0x1c, 0x8d, 0x4f,
-	0x9e, 0xff, 0x6a, 0x0d, 0x10, 0xa0, 0x36, 0xee, 0xbb, 0xfc, 0xd0, 0x0d, 0x3d, 0xad, 0xf9, 0xac,
-	0xff, 0xb9, 0xf1, 0xd4, 0x78, 0x6a, 0x3c, 0x3b, 0x3e, 0x3a, 0x3a, 0xfa, 0xac, 0xa7, 0x14, 0xfa,
-	0xaa, 0x1b, 0x86, 0x33, 0x6f, 0x4c, 0x09, 0x30, 0xbf, 0x13, 0x81, 0x7f, 0x9c, 0x43, 0x6e, 0x2a,
-	0xf4, 0xa3, 0xf5, 0xc9, 0xbf, 0x01, 0x00, 0x00, 0xff, 0xff, 0xe4, 0x50, 0x53, 0x58, 0xba, 0x07,
-	0x00, 0x00,
+	// 2129 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x58, 0xcd, 0x6e, 0x1b, 0xc9,
+	0x11, 0xf6, 0x50, 0xfc, 0x2d, 0x8a, 0xe4, 0xb0, 0xad, 0x9f, 0x59, 0xc6, 0x70, 0x98, 0x89, 0xe1,
+	0x10, 0xb4, 0x45, 0xda, 0x0c, 0xd6, 0x6b, 0x18, 0x8b, 0xc5, 0xd2, 0xd4, 0x58, 0x22, 0x4c, 0x93,
+	0x42, 0x93, 0xf2, 0xae, 0xf7, 0xa2, 0x8c, 0xc8, 0x0e, 0x35, 0x1b, 0x6a, 0x66, 0x32, 0x33, 0x5c,
+	0xdb, 0x08, 0x92, 0x43, 0x1e, 0x20, 0x08, 0x92, 0x4b, 0x80, 0x3c, 0x42, 0x5e, 0x26, 0x40, 0x72,
+	0xce, 0x29, 0x0f, 0x90, 0x5b, 0x8e, 0x09, 0xfa, 0x67, 0x7e, 0x49, 0x49, 0xb4, 0x37, 0x87, 0x9c,
+	0xc8, 0xfe, 0xaa, 0xba, 0xaa, 0xbb, 0xba, 0xfa, 0xab, 0xae, 0x81, 0x82, 0x6e, 0x1b, 0x2d, 0xdb,
+	0xb1, 0x3c, 0x0b, 0xa5, 0xec, 0xf3, 0xda, 0x0f, 0xe7, 0x96, 0x35, 0x5f, 0x90, 0x36, 0x43, 0xce,
+	0x97, 0x3f, 0x6f, 0x7b, 0xc6, 0x25, 0x71, 0x3d, 0xfd, 0xd2, 0xe6, 0x4a, 0xb5, 0xbb, 0x49, 0x85,
+	0xd9, 0xd2, 0xd1, 0x3d, 0xc3, 0x32, 0x85, 0xfc, 0x8e, 0x90, 0xeb, 0xb6, 0xd1, 0xd6, 0x4d, 0xd3,
+	0xf2, 0x98, 0xd0, 0x15, 0xd2, 0x87, 0xec, 0x67, 0x7a, 0x30, 0x27, 0xe6, 0x81, 0xfb, 0x56, 0x9f,
+	0xcf, 0x89, 0xd3, 0xb6, 0x6c, 0xa6, 0xb1, 0xaa, 0xad, 0xfe, 0x23, 0x03, 0x69, 0xbc, 0x5c, 0x10,
+	0x54, 0x86, 0x94, 0x31, 0x53, 0xa4, 0xba, 0xd4, 0xc8, 0xe0, 0x94, 0x31, 0x43, 0x75, 0x28, 0xce,
+	0x88, 0x3b, 0x75, 0x0c, 0x36, 0x55, 0x49, 0xd5, 0xa5, 0x46, 0x01, 0x47, 0x21, 0x74, 0x1f, 0xb2,
+	0xfa, 0x94, 0x09, 0xb7, 0xea, 0x52, 0xa3, 0xdc, 0x29, 0xb7, 0xec, 0xf3, 0x56, 0x97, 0x21, 0x93,
+	0xf7, 0x36, 0xc1, 0x42, 0x8a, 0xbe, 0x80, 0xed, 0x85, 0xee, 0x7a, 0xda, 0x3b, 0x32, 0x5d, 0x7a,
+	0x64, 0xa6, 0xa4, 0xeb, 0x52, 0xa3, 0xd8, 0xa9, 0xb5, 0xf8, 0x2e, 0x5a, 0xfe, 0x2e, 0x5b, 0x13,
+	0x3f, 0x0c, 0x38, 0xa6, 0x8f, 0x7e, 0x02, 0x79, 0xcf, 0x31, 0xe8, 0x3e, 0x5c, 0x25, 0x53, 0xdf,
+	0x6a, 0x14, 0x3b, 0x45, 0xea, 0x69, 0xc2, 0x31, 0x1c, 0x08, 0xd1, 0x3d, 0xc8, 0x79, 0xba, 0x33,
+	0x27, 0x9e, 0xab, 0x64, 0x99, 0x1e, 0x30, 0x3d, 0x06, 0x61, 0x5f, 0x84, 0xee, 0x43, 0x99, 0x2f,
+	0x6c, 0x4c, 0x3c, 0xcf, 0x30, 0xe7, 0xae, 0x92, 0xab, 0x4b, 0x8d, 0x6d, 0x9c, 0x40, 0xa9, 0x35,
+	0x8e, 0xb8, 0x4a, 0x3e, 0xb4, 0xc6, 0xf7, 0x87, 0x7d, 0x11, 0x52, 0x20, 0x47, 0x4c, 0xfd, 0x7c,
+	0x41, 0x66, 0x4a, 0xa1, 0x2e, 0x35, 0xf2, 0xd8, 0x1f, 0xa2, 0x3d, 0xc8, 0xce, 0x9c, 0xf7, 0x78,
+	0x69, 0x2a, 0xc0, 0x04, 0x62, 0x84, 0x1e, 0x43, 0x51, 0xac, 0xf8, 0x95, 0x35, 0x23, 0x4a, 0x91,
+	0xc5, 0xae, 0x12, 0xd9, 0x11, 0x85, 0x71, 0x54, 0x07, 0x35, 0x41, 0x16, 0xc3, 0xc9, 0x85, 0x43,
+	0xdc, 0x0b, 0x6b, 0x31, 0x53, 0xb6, 0xd9, 0x49, 0xad, 0xe0, 0xe8, 0x53, 0xc8, 0x4f, 0x2d, 0x6b,
+	0x31, 0xb3, 0xde, 0x9a, 0x4a, 0x89, 0x45, 0xfa, 0x93, 0x95, 0x48, 0x1f, 0x8a, 0x7c, 0xc2, 0x81,
+	0x2a, 0xba, 0x07, 0xa5, 0x4b, 0xfd, 0x1d, 0x8f, 0x39, 0xdb, 0x73, 0x99, 0xd9, 0x8f, 0x83, 0xa8,
+	0x07, 0x15, 0xe2, 0x8f, 0x4e, 0x88, 0x63, 0x58, 0x33, 0xa5, 0x72, 0x93, 0x8f, 0xe4, 0x0c, 0xd4,
+	0x80, 0x8a, 0xbb, 0xb4, 0x6d, 0x87, 0xb8, 0x2e, 0x99, 0xf5, 0xac, 0xa5, 0xe9, 0x29, 0x72, 0x5d,
+	0x6a, 0x6c, 0xe1, 0x24, 0x8c, 0x9e, 0x43, 0x99, 0x66, 0xc2, 0x38, 0x80, 0x95, 0xea, 0x8d, 0xb9,
+	0x93, 0x98, 0xa1, 0xfe, 0x5b, 0x82, 0x2c, 0x3f, 0xb4, 0x95, 0x14, 0x57, 0x21, 0xed, 0xbd, 0xb7,
+	0x89, 0x92, 0x5a, 0x9b, 0xbe, 0x4c, 0x86, 0x6a, 0x90, 0x77, 0xfd, 0x3c, 0xd9, 0x62, 0x79, 0x12,
+	0x8c, 0xd1, 0xa7, 0x50, 0xb0, 0xcc, 0x17, 0xba, 0xb1, 0x58, 0x3a, 0x84, 0x65, 0x75, 0xb9, 0xb3,
+	0x1f, 0x1a, 0x11, 0x82, 0x13, 0x6b, 0x61, 0x4c, 0xdf, 0xe3, 0x50, 0x13, 0x3d, 0x81, 0xf2, 0xd4,
+	0xba, 0xb4, 0x89, 0xe9, 0xea, 0x1e, 0xa1, 0xae, 0x94, 0xcc, 0xda, 0x05, 0x24, 0xb4, 0x50, 0x0b,
+	0x50, 0x88, 0x04, 0xc9, 0x9b, 0x65, 0x8b, 0x5a, 0x23, 0x51, 0x4f, 0x20, 0xcb, 0x73, 0x7f, 0x93,
+	0x8d, 0x73, 0xcd, 0xc8, 0xc6, 0x11, 0xa4, 0xc9, 0x3b, 0xdb, 0x61, 0x9b, 0x2e, 0x60, 0xf6, 0x5f,
+	0xfd, 0x06, 0x72, 0x22, 0x47, 0x57, 0x4c, 0xfe, 0x38, 0x66, 0x32, 0x9a, 0xce, 0x9b, 0x05, 0x53,
+	0x6d, 0x43, 0x89, 0xf2, 0x90, 0x8b, 0x89, 0x6b, 0x5b, 0xa6, 0x4b, 0xd0, 0x5d, 0xc8, 0x38, 0x14,
+	0x50, 0x24, 0x76, 0xfb, 0xf2, 0xd4, 0x24, 0xd5, 0xc0, 0x1c, 0x56, 0x1f, 0xc2, 0x36, 0x1b, 0xfa,
+	0xfa, 0x77, 0x20, 0x4d, 0x05, 0x6c, 0x4d, 0x51, 0x75, 0x86, 0xaa, 0x08, 0xe4, 0x81, 0xe1, 0x7a,
+	0xc2, 0xc5, 0x2f, 0x97, 0xc4, 0xf5, 0xd4, 0x06, 0x94, 0x8f, 0x88, 0xc7, 0x8d, 0x30, 0x84, 0xde,
+	0x59, 0xaa, 0xdd, 0xf7, 0x77, 0x26, 0x46, 0xea, 0xef, 0xd3, 0x50, 0xee, 0xce, 0x66, 0x51, 0xd5,
+	0x04, 0x3f, 0x4a, 0xd7, 0xf1, 0x63, 0xea, 0x5a, 0x7e, 0x8c, 0xf2, 0xdb, 0xd6, 0x86, 0xfc, 0x96,
+	0xfe, 0x10, 0x7e, 0xcb, 0xdc, 0xc4, 0x6f, 0xd9, 0xab, 0xf9, 0x2d, 0x64, 0xb1, 0xdc, 0x75, 0x2c,
+	0x96, 0xff, 0x48, 0x16, 0x2b, 0x6c, 0xc0, 0x62, 0xf0, 0x3d, 0x58, 0xac, 0xb8, 0x21, 0x8b, 0x6d,
+	0x7f, 0x28, 0x8b, 0xa9, 0x7f, 0x49, 0x43, 0xf5, 0xd4, 0x9e, 0xe9, 0x1e, 0xd9, 0x20, 0x81, 0xfe,
+	0x87, 0xd5, 0x34, 0x9a, 0x2d, 0xe9, 0x0d, 0xb3, 0x25, 0xf3, 0x21, 0xd9, 0x92, 0xbd, 0x29, 0x5b,
+	0x72, 0x9b, 0x64, 0x4b, 0xfe, 0xba, 0x6c, 0x29, 0x7c, 0x64, 0xb6, 0xc0, 0x06, 0xd9, 0x52, 0xfc,
+	0x1e, 0xd9, 0xb2, 0xbd, 0x61, 0xb6, 0x94, 0x3e, 0x38, 0x5b, 0x1e, 0x40, 0xf5, 0x90, 0x2c, 0xc8,
+	0x46, 0xc9, 0xa2, 0x3e, 0x04, 0x14, 0x55, 0x16, 0xfc, 0x76, 0x95, 0x76, 0x13, 0xe4, 0x13, 0x7d,
+	0xe9, 0x6e, 0x64, 0xf9, 0x01, 0x54, 0x31, 0x71, 0x97, 0x97, 0x1b, 0x29, 0x1f, 0x02, 0x12, 0x6f,
+	0xb0, 0x4d, 0x32, 0x3c, 0x3c, 0xfa, 0x54, 0xf4, 0xe8, 0xd5, 0xb7, 0xb0, 0x4b, 0x89, 0x37, 0x0c,
+	0xe8, 0x4d, 0x86, 0x1e, 0x41, 0xc6, 0x35, 0xcc, 0x29, 0x2f, 0x25, 0xd7, 0xd7, 0x7a, 0xae, 0x88,
+	0x76, 0x20, 0xb3, 0x30, 0x2e, 0x0d, 0x8f, 0xdd, 0x9c, 0x0c, 0xe6, 0x03, 0xb5, 0x07, 0x28, 0xea,
+	0x54, 0x44, 0xf1, 0x00, 0x80, 0x84, 0x07, 0xce, 0x4b, 0x4b, 0x89, 0x26, 0x62, 0xa0, 0x8b, 0x23,
+	0x0a, 0xea, 0x97, 0x50, 0x0d, 0x05, 0xbe, 0x8d, 0x07, 0x50, 0x08, 0x54, 0x44, 0xb9, 0x49, 0x98,
+	0x08, 0xe5, 0xea, 0x5f, 0x53, 0x50, 0x08, 0x04, 0x2b, 0x65, 0x33, 0x0c, 0x42, 0x2a, 0x16, 0x84,
+	0x3b, 0x50, 0x10, 0x59, 0xde, 0x9f, 0x89, 0x6d, 0x85, 0x00, 0xfa, 0x3c, 0xb8, 0x4e, 0x64, 0xd6,
+	0xf5, 0x36, 0x78, 0x50, 0x47, 0xd5, 0x51, 0x03, 0x32, 0xe4, 0x3b, 0x62, 0x7a, 0xac, 0x2e, 0x14,
+	0x3b, 0x28, 0xb6, 0x74, 0x8d, 0x4a, 0x30, 0x57, 0x40, 0x8f, 0x20, 0x6f, 0x2d, 0xbd, 0xa9, 0x75,
+	0x49, 0xfc, 0x1a, 0xb1, 0x13, 0x53, 0x1e, 0x71, 0x21, 0x0e, 0xb4, 0xe8, 0x4d, 0xf4, 0x9b, 0x15,
+	0x25, 0x77, 0xd3, 0x2d, 0x09, 0x54, 0xe9, 0x09, 0x12, 0xc7, 0xb1, 0x1c, 0x46, 0x1b, 0x05, 0xcc,
+	0x07, 0x91, 0x94, 0x2a, 0xc4, 0x52, 0xea, 0x77, 0x12, 0x94, 0xe3, 0x0b, 0xa6, 0xaf, 0x15, 0xf6,
+	0xfc, 0xe0, 0x65, 0x98, 0xfd, 0xa7, 0xd3, 0x5d, 0x6b, 0xe9, 0x88, 0x4c, 0x2a, 0x60, 0x31, 0xa2,
+	0x38, 0x67, 0x3f, 0xf1, 0xb6, 0x11, 0x23, 0xf4, 0x14, 0x0a, 0x41, 0x27, 0xb6, 0x41, 0x4c, 0x43,
+	0x65, 0xf5, 0xef, 0x12, 0xc8, 0xc9, 0xa0, 0x44, 0x08, 0x5d, 0xba, 0x96, 0xd0, 0x5b, 0x00, 0x5e,
+	0xf0, 0xf8, 0xba, 0xe2, 0x49, 0x16, 0xd1, 0xb8, 0x72, 0xf9, 0x41, 0x0c, 0xd3, 0xd1, 0x18, 0xd2,
+	0xc2, 0xc3, 0xa2, 0xd6, 0xd3, 0x17, 0x0b, 0xfe, 0x14, 0x28, 0xe0, 0x28, 0x44, 0x3b, 0x18, 0x9b,
+	0x98, 0x33, 0xc3, 0x9c, 0x33, 0xea, 0xcf, 0x63, 0x7f, 0xa8, 0xb6, 0x60, 0x47, 0xbc, 0x8f, 0xc6,
+	0x9e, 0xee, 0x2d, 0x6f, 0xba, 0xb9, 0xea, 0xe7, 0x80, 0xa2, 0xca, 0xe2, 0xb6, 0xdc, 0x87, 0xac,
+	0xcb, 0x10, 0x71, 0x55, 0xca, 0xfe, 0xcb, 0x4c, 0xe8, 0x09, 0xa9, 0xfa, 0x1f, 0x09, 0x20, 0x84,
+	0xaf, 0xa4, 0x87, 0x26, 0x64, 0xe8, 0x04, 0x3f, 0x52, 0x3b, 0xbe, 0xb5, 0xaf, 0x74, 0x6f, 0x7a,
+	0x41, 0x1c, 0x3a, 0x9b, 0x60, 0xae, 0x42, 0x6f, 0x11, 0xeb, 0x24, 0x59, 0x58, 0x78, 0xb4, 0x42,
+	0x00, 0x7d, 0x09, 0xa5, 0x60, 0x40, 0x8f, 0x75, 0x83, 0x33, 0x8f, 0x4f, 0x40, 0x77, 0x01, 0x58,
+	0x94, 0x79, 0x13, 0x93, 0x61, 0x4d, 0x4c, 0x04, 0x41, 0x07, 0x91, 0x5a, 0xcd, 0xef, 0x4f, 0x35,
+	0x52, 0xf3, 0xc4, 0xfe, 0x03, 0x15, 0xf5, 0x5f, 0x29, 0x28, 0xc5, 0x64, 0x71, 0x1a, 0x90, 0x92,
+	0x34, 0x70, 0x10, 0x0f, 0xc5, 0x7e, 0xc4, 0xf6, 0xff, 0x63, 0x34, 0x84, 0x87, 0x89, 0x4f, 0x45,
+	0x4a, 0x76, 0x33, 0x0f, 0xc1, 0x04, 0xf4, 0x04, 0xf2, 0x26, 0x79, 0xe7, 0xbd, 0x30, 0x1c, 0xa2,
+	0xe4, 0x6e, 0x9c, 0x1c, 0xe8, 0xd2, 0x14, 0x9f, 0xd2, 0x25, 0x10, 0x4e, 0x30, 0x19, 0xec, 0x0f,
+	0x55, 0x05, 0xf6, 0x8e, 0x88, 0xa7, 0x99, 0x73, 0xc3, 0x8c, 0x27, 0xb9, 0xba, 0x84, 0x9d, 0x38,
+	0x2c, 0xd2, 0xf9, 0x5e, 0xbc, 0x2d, 0x49, 0x66, 0x33, 0x17, 0xa2, 0xcf, 0xa0, 0xc8, 0x28, 0x74,
+	0xec, 0x39, 0x44, 0xbf, 0x14, 0xa5, 0x6c, 0x97, 0x91, 0x67, 0x08, 0x8b, 0x29, 0x51, 0x4d, 0xf5,
+	0xcf, 0x29, 0xa8, 0xae, 0xa8, 0xd0, 0xa3, 0x9b, 0x5a, 0xa6, 0x49, 0xa6, 0x1e, 0xe1, 0x79, 0x90,
+	0xc7, 0x21, 0xf0, 0x11, 0x15, 0xf3, 0xfa, 0x54, 0xa0, 0x52, 0xc3, 0xf5, 0x88, 0xc9, 0xdf, 0x98,
+	0x2c, 0xeb, 0x02, 0x00, 0x3d, 0x12, 0x73, 0x6f, 0x28, 0x21, 0xa1, 0x12, 0x3a, 0x86, 0x6a, 0x30,
+	0xc0, 0x64, 0x4a, 0x8c, 0xef, 0x36, 0x3a, 0xfc, 0xd5, 0x49, 0xea, 0x0e, 0xa0, 0x63, 0xa2, 0x2f,
+	0xbc, 0x8b, 0xde, 0x05, 0x99, 0xfe, 0xc2, 0x3f, 0xaa, 0x2e, 0xdc, 0x8e, 0xa1, 0xe2, 0xa4, 0x10,
+	0xa4, 0x7b, 0xf4, 0xb5, 0x29, 0xb1, 0x4c, 0x64, 0xff, 0x29, 0xab, 0xf0, 0x90, 0xfa, 0x35, 0x81,
+	0x8f, 0x9a, 0xbf, 0x01, 0x08, 0xa9, 0x19, 0xed, 0x80, 0x7c, 0x3a, 0x3c, 0xd4, 0x5e, 0xf4, 0x87,
+	0xda, 0xe1, 0x59, 0xb7, 0x37, 0xe9, 0x8f, 0x86, 0xf2, 0x2d, 0x24, 0xc3, 0xf6, 0x4b, 0xed, 0xcd,
+	0x19, 0x1e, 0x4d, 0xba, 0x0c, 0x91, 0x50, 0x15, 0x4a, 0x58, 0x7b, 0x35, 0x7a, 0xad, 0x9d, 0xf5,
+	0x06, 0x7d, 0x6d, 0x38, 0x91, 0x53, 0x68, 0x1f, 0x6e, 0x9f, 0x0e, 0x07, 0xfd, 0xe1, 0x4b, 0x01,
+	0x9d, 0x4d, 0x46, 0x27, 0xfd, 0x9e, 0xbc, 0x85, 0x2a, 0x50, 0xc4, 0xda, 0x58, 0xf3, 0x81, 0x34,
+	0x2a, 0x42, 0xee, 0x2b, 0xed, 0xf9, 0xf1, 0x68, 0xf4, 0x52, 0xce, 0x34, 0xbf, 0x80, 0xdb, 0x6b,
+	0xbe, 0x1a, 0xa0, 0x02, 0x64, 0xba, 0xcf, 0x47, 0x78, 0x22, 0xdf, 0x42, 0xdb, 0x90, 0xef, 0x8d,
+	0x86, 0x93, 0xfe, 0xf0, 0x54, 0x93, 0x25, 0x54, 0x06, 0xe8, 0x8d, 0x5e, 0x9d, 0x68, 0xc3, 0x71,
+	0x77, 0xa2, 0xc9, 0xa9, 0xe6, 0x43, 0x80, 0xb0, 0x5c, 0xa0, 0x1c, 0x6c, 0x75, 0x87, 0x6f, 0xe4,
+	0x5b, 0x74, 0x3e, 0x77, 0x27, 0x21, 0x80, 0xac, 0xbf, 0xc8, 0xe6, 0x31, 0x14, 0x23, 0xef, 0x6e,
+	0xba, 0xb4, 0xee, 0xf0, 0xcd, 0xd9, 0x04, 0xf7, 0x8f, 0x8e, 0x34, 0xcc, 0x77, 0xda, 0x1d, 0x0c,
+	0x7c, 0x60, 0x2c, 0x4b, 0x68, 0x0f, 0xd0, 0xe4, 0x18, 0x6b, 0xe3, 0xe3, 0xd1, 0xe0, 0x30, 0xc4,
+	0x53, 0xcd, 0x41, 0x60, 0x89, 0x39, 0xde, 0x85, 0x6a, 0x18, 0xb8, 0xd0, 0x5e, 0x15, 0x4a, 0x93,
+	0xfe, 0x2b, 0xed, 0xac, 0x3f, 0x9c, 0x68, 0xf8, 0x75, 0x77, 0x20, 0x4b, 0x74, 0x65, 0xda, 0x6b,
+	0x1e, 0xb2, 0x3c, 0xa4, 0x47, 0xc3, 0x9e, 0x26, 0x6f, 0x35, 0xbf, 0x01, 0x39, 0x49, 0xe5, 0x74,
+	0x2d, 0xf8, 0x74, 0xa0, 0x9d, 0x8d, 0x27, 0xa3, 0x93, 0x13, 0xed, 0x50, 0xbe, 0x15, 0x20, 0xf8,
+	0x74, 0x38, 0xec, 0x0f, 0x8f, 0x64, 0x89, 0xc5, 0x96, 0x22, 0x27, 0xdd, 0xd3, 0xb1, 0x76, 0x28,
+	0xa7, 0xd8, 0xc1, 0x50, 0x40, 0xc3, 0x78, 0x84, 0xa9, 0xce, 0x56, 0xf3, 0x5b, 0xb8, 0xbd, 0x86,
+	0x1b, 0xd1, 0x6d, 0xa8, 0x88, 0x75, 0x46, 0x3c, 0x44, 0xc0, 0xd0, 0x49, 0x04, 0xec, 0x0f, 0x5f,
+	0x77, 0x07, 0x7d, 0xea, 0x68, 0x07, 0x64, 0x1f, 0x0c, 0x7d, 0x75, 0xfe, 0x94, 0x07, 0xd4, 0xeb,
+	0x74, 0x97, 0x9e, 0x75, 0xc9, 0xde, 0x37, 0x9c, 0x48, 0xd0, 0x21, 0x14, 0x82, 0x6f, 0x10, 0x88,
+	0x15, 0xae, 0xe4, 0x27, 0x89, 0x5a, 0xd5, 0xa7, 0x93, 0x80, 0x70, 0xd4, 0xf2, 0x6f, 0xff, 0xf6,
+	0xcf, 0x3f, 0xa6, 0xf2, 0x28, 0xdb, 0xe6, 0xd4, 0x72, 0x0c, 0x39, 0x51, 0x95, 0x11, 0xbb, 0x77,
+	0xf1, 0x4f, 0x18, 0x35, 0xd9, 0xb7, 0x10, 0x18, 0xd8, 0x67, 0x06, 0xaa, 0xa8, 0xc2, 0x0d, 0xb4,
+	0x7f, 0xc5, 0x2b, 0xe9, 0xaf, 0xd1, 0x73, 0xc8, 0x89, 0x8f, 0x1a, 0xdc, 0x52, 0xfc, 0x0b, 0xc7,
+	0x1a, 0x4b, 0x55, 0x66, 0xa9, 0xa8, 0x8a, 0xa5, 0x3c, 0x93, 0x9a, 0xe8, 0x18, 0x20, 0xec, 0x82,
+	0x11, 0x63, 0xb8, 0x95, 0xae, 0xf8, 0x6a, 0x4b, 0xb5, 0x88, 0xa5, 0x09, 0x40, 0xd8, 0xf5, 0x70,
+	0x4b, 0x2b, 0x2d, 0x53, 0x6d, 0x2f, 0x09, 0xc7, 0xf7, 0xd8, 0x5c, 0xd9, 0xe3, 0x29, 0x14, 0x82,
+	0xee, 0x88, 0xc7, 0x3c, 0xd9, 0x2c, 0xad, 0x59, 0x5d, 0x9d, 0x59, 0xab, 0xa9, 0xbb, 0x09, 0x6b,
+	0x6d, 0x9b, 0xce, 0xa5, 0x8b, 0xfd, 0x1a, 0x20, 0x6c, 0xa4, 0xf8, 0x62, 0x57, 0x1a, 0xab, 0x35,
+	0x86, 0x7f, 0xc4, 0x0c, 0xff, 0x40, 0xdd, 0x4b, 0x1a, 0x76, 0xd8, 0x64, 0x6a, 0xf9, 0x67, 0x50,
+	0x8c, 0x74, 0x5d, 0x68, 0x2f, 0xa4, 0xd6, 0x98, 0xed, 0xdd, 0x78, 0xc3, 0xe1, 0x3b, 0x50, 0x99,
+	0x83, 0x3b, 0xea, 0x7e, 0xd2, 0x01, 0x6f, 0x48, 0x98, 0x87, 0x39, 0x94, 0xe3, 0x1d, 0x19, 0xfa,
+	0xc4, 0xcf, 0xc5, 0x95, 0x2e, 0xad, 0xb6, 0x17, 0xf3, 0xe3, 0x26, 0x1d, 0xa1, 0xda, 0x7a, 0x47,
+	0xcc, 0xac, 0x0e, 0xa5, 0xd8, 0xfb, 0x11, 0x29, 0x91, 0x7c, 0x8d, 0x55, 0x5b, 0xee, 0x66, 0xf5,
+	0xf1, 0xa8, 0xde, 0x65, 0x6e, 0x14, 0xb4, 0x12, 0x30, 0xfe, 0x68, 0x44, 0x5f, 0x43, 0x25, 0x51,
+	0xbf, 0x51, 0x4d, 0x38, 0x59, 0x53, 0xd4, 0x6b, 0x6c, 0x01, 0xeb, 0xca, 0xba, 0x5a, 0x61, 0x8e,
+	0x0a, 0x28, 0xe7, 0x5b, 0x3e, 0x85, 0x62, 0xa4, 0xa8, 0xf0, 0x73, 0x58, 0xad, 0x3d, 0xb5, 0xfd,
+	0x15, 0x5c, 0x18, 0xdc, 0x65, 0x06, 0x2b, 0xa8, 0xd4, 0xbe, 0x60, 0xd2, 0x83, 0x29, 0x15, 0x3f,
+	0x7f, 0xf1, 0x87, 0x6e, 0xaf, 0x56, 0x7e, 0xdc, 0xf9, 0xac, 0xf5, 0xa8, 0xf5, 0xa8, 0xf5, 0xf8,
+	0xd9, 0xd3, 0xa7, 0x4f, 0x9f, 0x20, 0x80, 0xfc, 0xb4, 0xa3, 0x93, 0x03, 0xdd, 0x36, 0x9a, 0x52,
+	0xaa, 0x23, 0xeb, 0xb6, 0xbd, 0x30, 0xa6, 0x8c, 0x3a, 0xda, 0xdf, 0xba, 0x96, 0xf9, 0x6c, 0x05,
+	0x39, 0xcf, 0xb2, 0x82, 0xf9, 0xd3, 0xff, 0x0e, 0x00, 0xb2, 0x56, 0x2d, 0x20, 0x5c, 0x1a, 0x00,
+	0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -844,6 +2336,18 @@ type C2AutomationEngineClient interface {
 	UpdateRule(ctx context.Context, in *UpdateRuleRequest, opts ...grpc.CallOption) (*RuleResponse, error)
 	// Remove a rule
 	DeleteRule(ctx context.Context, in *DeleteRuleRequest, opts ...grpc.CallOption) (*DeleteRuleResponse, error)
+	// Pause a rule, its triggers stop being watched until it is resumed
+	PauseRule(ctx context.Context, in *PauseRuleRequest, opts ...grpc.CallOption) (*RuleResponse, error)
+	// Resume a paused rule
+	ResumeRule(ctx context.Context, in *ResumeRuleRequest, opts ...grpc.CallOption) (*RuleResponse, error)
+	// Execute a rule action immediately, on the rule targets
+	ExecuteRule(ctx context.Context, in *ExecuteRuleRequest, opts ...grpc.CallOption) (*ExecutionResponse, error)
+	// Retrieve the most recent executions of a rule
+	ListExecutions(ctx context.Context, in *ListExecutionsRequest, opts ...grpc.CallOption) (*ExecutionsResponse, error)
+	// Retrieve the state of a rule and its triggers in the automation engine
+	GetRuleStatus(ctx context.Context, in *GetRuleStatusRequest, opts ...grpc.CallOption) (*RuleStatusResponse, error)
+	// Retrieve the state of the automation engine: its rules and triggers, and the C2 event stream
+	GetEngineStatus(ctx context.Context, in *GetEngineStatusRequest, opts ...grpc.CallOption) (*EngineStatusResponse, error)
 	HealthCheck(ctx context.Context, in *HealthCheckRequest, opts ...grpc.CallOption) (*HealthCheckResponse, error)
 }
 
@@ -900,6 +2404,60 @@ func (c *c2AutomationEngineClient) DeleteRule(ctx context.Context, in *DeleteRul
 	return out, nil
 }
 
+func (c *c2AutomationEngineClient) PauseRule(ctx context.Context, in *PauseRuleRequest, opts ...grpc.CallOption) (*RuleResponse, error) {
+	out := new(RuleResponse)
+	err := c.cc.Invoke(ctx, "/pb.C2AutomationEngine/PauseRule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *c2AutomationEngineClient) ResumeRule(ctx context.Context, in *ResumeRuleRequest, opts ...grpc.CallOption) (*RuleResponse, error) {
+	out := new(RuleResponse)
+	err := c.cc.Invoke(ctx, "/pb.C2AutomationEngine/ResumeRule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *c2AutomationEngineClient) ExecuteRule(ctx context.Context, in *ExecuteRuleRequest, opts ...grpc.CallOption) (*ExecutionResponse, error) {
+	out := new(ExecutionResponse)
+	err := c.cc.Invoke(ctx, "/pb.C2AutomationEngine/ExecuteRule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *c2AutomationEngineClient) ListExecutions(ctx context.Context, in *ListExecutionsRequest, opts ...grpc.CallOption) (*ExecutionsResponse, error) {
+	out := new(ExecutionsResponse)
+	err := c.cc.Invoke(ctx, "/pb.C2AutomationEngine/ListExecutions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *c2AutomationEngineClient) GetRuleStatus(ctx context.Context, in *GetRuleStatusRequest, opts ...grpc.CallOption) (*RuleStatusResponse, error) {
+	out := new(RuleStatusResponse)
+	err := c.cc.Invoke(ctx, "/pb.C2AutomationEngine/GetRuleStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *c2AutomationEngineClient) GetEngineStatus(ctx context.Context, in *GetEngineStatusRequest, opts ...grpc.CallOption) (*EngineStatusResponse, error) {
+	out := new(EngineStatusResponse)
+	err := c.cc.Invoke(ctx, "/pb.C2AutomationEngine/GetEngineStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *c2AutomationEngineClient) HealthCheck(ctx context.Context, in *HealthCheckRequest, opts ...grpc.CallOption) (*HealthCheckResponse, error) {
 	out := new(HealthCheckResponse)
 	err := c.cc.Invoke(ctx, "/pb.C2AutomationEngine/HealthCheck", in, out, opts...)
@@ -921,6 +2479,18 @@ type C2AutomationEngineServer interface {
 	UpdateRule(context.Context, *UpdateRuleRequest) (*RuleResponse, error)
 	// Remove a rule
 	DeleteRule(context.Context, *DeleteRuleRequest) (*DeleteRuleResponse, error)
+	// Pause a rule, its triggers stop being watched until it is resumed
+	PauseRule(context.Context, *PauseRuleRequest) (*RuleResponse, error)
+	// Resume a paused rule
+	ResumeRule(context.Context, *ResumeRuleRequest) (*RuleResponse, error)
+	// Execute a rule action immediately, on the rule targets
+	ExecuteRule(context.Context, *ExecuteRuleRequest) (*ExecutionResponse, error)
+	// Retrieve the most recent executions of a rule
+	ListExecutions(context.Context, *ListExecutionsRequest) (*ExecutionsResponse, error)
+	// Retrieve the state of a rule and its triggers in the automation engine
+	GetRuleStatus(context.Context, *GetRuleStatusRequest) (*RuleStatusResponse, error)
+	// Retrieve the state of the automation engine: its rules and triggers, and the C2 event stream
+	GetEngineStatus(context.Context, *GetEngineStatusRequest) (*EngineStatusResponse, error)
 	HealthCheck(context.Context, *HealthCheckRequest) (*HealthCheckResponse, error)
 }
 
@@ -943,6 +2513,24 @@ func (*UnimplementedC2AutomationEngineServer) UpdateRule(ctx context.Context, re
 func (*UnimplementedC2AutomationEngineServer) DeleteRule(ctx context.Context, req *DeleteRuleRequest) (*DeleteRuleResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteRule not implemented")
 }
+func (*UnimplementedC2AutomationEngineServer) PauseRule(ctx context.Context, req *PauseRuleRequest) (*RuleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PauseRule not implemented")
+}
+func (*UnimplementedC2AutomationEngineServer) ResumeRule(ctx context.Context, req *ResumeRuleRequest) (*RuleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeRule not implemented")
+}
+func (*UnimplementedC2AutomationEngineServer) ExecuteRule(ctx context.Context, req *ExecuteRuleRequest) (*ExecutionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExecuteRule not implemented")
+}
+func (*UnimplementedC2AutomationEngineServer) ListExecutions(ctx context.Context, req *ListExecutionsRequest) (*ExecutionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListExecutions not implemented")
+}
+func (*UnimplementedC2AutomationEngineServer) GetRuleStatus(ctx context.Context, req *GetRuleStatusRequest) (*RuleStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRuleStatus not implemented")
+}
+func (*UnimplementedC2AutomationEngineServer) GetEngineStatus(ctx context.Context, req *GetEngineStatusRequest) (*EngineStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEngineStatus not implemented")
+}
 func (*UnimplementedC2AutomationEngineServer) HealthCheck(ctx context.Context, req *HealthCheckRequest) (*HealthCheckResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method HealthCheck not implemented")
 }
@@ -1041,6 +2629,114 @@ func _C2AutomationEngine_DeleteRule_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _C2AutomationEngine_PauseRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(C2AutomationEngineServer).PauseRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.C2AutomationEngine/PauseRule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(C2AutomationEngineServer).PauseRule(ctx, req.(*PauseRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _C2AutomationEngine_ResumeRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(C2AutomationEngineServer).ResumeRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.C2AutomationEngine/ResumeRule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(C2AutomationEngineServer).ResumeRule(ctx, req.(*ResumeRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _C2AutomationEngine_ExecuteRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExecuteRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(C2AutomationEngineServer).ExecuteRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.C2AutomationEngine/ExecuteRule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(C2AutomationEngineServer).ExecuteRule(ctx, req.(*ExecuteRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _C2AutomationEngine_ListExecutions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListExecutionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(C2AutomationEngineServer).ListExecutions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.C2AutomationEngine/ListExecutions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(C2AutomationEngineServer).ListExecutions(ctx, req.(*ListExecutionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _C2AutomationEngine_GetRuleStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRuleStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(C2AutomationEngineServer).GetRuleStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.C2AutomationEngine/GetRuleStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(C2AutomationEngineServer).GetRuleStatus(ctx, req.(*GetRuleStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _C2AutomationEngine_GetEngineStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEngineStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(C2AutomationEngineServer).GetEngineStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.C2AutomationEngine/GetEngineStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(C2AutomationEngineServer).GetEngineStatus(ctx, req.(*GetEngineStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _C2AutomationEngine_HealthCheck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(HealthCheckRequest)
 	if err := dec(in); err != nil {
@@ -1083,6 +2779,30 @@ var _C2AutomationEngine_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteRule",
 			Handler:    _C2AutomationEngine_DeleteRule_Handler,
 		},
+		{
+			MethodName: "PauseRule",
+			Handler:    _C2AutomationEngine_PauseRule_Handler,
+		},
+		{
+			MethodName: "ResumeRule",
+			Handler:    _C2AutomationEngine_ResumeRule_Handler,
+		},
+		{
+			MethodName: "ExecuteRule",
+			Handler:    _C2AutomationEngine_ExecuteRule_Handler,
+		},
+		{
+			MethodName: "ListExecutions",
+			Handler:    _C2AutomationEngine_ListExecutions_Handler,
+		},
+		{
+			MethodName: "GetRuleStatus",
+			Handler:    _C2AutomationEngine_GetRuleStatus_Handler,
+		},
+		{
+			MethodName: "GetEngineStatus",
+			Handler:    _C2AutomationEngine_GetEngineStatus_Handler,
+		},
 		{
 			MethodName: "HealthCheck",
 			Handler:    _C2AutomationEngine_HealthCheck_Handler,
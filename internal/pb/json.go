@@ -41,3 +41,59 @@ func (t *Trigger) MarshalJSON() ([]byte, error) {
 
 	return json.Marshal(jsonTrigger)
 }
+
+// jsonRule only differs from Rule by its lack of methods, so Rule.MarshalJSON can
+// marshal its fields without calling itself.
+type jsonRule Rule
+
+// MarshalJSON is a custom json marshalling for Rule type
+// allowing to decode binary ActionSettings to a proper json representation
+func (r *Rule) MarshalJSON() ([]byte, error) {
+	actionSettings, err := decodeJSONActionSettings(r.Action, r.ActionSettings)
+	if err != nil {
+		return nil, fmt.Errorf("json marshalling failed: %v", err)
+	}
+
+	return json.Marshal(struct {
+		jsonRule
+		ActionSettings ActionSettings `json:"actionSettings,omitempty"`
+	}{
+		jsonRule:       jsonRule(*r),
+		ActionSettings: actionSettings,
+	})
+}
+
+type jsonAction Action
+
+// MarshalJSON is a custom json marshalling for Action type
+// allowing to decode binary Settings and CompensateSettings to a proper json representation
+func (a *Action) MarshalJSON() ([]byte, error) {
+	settings, err := decodeJSONActionSettings(a.Type, a.Settings)
+	if err != nil {
+		return nil, fmt.Errorf("json marshalling failed: %v", err)
+	}
+
+	compensateSettings, err := decodeJSONActionSettings(a.CompensateType, a.CompensateSettings)
+	if err != nil {
+		return nil, fmt.Errorf("json marshalling failed: %v", err)
+	}
+
+	return json.Marshal(struct {
+		jsonAction
+		Settings           ActionSettings `json:"settings,omitempty"`
+		CompensateSettings ActionSettings `json:"compensateSettings,omitempty"`
+	}{
+		jsonAction:         jsonAction(*a),
+		Settings:           settings,
+		CompensateSettings: compensateSettings,
+	})
+}
+
+// decodeJSONActionSettings decodes binary action settings, returning nil when they are empty
+func decodeJSONActionSettings(t ActionType, settings []byte) (ActionSettings, error) {
+	if len(settings) == 0 {
+		return nil, nil
+	}
+
+	return DecodeActionSettings(t, settings)
+}
@@ -15,6 +15,8 @@
 package pb
 
 import (
+	"encoding/json"
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -73,4 +75,99 @@ func TestJson(t *testing.T) {
 			t.Error("Expected err to be not nil")
 		}
 	})
+
+	t.Run("Rule MarshalJson properly marshall ActionSettings and triggers", func(t *testing.T) {
+		actionSettings := &ActionSettingsWebhook{URL: "https://example.com/hook", MaxRetries: 3}
+		encodedActionSettings, err := actionSettings.Encode()
+		if err != nil {
+			t.Fatalf("Expected err to be nil, got %s", err)
+		}
+
+		encodedTriggerSettings, err := (&TriggerSettingsTimeInterval{Expr: "expectedExpr"}).Encode()
+		if err != nil {
+			t.Fatalf("Expected err to be nil, got %s", err)
+		}
+
+		rule := &Rule{
+			Id:             1,
+			Description:    "expectedDescription",
+			Action:         ActionType_WEBHOOK,
+			ActionSettings: encodedActionSettings,
+			Triggers:       []*Trigger{&Trigger{Type: TriggerType_TIME_INTERVAL, Settings: encodedTriggerSettings}},
+		}
+
+		encoded, err := json.Marshal(rule)
+		if err != nil {
+			t.Fatalf("Expected err to be nil, got %s", err)
+		}
+
+		var decoded struct {
+			ID             int32                  `json:"id"`
+			Description    string                 `json:"description"`
+			ActionSettings *ActionSettingsWebhook `json:"actionSettings"`
+			Triggers       []struct {
+				Settings *TriggerSettingsTimeInterval `json:"settings"`
+			} `json:"triggers"`
+		}
+		if err := json.Unmarshal(encoded, &decoded); err != nil {
+			t.Fatalf("Expected json '%s' to be decodable, got %s", encoded, err)
+		}
+
+		if decoded.ID != 1 || decoded.Description != "expectedDescription" {
+			t.Errorf("Expected json to hold the rule fields, got '%s'", encoded)
+		}
+
+		if reflect.DeepEqual(decoded.ActionSettings, actionSettings) == false {
+			t.Errorf("Expected json action settings to be %#v, got %#v", actionSettings, decoded.ActionSettings)
+		}
+
+		if len(decoded.Triggers) != 1 || decoded.Triggers[0].Settings == nil || decoded.Triggers[0].Settings.Expr != "expectedExpr" {
+			t.Errorf("Expected json to hold the trigger settings, got '%s'", encoded)
+		}
+	})
+
+	t.Run("Rule MarshalJson omits empty ActionSettings", func(t *testing.T) {
+		encoded, err := json.Marshal(&Rule{Action: ActionType_KEY_ROTATION})
+		if err != nil {
+			t.Fatalf("Expected err to be nil, got %s", err)
+		}
+
+		if strings.Contains(string(encoded), "actionSettings") {
+			t.Errorf("Expected json to not contain actionSettings, got '%s'", encoded)
+		}
+	})
+
+	t.Run("Action MarshalJson properly marshall Settings and CompensateSettings", func(t *testing.T) {
+		encodedSettings, err := (&ActionSettingsWebhook{URL: "https://example.com/compensate"}).Encode()
+		if err != nil {
+			t.Fatalf("Expected err to be nil, got %s", err)
+		}
+
+		action := &Action{
+			Type:               ActionType_RESET_TOPIC,
+			OnFailure:          ActionFailurePolicy_COMPENSATE,
+			CompensateType:     ActionType_WEBHOOK,
+			CompensateSettings: encodedSettings,
+		}
+
+		encoded, err := json.Marshal(action)
+		if err != nil {
+			t.Fatalf("Expected err to be nil, got %s", err)
+		}
+
+		if strings.Contains(string(encoded), `"compensateSettings":{"url":"https://example.com/compensate"}`) == false {
+			t.Errorf("Expected json to contain the decoded compensate settings, got '%s'", encoded)
+		}
+	})
+
+	t.Run("Rule MarshalJson returns an error on invalid ActionSettings", func(t *testing.T) {
+		rule := &Rule{
+			Action:         ActionType_KEY_ROTATION,
+			ActionSettings: []byte(`{"url":"https://example.com"}`),
+		}
+
+		if _, err := rule.MarshalJSON(); err == nil {
+			t.Error("Expected err to be not nil")
+		}
+	})
 }
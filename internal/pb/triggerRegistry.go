@@ -0,0 +1,146 @@
+// Copyright 2020 Teserakt AG
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pb
+
+import (
+	fmt "fmt"
+	"sort"
+	"sync"
+)
+
+// TriggerDefinition describes a trigger type, as registered with RegisterTrigger
+type TriggerDefinition struct {
+	Type TriggerType
+	Name string
+	// NewSettings returns empty settings for the trigger type, which are decoded
+	// and validated when the trigger is saved. The cli decodes its --setting name=value
+	// flags into these settings, matching names to their fields.
+	NewSettings func() TriggerSettings
+	// SettingSuggestions lists the name= or name=value settings suggested by the cli completion
+	SettingSuggestions []string
+}
+
+var triggerRegistry = struct {
+	sync.RWMutex
+	definitions map[TriggerType]TriggerDefinition
+}{
+	definitions: make(map[TriggerType]TriggerDefinition),
+}
+
+func init() {
+	RegisterTrigger(TriggerDefinition{
+		Type:        TriggerType_TIME_INTERVAL,
+		Name:        TriggerType_TIME_INTERVAL.String(),
+		NewSettings: func() TriggerSettings { return &TriggerSettingsTimeInterval{} },
+		SettingSuggestions: []string{
+			"expr=", "timezone=", "notBefore=", "notAfter=", "jitter=", "splay=", "maxLateness=",
+			"misfirePolicy=" + MisfirePolicyFireOnce,
+			"misfirePolicy=" + MisfirePolicySkip,
+			"misfirePolicy=" + MisfirePolicyFireAll,
+		},
+	})
+
+	eventSuggestions := []string{"maxOccurrence=", "window=", "include=", "exclude="}
+	for _, eventType := range AvailableEventTypes() {
+		eventSuggestions = append(
+			eventSuggestions,
+			fmt.Sprintf("eventType=%s", eventType),
+			fmt.Sprintf("eventTypes=%s", eventType),
+		)
+	}
+	RegisterTrigger(TriggerDefinition{
+		Type:               TriggerType_EVENT,
+		Name:               TriggerType_EVENT.String(),
+		NewSettings:        func() TriggerSettings { return &TriggerSettingsEvent{} },
+		SettingSuggestions: eventSuggestions,
+	})
+
+	RegisterTrigger(TriggerDefinition{
+		Type:               TriggerType_ONCE,
+		Name:               TriggerType_ONCE.String(),
+		NewSettings:        func() TriggerSettings { return &TriggerSettingsOnce{} },
+		SettingSuggestions: []string{"at="},
+	})
+}
+
+// RegisterTrigger registers a trigger type, making it known to the triggers validation and the cli.
+// Trigger types not defined in the api.proto TriggerType enum get their name added to it.
+// It panics when the type is undefined, when it has no settings, or when its type or name
+// are already registered, and is meant to be called from init functions.
+func RegisterTrigger(definition TriggerDefinition) {
+	triggerRegistry.Lock()
+	defer triggerRegistry.Unlock()
+
+	if definition.Type == TriggerType_UNDEFINED_TRIGGER || len(definition.Name) == 0 || definition.NewSettings == nil {
+		panic("pb: RegisterTrigger requires a trigger type, name and settings")
+	}
+
+	if _, ok := triggerRegistry.definitions[definition.Type]; ok {
+		panic(fmt.Sprintf("pb: trigger type %d is already registered", definition.Type))
+	}
+
+	if name, ok := TriggerType_name[int32(definition.Type)]; ok && name != definition.Name {
+		panic(fmt.Sprintf("pb: trigger type %d is already named %s", definition.Type, name))
+	}
+
+	if value, ok := TriggerType_value[definition.Name]; ok && value != int32(definition.Type) {
+		panic(fmt.Sprintf("pb: trigger name %s is already used by type %d", definition.Name, value))
+	}
+
+	TriggerType_name[int32(definition.Type)] = definition.Name
+	TriggerType_value[definition.Name] = int32(definition.Type)
+	triggerRegistry.definitions[definition.Type] = definition
+}
+
+// LookupTrigger returns the definition of given trigger type, and false when it is not registered
+func LookupTrigger(t TriggerType) (TriggerDefinition, bool) {
+	triggerRegistry.RLock()
+	defer triggerRegistry.RUnlock()
+
+	definition, ok := triggerRegistry.definitions[t]
+
+	return definition, ok
+}
+
+// LookupTriggerName returns the definition of the trigger type registered with given name,
+// and false when none are.
+func LookupTriggerName(name string) (TriggerDefinition, bool) {
+	triggerRegistry.RLock()
+	value, ok := TriggerType_value[name]
+	triggerRegistry.RUnlock()
+
+	if !ok {
+		return TriggerDefinition{}, false
+	}
+
+	return LookupTrigger(TriggerType(value))
+}
+
+// RegisteredTriggers returns the definitions of every registered trigger types, sorted by type
+func RegisteredTriggers() []TriggerDefinition {
+	triggerRegistry.RLock()
+	defer triggerRegistry.RUnlock()
+
+	var definitions []TriggerDefinition
+	for _, definition := range triggerRegistry.definitions {
+		definitions = append(definitions, definition)
+	}
+
+	sort.Slice(definitions, func(i, j int) bool {
+		return definitions[i].Type < definitions[j].Type
+	})
+
+	return definitions
+}
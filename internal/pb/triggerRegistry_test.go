@@ -0,0 +1,102 @@
+// Copyright 2020 Teserakt AG
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pb
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTriggerRegistry(t *testing.T) {
+	t.Run("Built-in trigger types are registered", func(t *testing.T) {
+		var names []string
+		for _, definition := range RegisteredTriggers() {
+			names = append(names, definition.Name)
+		}
+
+		expectedNames := []string{"TIME_INTERVAL", "EVENT", "ONCE"}
+		if reflect.DeepEqual(names[:len(expectedNames)], expectedNames) == false {
+			t.Errorf("Expected registered triggers to start with %v, got %v", expectedNames, names)
+		}
+
+		if _, ok := LookupTrigger(TriggerType_UNDEFINED_TRIGGER); ok {
+			t.Errorf("Expected UNDEFINED_TRIGGER to not be registered")
+		}
+
+		definition, ok := LookupTriggerName("ONCE")
+		if !ok || definition.Type != TriggerType_ONCE {
+			t.Errorf("Expected ONCE to be registered, got %#v", definition)
+		}
+
+		if _, ok := definition.NewSettings().(*TriggerSettingsOnce); !ok {
+			t.Errorf("Expected ONCE settings to be *TriggerSettingsOnce, got %T", definition.NewSettings())
+		}
+	})
+
+	t.Run("RegisterTrigger makes the trigger type decodable", func(t *testing.T) {
+		customType := TriggerType(1000)
+		RegisterTrigger(TriggerDefinition{
+			Type:        customType,
+			Name:        "CUSTOM_REGISTRY_TEST",
+			NewSettings: func() TriggerSettings { return &TriggerSettingsOnce{} },
+		})
+
+		if customType.String() != "CUSTOM_REGISTRY_TEST" {
+			t.Errorf("Expected trigger type name to be CUSTOM_REGISTRY_TEST, got %s", customType)
+		}
+
+		settings, err := Decode(customType, []byte(`{"at":"2020-06-01T00:00:00Z"}`))
+		if err != nil {
+			t.Fatalf("Expected no error decoding custom trigger settings, got %v", err)
+		}
+
+		expectedSettings := &TriggerSettingsOnce{At: "2020-06-01T00:00:00Z"}
+		if reflect.DeepEqual(settings, expectedSettings) == false {
+			t.Errorf("Expected settings to be %#v, got %#v", expectedSettings, settings)
+		}
+	})
+
+	t.Run("Decode returns an error on unregistered types and invalid settings", func(t *testing.T) {
+		if _, err := Decode(TriggerType(1001), []byte(`{}`)); err == nil {
+			t.Errorf("Expected an error decoding an unregistered trigger type")
+		}
+
+		if _, err := Decode(TriggerType_ONCE, []byte(`{`)); err == nil {
+			t.Errorf("Expected an error decoding invalid settings")
+		}
+	})
+
+	t.Run("RegisterTrigger panics on invalid or already registered definitions", func(t *testing.T) {
+		newSettings := func() TriggerSettings { return &TriggerSettingsOnce{} }
+		testData := []TriggerDefinition{
+			TriggerDefinition{Type: TriggerType_ONCE, Name: "ONCE", NewSettings: newSettings},
+			TriggerDefinition{Type: TriggerType(1002), Name: "ONCE", NewSettings: newSettings},
+			TriggerDefinition{Type: TriggerType(1003), Name: "NO_SETTINGS_REGISTRY_TEST"},
+			TriggerDefinition{Name: "UNDEFINED_REGISTRY_TEST", NewSettings: newSettings},
+		}
+
+		for _, definition := range testData {
+			func() {
+				defer func() {
+					if recover() == nil {
+						t.Errorf("Expected RegisterTrigger to panic with %#v", definition)
+					}
+				}()
+
+				RegisterTrigger(definition)
+			}()
+		}
+	})
+}
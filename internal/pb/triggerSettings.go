@@ -18,6 +18,9 @@ import (
 	"encoding/json"
 	"errors"
 	fmt "fmt"
+	"regexp"
+	"sort"
+	"time"
 
 	"github.com/gorhill/cronexpr"
 	c2pb "github.com/teserakt-io/c2/pkg/pb"
@@ -33,6 +36,32 @@ var (
 	EventTypeClientUnsubscribed EventType = EventType(c2pb.EventType_CLIENT_UNSUBSCRIBED.String())
 )
 
+// AvailableEventTypes returns the C2 event types triggers can listen for, sorted by name.
+// They are derived from the C2 event definitions, so new C2 events are supported as soon as they are defined there.
+func AvailableEventTypes() []EventType {
+	var eventTypes []EventType
+	for name, value := range c2pb.EventType_value {
+		if value == int32(c2pb.EventType_UNDEFINED) {
+			continue
+		}
+
+		eventTypes = append(eventTypes, EventType(name))
+	}
+
+	sort.Slice(eventTypes, func(i, j int) bool {
+		return eventTypes[i] < eventTypes[j]
+	})
+
+	return eventTypes
+}
+
+// IsValid returns true when the event type is one of the AvailableEventTypes
+func (t EventType) IsValid() bool {
+	value, ok := c2pb.EventType_value[string(t)]
+
+	return ok && value != int32(c2pb.EventType_UNDEFINED)
+}
+
 // TriggerSettings defines a generic trigger settings structure
 type TriggerSettings interface {
 	Validate() error
@@ -43,31 +72,92 @@ type TriggerSettings interface {
 // TriggerSettingsTimeInterval holds settings for pb.TriggerType_TIME_INTERVAL trigger types
 type TriggerSettingsTimeInterval struct {
 	Expr string `json:"expr,omitempty"`
+	// Timezone is an IANA time zone name (such as Europe/Zurich) in which the cron expression is evaluated.
+	// The engine local time zone is used when empty.
+	Timezone string `json:"timezone,omitempty"`
+	// NotBefore and NotAfter optionally bound the schedule, as RFC3339 timestamps.
+	// No run is due before NotBefore, nor after NotAfter.
+	NotBefore string `json:"notBefore,omitempty"`
+	NotAfter  string `json:"notAfter,omitempty"`
+	// Jitter, when set, is a duration (such as 30s) bounding a random delay added to each run.
+	Jitter string `json:"jitter,omitempty"`
+	// Splay, when set, is a duration over which key rotations spread their targets.
+	// Each target gets a fixed offset within it, so it is always rotated at the same time.
+	Splay string `json:"splay,omitempty"`
+	// MisfirePolicy defines how the runs missed while the engine was down are handled,
+	// and must be one of the MisfirePolicy constants. Defaults to MisfirePolicyFireOnce.
+	MisfirePolicy string `json:"misfirePolicy,omitempty"`
+	// MaxLateness, when set, is a duration (such as 1h) after which a missed run is dropped, whatever the MisfirePolicy.
+	MaxLateness string `json:"maxLateness,omitempty"`
+}
+
+// Available MisfirePolicy values of time interval triggers
+const (
+	// MisfirePolicyFireOnce fires a single run for all the missed ones
+	MisfirePolicyFireOnce = "fire_once"
+	// MisfirePolicySkip drops the missed runs, and waits for the next one
+	MisfirePolicySkip = "skip"
+	// MisfirePolicyFireAll fires every missed runs, in sequence
+	MisfirePolicyFireAll = "fire_all"
+)
+
+// TriggerSettingsOnce holds settings for pb.TriggerType_ONCE trigger types
+type TriggerSettingsOnce struct {
+	// At is the RFC3339 timestamp when the trigger fires
+	At string `json:"at,omitempty"`
 }
 
 // TriggerSettingsEvent holds settings for event driven trigger types
 type TriggerSettingsEvent struct {
-	EventType     EventType `json:"eventType,omitempty"`
-	MaxOccurrence int       `json:"maxOccurrence,omitempty"`
+	// EventType and EventTypes define the C2 event types to listen for, and are merged together.
+	EventType     EventType   `json:"eventType,omitempty"`
+	EventTypes    []EventType `json:"eventTypes,omitempty"`
+	MaxOccurrence int         `json:"maxOccurrence,omitempty"`
+	// Window, when set, is a duration (such as 10m) restricting the counted events
+	// to the ones received during this last period.
+	Window string `json:"window,omitempty"`
+	// Include and Exclude filter the received events before they are matched against the rule targets.
+	// When Include is set, events must match at least one of its filters, and events matching any
+	// of the Exclude filters are ignored.
+	Include []EventFilter `json:"include,omitempty"`
+	Exclude []EventFilter `json:"exclude,omitempty"`
 }
 
+// EventFilter matches C2 events on their fields. Every set field must match for the filter to match.
+type EventFilter struct {
+	// Source and Target are regular expressions which must match the whole event source or target
+	Source string `json:"source,omitempty"`
+	Target string `json:"target,omitempty"`
+	// From and To restrict the event timestamp to a time of the day range, formatted as 15:04 in UTC.
+	// The range wraps over midnight when From is after To.
+	From string `json:"from,omitempty"`
+	To   string `json:"to,omitempty"`
+	// Description is not supported, as C2 events carry no description. It is only
+	// decoded so that filters setting it are rejected rather than silently matching every event.
+	Description string `json:"description,omitempty"`
+}
+
+// EventFilterTimeLayout is the layout of the EventFilter From and To fields
+const EventFilterTimeLayout = "15:04"
+
 var _ TriggerSettings = &TriggerSettingsTimeInterval{}
 var _ TriggerSettings = &TriggerSettingsEvent{}
+var _ TriggerSettings = &TriggerSettingsOnce{}
 
 // Decode will attempt to turn []byte settings into matching struct given the trigger type
 func Decode(t TriggerType, settings []byte) (TriggerSettings, error) {
-	var triggerSettings TriggerSettings
-
-	switch t {
-	case TriggerType_TIME_INTERVAL:
-		triggerSettings = &TriggerSettingsTimeInterval{}
-	case TriggerType_EVENT:
-		triggerSettings = &TriggerSettingsEvent{}
-	default:
+	definition, ok := LookupTrigger(t)
+	if !ok {
 		return nil, fmt.Errorf("trigger type %s is not supported", t)
 	}
 
-	triggerSettings.Decode(settings)
+	// Empty settings are left to the settings validation to report the missing fields
+	triggerSettings := definition.NewSettings()
+	if len(settings) > 0 {
+		if err := triggerSettings.Decode(settings); err != nil {
+			return nil, err
+		}
+	}
 
 	return triggerSettings, nil
 }
@@ -78,14 +168,140 @@ func (t *TriggerSettingsTimeInterval) Validate() error {
 		return errors.New("expr field is required and must be a valid cron expression")
 	}
 
-	_, err := cronexpr.Parse(t.Expr)
+	expr, err := cronexpr.Parse(t.Expr)
 	if err != nil {
 		return fmt.Errorf("failed to parse cron expression from Expr field: %s", err)
 	}
 
+	loc, err := t.Location()
+	if err != nil {
+		return err
+	}
+
+	notBefore, notAfter, err := t.Bounds()
+	if err != nil {
+		return err
+	}
+
+	if !notBefore.IsZero() && !notAfter.IsZero() && !notBefore.Before(notAfter) {
+		return errors.New("NotBefore must be before NotAfter")
+	}
+
+	if err := validatePositiveDuration("Jitter", t.Jitter); err != nil {
+		return err
+	}
+
+	// A jittered run must not be delayed past the next one
+	if jitter := t.JitterDuration(); jitter > 0 {
+		if interval, ok := shortestInterval(expr, loc, notBefore); ok && jitter >= interval {
+			return fmt.Errorf("Jitter must be below the %s interval between two runs", interval)
+		}
+	}
+
+	if err := validatePositiveDuration("Splay", t.Splay); err != nil {
+		return err
+	}
+
+	switch t.MisfirePolicy {
+	case "", MisfirePolicyFireOnce, MisfirePolicySkip, MisfirePolicyFireAll:
+	default:
+		return fmt.Errorf(
+			"invalid MisfirePolicy %q, must be one of %s, %s or %s",
+			t.MisfirePolicy,
+			MisfirePolicyFireOnce,
+			MisfirePolicySkip,
+			MisfirePolicyFireAll,
+		)
+	}
+
+	if err := validatePositiveDuration("MaxLateness", t.MaxLateness); err != nil {
+		return err
+	}
+
 	return nil
 }
 
+// JitterDuration returns the parsed Jitter, or 0 when it is not set or invalid
+func (t *TriggerSettingsTimeInterval) JitterDuration() time.Duration {
+	return positiveDuration(t.Jitter)
+}
+
+// Misfire returns the MisfirePolicy, or MisfirePolicyFireOnce when it is not set
+func (t *TriggerSettingsTimeInterval) Misfire() string {
+	if len(t.MisfirePolicy) == 0 {
+		return MisfirePolicyFireOnce
+	}
+
+	return t.MisfirePolicy
+}
+
+// MaxLatenessDuration returns the parsed MaxLateness, or 0 when it is not set or invalid
+func (t *TriggerSettingsTimeInterval) MaxLatenessDuration() time.Duration {
+	return positiveDuration(t.MaxLateness)
+}
+
+// SplayDuration returns the parsed Splay, or 0 when it is not set or invalid
+func (t *TriggerSettingsTimeInterval) SplayDuration() time.Duration {
+	return positiveDuration(t.Splay)
+}
+
+// Bounds returns the parsed NotBefore and NotAfter settings, zero when unset
+func (t *TriggerSettingsTimeInterval) Bounds() (notBefore time.Time, notAfter time.Time, err error) {
+	if len(t.NotBefore) > 0 {
+		if notBefore, err = time.Parse(time.RFC3339, t.NotBefore); err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("NotBefore must be a RFC3339 timestamp: %v", err)
+		}
+	}
+
+	if len(t.NotAfter) > 0 {
+		if notAfter, err = time.Parse(time.RFC3339, t.NotAfter); err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("NotAfter must be a RFC3339 timestamp: %v", err)
+		}
+	}
+
+	return notBefore, notAfter, nil
+}
+
+// intervalSamples is how many upcoming runs of a cron expression shortestInterval looks at
+const intervalSamples = 64
+
+// shortestInterval returns the shortest interval between the upcoming runs of expr, evaluated in the loc time zone,
+// from notBefore when set or from now. It returns false when expr doesn't have at least two upcoming runs.
+func shortestInterval(expr *cronexpr.Expression, loc *time.Location, notBefore time.Time) (time.Duration, bool) {
+	from := time.Now()
+	if notBefore.After(from) {
+		from = notBefore
+	}
+
+	runs := expr.NextN(from.In(loc), intervalSamples)
+	if len(runs) < 2 {
+		return 0, false
+	}
+
+	shortest := runs[1].Sub(runs[0])
+	for i := 2; i < len(runs); i++ {
+		if interval := runs[i].Sub(runs[i-1]); interval < shortest {
+			shortest = interval
+		}
+	}
+
+	return shortest, true
+}
+
+// Location returns the time zone in which the cron expression is evaluated
+func (t *TriggerSettingsTimeInterval) Location() (*time.Location, error) {
+	if len(t.Timezone) == 0 {
+		return time.Local, nil
+	}
+
+	loc, err := time.LoadLocation(t.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone %s: %v", t.Timezone, err)
+	}
+
+	return loc, nil
+}
+
 // Encode json encode settings to []byte
 func (t *TriggerSettingsTimeInterval) Encode() ([]byte, error) {
 	return jsonEncode(t)
@@ -96,25 +312,127 @@ func (t *TriggerSettingsTimeInterval) Decode(b []byte) error {
 	return jsonDecode(t, b)
 }
 
+// Validate implements TriggerSettings and returns an error when the settings are invalid
+func (t *TriggerSettingsOnce) Validate() error {
+	if len(t.At) == 0 {
+		return errors.New("At field is required and must be a RFC3339 timestamp")
+	}
+
+	if _, err := t.Time(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Time returns the parsed At setting
+func (t *TriggerSettingsOnce) Time() (time.Time, error) {
+	at, err := time.Parse(time.RFC3339, t.At)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("At must be a RFC3339 timestamp: %v", err)
+	}
+
+	return at, nil
+}
+
+// Encode json encode settings to []byte
+func (t *TriggerSettingsOnce) Encode() ([]byte, error) {
+	return jsonEncode(t)
+}
+
+// Decode json decode bytes to settings
+func (t *TriggerSettingsOnce) Decode(b []byte) error {
+	return jsonDecode(t, b)
+}
+
 // Validate implements TriggerSettings and returns an error when the settings are invalid
 func (t *TriggerSettingsEvent) Validate() error {
-	if len(t.EventType) <= 0 {
-		return errors.New("EventType is required")
+	eventTypes := t.WatchedEventTypes()
+	if len(eventTypes) == 0 {
+		return errors.New("EventType or EventTypes is required")
 	}
 
-	switch t.EventType {
-	case EventTypeClientSubscribed, EventTypeClientUnsubscribed:
-	default:
-		return fmt.Errorf("EventType must be one of %v", []EventType{EventTypeClientSubscribed, EventTypeClientUnsubscribed})
+	for _, eventType := range eventTypes {
+		if !eventType.IsValid() {
+			return fmt.Errorf("invalid event type %q, must be one of %v", eventType, AvailableEventTypes())
+		}
 	}
 
 	if t.MaxOccurrence <= 0 {
 		return errors.New("MaxOccurrence must be greater than 0")
 	}
 
+	for _, filters := range [][]EventFilter{t.Include, t.Exclude} {
+		for _, filter := range filters {
+			if err := filter.Validate(); err != nil {
+				return fmt.Errorf("invalid event filter: %v", err)
+			}
+		}
+	}
+
+	if err := validatePositiveDuration("Window", t.Window); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Validate returns an error when the filter is empty or holds invalid fields
+func (f EventFilter) Validate() error {
+	if len(f.Description) > 0 {
+		return errors.New("description filters are not supported, C2 events carry no description")
+	}
+
+	if len(f.Source) == 0 && len(f.Target) == 0 && len(f.From) == 0 && len(f.To) == 0 {
+		return errors.New("at least one of source, target, from or to is required")
+	}
+
+	if _, err := regexp.Compile(f.Source); err != nil {
+		return fmt.Errorf("invalid source expression: %v", err)
+	}
+
+	if _, err := regexp.Compile(f.Target); err != nil {
+		return fmt.Errorf("invalid target expression: %v", err)
+	}
+
+	if (len(f.From) == 0) != (len(f.To) == 0) {
+		return errors.New("from and to must be set together")
+	}
+
+	if len(f.From) > 0 {
+		if _, err := time.Parse(EventFilterTimeLayout, f.From); err != nil {
+			return fmt.Errorf("invalid from time, expected HH:MM: %v", err)
+		}
+
+		if _, err := time.Parse(EventFilterTimeLayout, f.To); err != nil {
+			return fmt.Errorf("invalid to time, expected HH:MM: %v", err)
+		}
+	}
+
 	return nil
 }
 
+// WatchedEventTypes returns the EventType and EventTypes settings merged, without duplicates
+func (t *TriggerSettingsEvent) WatchedEventTypes() []EventType {
+	var eventTypes []EventType
+	seen := make(map[EventType]bool)
+	for _, eventType := range append([]EventType{t.EventType}, t.EventTypes...) {
+		if len(eventType) == 0 || seen[eventType] {
+			continue
+		}
+
+		seen[eventType] = true
+		eventTypes = append(eventTypes, eventType)
+	}
+
+	return eventTypes
+}
+
+// WindowDuration returns the configured window, or 0 when the events are counted without time limit
+func (t *TriggerSettingsEvent) WindowDuration() time.Duration {
+	return positiveDuration(t.Window)
+}
+
 // Encode json encode settings to []byte
 func (t *TriggerSettingsEvent) Encode() ([]byte, error) {
 	return jsonEncode(t)
@@ -141,3 +459,31 @@ func jsonDecode(t interface{}, b []byte) error {
 
 	return nil
 }
+
+// validatePositiveDuration returns an error when the optional field value is set but is not a positive duration
+func validatePositiveDuration(field string, value string) error {
+	if len(value) == 0 {
+		return nil
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s duration: %v", field, err)
+	}
+
+	if d <= 0 {
+		return fmt.Errorf("%s must be a positive duration", field)
+	}
+
+	return nil
+}
+
+// positiveDuration returns the parsed value, or 0 when it is empty or not a positive duration
+func positiveDuration(value string) time.Duration {
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return 0
+	}
+
+	return d
+}
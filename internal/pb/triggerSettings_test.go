@@ -17,6 +17,9 @@ package pb
 import (
 	"reflect"
 	"testing"
+	"time"
+
+	c2pb "github.com/teserakt-io/c2/pkg/pb"
 )
 
 func TestTriggerSettings(t *testing.T) {
@@ -24,6 +27,7 @@ func TestTriggerSettings(t *testing.T) {
 		testData := map[TriggerSettings]TriggerSettings{
 			&TriggerSettingsTimeInterval{}: &TriggerSettingsTimeInterval{Expr: "something"},
 			&TriggerSettingsEvent{}:        &TriggerSettingsEvent{MaxOccurrence: 5},
+			&TriggerSettingsOnce{}:         &TriggerSettingsOnce{At: "2020-06-01T02:00:00Z"},
 		}
 
 		for settings, expectedSettings := range testData {
@@ -44,12 +48,32 @@ func TestTriggerSettings(t *testing.T) {
 func TestTriggerSettingsTimeInterval(t *testing.T) {
 	t.Run("Validate properly checks settings", func(t *testing.T) {
 		testData := map[*TriggerSettingsTimeInterval]bool{
-			&TriggerSettingsTimeInterval{Expr: ""}:                     false,
-			&TriggerSettingsTimeInterval{Expr: "*****"}:                false,
-			&TriggerSettingsTimeInterval{Expr: "* * * * *"}:            true,
-			&TriggerSettingsTimeInterval{Expr: "0/5 * * * *"}:          true,
-			&TriggerSettingsTimeInterval{Expr: "0 0 12 ? * WED,SAT *"}: true,
-			&TriggerSettingsTimeInterval{Expr: "0 0 2 ? 1 MON#1 *"}:    true,
+			&TriggerSettingsTimeInterval{Expr: ""}:                                                                               false,
+			&TriggerSettingsTimeInterval{Expr: "*****"}:                                                                          false,
+			&TriggerSettingsTimeInterval{Expr: "* * * * *"}:                                                                      true,
+			&TriggerSettingsTimeInterval{Expr: "0/5 * * * *"}:                                                                    true,
+			&TriggerSettingsTimeInterval{Expr: "0 0 12 ? * WED,SAT *"}:                                                           true,
+			&TriggerSettingsTimeInterval{Expr: "0 0 2 ? 1 MON#1 *"}:                                                              true,
+			&TriggerSettingsTimeInterval{Expr: "* * * * *", Timezone: "Europe/Zurich"}:                                           true,
+			&TriggerSettingsTimeInterval{Expr: "* * * * *", Timezone: "UTC"}:                                                     true,
+			&TriggerSettingsTimeInterval{Expr: "* * * * *", Timezone: "Europe/Nowhere"}:                                          false,
+			&TriggerSettingsTimeInterval{Expr: "* * * * *", NotBefore: "2020-01-01T00:00:00Z"}:                                   true,
+			&TriggerSettingsTimeInterval{Expr: "* * * * *", NotAfter: "2020-12-31T23:59:59+01:00"}:                               true,
+			&TriggerSettingsTimeInterval{Expr: "* * * * *", NotBefore: "2020-01-01T00:00:00Z", NotAfter: "2020-12-31T00:00:00Z"}: true,
+			&TriggerSettingsTimeInterval{Expr: "* * * * *", NotBefore: "2020-12-31T00:00:00Z", NotAfter: "2020-01-01T00:00:00Z"}: false,
+			&TriggerSettingsTimeInterval{Expr: "* * * * *", NotBefore: "2020-01-01"}:                                             false,
+			&TriggerSettingsTimeInterval{Expr: "* * * * *", NotAfter: "tomorrow"}:                                                false,
+			&TriggerSettingsTimeInterval{Expr: "* * * * *", Jitter: "30s", Splay: "10m"}:                                         true,
+			&TriggerSettingsTimeInterval{Expr: "* * * * *", Jitter: "30"}:                                                        false,
+			&TriggerSettingsTimeInterval{Expr: "* * * * *", Jitter: "-30s"}:                                                      false,
+			&TriggerSettingsTimeInterval{Expr: "* * * * *", Jitter: "1m"}:                                                        false,
+			&TriggerSettingsTimeInterval{Expr: "0 9,10 * * *", Jitter: "59m"}:                                                    true,
+			&TriggerSettingsTimeInterval{Expr: "0 9,10 * * *", Jitter: "2h"}:                                                     false,
+			&TriggerSettingsTimeInterval{Expr: "* * * * *", Splay: "0s"}:                                                         false,
+			&TriggerSettingsTimeInterval{Expr: "* * * * *", MisfirePolicy: MisfirePolicySkip}:                                    true,
+			&TriggerSettingsTimeInterval{Expr: "* * * * *", MisfirePolicy: MisfirePolicyFireAll, MaxLateness: "1h"}:              true,
+			&TriggerSettingsTimeInterval{Expr: "* * * * *", MisfirePolicy: "fire_twice"}:                                         false,
+			&TriggerSettingsTimeInterval{Expr: "* * * * *", MaxLateness: "-1h"}:                                                  false,
 		}
 
 		for settings, valid := range testData {
@@ -62,20 +86,79 @@ func TestTriggerSettingsTimeInterval(t *testing.T) {
 			}
 		}
 	})
+	t.Run("Misfire defaults to fire_once", func(t *testing.T) {
+		settings := &TriggerSettingsTimeInterval{}
+		if policy := settings.Misfire(); policy != MisfirePolicyFireOnce {
+			t.Errorf("Expected default misfire policy to be %s, got %s", MisfirePolicyFireOnce, policy)
+		}
+
+		settings.MisfirePolicy = MisfirePolicySkip
+		if policy := settings.Misfire(); policy != MisfirePolicySkip {
+			t.Errorf("Expected misfire policy to be %s, got %s", MisfirePolicySkip, policy)
+		}
+	})
+	t.Run("Location returns the settings timezone", func(t *testing.T) {
+		settings := &TriggerSettingsTimeInterval{}
+		if loc, err := settings.Location(); err != nil || loc != time.Local {
+			t.Errorf("Expected empty timezone to be local time, got %v, %v", loc, err)
+		}
+
+		settings.Timezone = "Europe/Zurich"
+		if loc, err := settings.Location(); err != nil || loc.String() != "Europe/Zurich" {
+			t.Errorf("Expected location to be Europe/Zurich, got %v, %v", loc, err)
+		}
+	})
+}
+
+func TestTriggerSettingsOnce(t *testing.T) {
+	t.Run("Validate properly checks settings", func(t *testing.T) {
+		testData := map[*TriggerSettingsOnce]bool{
+			&TriggerSettingsOnce{}:                                false,
+			&TriggerSettingsOnce{At: "2020-06-01"}:                false,
+			&TriggerSettingsOnce{At: "2020-06-01T02:00:00Z"}:      true,
+			&TriggerSettingsOnce{At: "2020-06-01T02:00:00+02:00"}: true,
+		}
+
+		for settings, valid := range testData {
+			err := settings.Validate()
+
+			if valid && err != nil {
+				t.Errorf("Expected err to be nil, got %s with settings: %#v", err, settings)
+			} else if !valid && err == nil {
+				t.Errorf("Expected err to be not nil with settings: %#v", settings)
+			}
+		}
+	})
+	t.Run("Time returns the parsed At", func(t *testing.T) {
+		settings := &TriggerSettingsOnce{At: "2020-06-01T02:00:00+02:00"}
+
+		expected := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+		if at, err := settings.Time(); err != nil || !at.Equal(expected) {
+			t.Errorf("Expected time to be %v, got %v, %v", expected, at, err)
+		}
+	})
 }
 
 func TestTriggerSettingsEvent(t *testing.T) {
 	t.Run("Validate properly checks settings", func(t *testing.T) {
 		testData := map[*TriggerSettingsEvent]bool{
-			&TriggerSettingsEvent{EventType: ""}:                                        false,
-			&TriggerSettingsEvent{EventType: "CLIENT_SUBSCRIBED"}:                       false,
-			&TriggerSettingsEvent{EventType: "CLIENT_SUBSCRIBED", MaxOccurrence: 0}:     false,
-			&TriggerSettingsEvent{EventType: "CLIENT_SUBSCRIBED", MaxOccurrence: 0}:     false,
-			&TriggerSettingsEvent{EventType: "CLIENT_SUBSCRIBED", MaxOccurrence: -1}:    false,
-			&TriggerSettingsEvent{EventType: "NOT_VALID_TYPE", MaxOccurrence: 1}:        false,
-			&TriggerSettingsEvent{EventType: "CLIENT_SUBSCRIBED", MaxOccurrence: 1}:     true,
-			&TriggerSettingsEvent{EventType: "CLIENT_SUBSCRIBED", MaxOccurrence: 5}:     true,
-			&TriggerSettingsEvent{EventType: "CLIENT_UNSUBSCRIBED", MaxOccurrence: 100}: true,
+			&TriggerSettingsEvent{EventType: ""}:                                                                                    false,
+			&TriggerSettingsEvent{EventType: "CLIENT_SUBSCRIBED"}:                                                                   false,
+			&TriggerSettingsEvent{EventType: "CLIENT_SUBSCRIBED", MaxOccurrence: 0}:                                                 false,
+			&TriggerSettingsEvent{EventType: "CLIENT_SUBSCRIBED", MaxOccurrence: 0}:                                                 false,
+			&TriggerSettingsEvent{EventType: "CLIENT_SUBSCRIBED", MaxOccurrence: -1}:                                                false,
+			&TriggerSettingsEvent{EventType: "NOT_VALID_TYPE", MaxOccurrence: 1}:                                                    false,
+			&TriggerSettingsEvent{EventType: "CLIENT_SUBSCRIBED", MaxOccurrence: 1}:                                                 true,
+			&TriggerSettingsEvent{EventType: "CLIENT_SUBSCRIBED", MaxOccurrence: 5}:                                                 true,
+			&TriggerSettingsEvent{EventType: "CLIENT_UNSUBSCRIBED", MaxOccurrence: 100}:                                             true,
+			&TriggerSettingsEvent{EventType: "CLIENT_SUBSCRIBED", MaxOccurrence: 5, Window: "10m"}:                                  true,
+			&TriggerSettingsEvent{EventTypes: []EventType{"CLIENT_SUBSCRIBED", "CLIENT_UNSUBSCRIBED"}, MaxOccurrence: 1}:            true,
+			&TriggerSettingsEvent{EventType: "CLIENT_SUBSCRIBED", EventTypes: []EventType{"CLIENT_UNSUBSCRIBED"}, MaxOccurrence: 1}: true,
+			&TriggerSettingsEvent{EventTypes: []EventType{"CLIENT_SUBSCRIBED", "NOT_VALID_TYPE"}, MaxOccurrence: 1}:                 false,
+			&TriggerSettingsEvent{EventTypes: []EventType{"UNDEFINED"}, MaxOccurrence: 1}:                                           false,
+			&TriggerSettingsEvent{EventTypes: []EventType{}, MaxOccurrence: 1}:                                                      false,
+			&TriggerSettingsEvent{EventType: "CLIENT_SUBSCRIBED", MaxOccurrence: 5, Window: "10"}:                                   false,
+			&TriggerSettingsEvent{EventType: "CLIENT_SUBSCRIBED", MaxOccurrence: 5, Window: "-1m"}:                                  false,
 		}
 
 		for settings, valid := range testData {
@@ -88,4 +171,86 @@ func TestTriggerSettingsEvent(t *testing.T) {
 			}
 		}
 	})
+	t.Run("Validate checks event filters", func(t *testing.T) {
+		testData := map[*EventFilter]bool{
+			&EventFilter{}:                                        false,
+			&EventFilter{Source: "test-.*"}:                       true,
+			&EventFilter{Target: "/devices/(a|b)"}:                true,
+			&EventFilter{Source: "("}:                             false,
+			&EventFilter{Target: "["}:                             false,
+			&EventFilter{From: "22:00", To: "06:00"}:              true,
+			&EventFilter{From: "22:00"}:                           false,
+			&EventFilter{To: "06:00"}:                             false,
+			&EventFilter{From: "25:00", To: "06:00"}:              false,
+			&EventFilter{Source: "test-.*", From: "9:00"}:         false,
+			&EventFilter{Source: "a", From: "09:00", To: "17:30"}: true,
+			&EventFilter{Description: "maintenance"}:              false,
+			&EventFilter{Source: "a", Description: "maintenance"}: false,
+		}
+
+		for filter, valid := range testData {
+			err := filter.Validate()
+
+			if valid && err != nil {
+				t.Errorf("Expected err to be nil, got %s with filter: %#v", err, filter)
+			} else if !valid && err == nil {
+				t.Errorf("Expected err to be not nil with filter: %#v", filter)
+			}
+
+			settings := &TriggerSettingsEvent{
+				EventType:     EventTypeClientSubscribed,
+				MaxOccurrence: 1,
+				Include:       []EventFilter{*filter},
+				Exclude:       []EventFilter{*filter},
+			}
+			if err := settings.Validate(); (err == nil) != valid {
+				t.Errorf("Expected settings validation to be %v with filter: %#v, got error %v", valid, filter, err)
+			}
+		}
+	})
+	t.Run("WindowDuration returns the parsed window", func(t *testing.T) {
+		testData := map[string]time.Duration{
+			"":      0,
+			"10m":   10 * time.Minute,
+			"1h30m": 90 * time.Minute,
+			"-1m":   0,
+			"bad":   0,
+		}
+
+		for window, expected := range testData {
+			settings := &TriggerSettingsEvent{Window: window}
+			if got := settings.WindowDuration(); got != expected {
+				t.Errorf("Expected window %q to be %v, got %v", window, expected, got)
+			}
+		}
+	})
+	t.Run("WatchedEventTypes merges EventType and EventTypes", func(t *testing.T) {
+		settings := &TriggerSettingsEvent{
+			EventType:  EventTypeClientSubscribed,
+			EventTypes: []EventType{EventTypeClientUnsubscribed, EventTypeClientSubscribed},
+		}
+
+		expected := []EventType{EventTypeClientSubscribed, EventTypeClientUnsubscribed}
+		if got := settings.WatchedEventTypes(); reflect.DeepEqual(got, expected) == false {
+			t.Errorf("Expected watched event types to be %v, got %v", expected, got)
+		}
+	})
+}
+
+func TestAvailableEventTypes(t *testing.T) {
+	eventTypes := AvailableEventTypes()
+
+	if len(eventTypes) != len(c2pb.EventType_value)-1 {
+		t.Errorf("Expected every C2 event type but UNDEFINED to be available, got %v", eventTypes)
+	}
+
+	for _, eventType := range eventTypes {
+		if !eventType.IsValid() {
+			t.Errorf("Expected event type %s to be valid", eventType)
+		}
+	}
+
+	if EventType("UNDEFINED").IsValid() {
+		t.Errorf("Expected UNDEFINED event type to be invalid")
+	}
 }
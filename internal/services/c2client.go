@@ -34,6 +34,11 @@ type C2EventStreamClient interface {
 type C2 interface {
 	NewClientKey(ctx context.Context, clientName string) error
 	NewTopicKey(ctx context.Context, topic string) error
+	RemoveClient(ctx context.Context, clientName string) error
+	RemoveTopicClient(ctx context.Context, clientName string, topic string) error
+	RemoveTopic(ctx context.Context, topic string) error
+	GetClients(ctx context.Context, offset, count int64) ([]string, error)
+	GetTopics(ctx context.Context, offset, count int64) ([]string, error)
 	SubscribeToEventStream(ctx context.Context) (C2EventStreamClient, error)
 }
 
@@ -80,6 +85,92 @@ func (c *c2) NewTopicKey(ctx context.Context, topic string) error {
 	return err
 }
 
+func (c *c2) RemoveClient(ctx context.Context, clientName string) error {
+	ctx, span := trace.StartSpan(ctx, "C2Client.RemoveClient")
+	defer span.End()
+
+	client, err := c.c2PbClientFactory.Create()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	_, err = client.RemoveClient(ctx, &c2pb.RemoveClientRequest{Client: &c2pb.Client{Name: clientName}})
+
+	return err
+}
+
+func (c *c2) RemoveTopicClient(ctx context.Context, clientName string, topic string) error {
+	ctx, span := trace.StartSpan(ctx, "C2Client.RemoveTopicClient")
+	defer span.End()
+
+	client, err := c.c2PbClientFactory.Create()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	_, err = client.RemoveTopicClient(ctx, &c2pb.RemoveTopicClientRequest{Client: &c2pb.Client{Name: clientName}, Topic: topic})
+
+	return err
+}
+
+func (c *c2) RemoveTopic(ctx context.Context, topic string) error {
+	ctx, span := trace.StartSpan(ctx, "C2Client.RemoveTopic")
+	defer span.End()
+
+	client, err := c.c2PbClientFactory.Create()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	_, err = client.RemoveTopic(ctx, &c2pb.RemoveTopicRequest{Topic: topic})
+
+	return err
+}
+
+func (c *c2) GetClients(ctx context.Context, offset, count int64) ([]string, error) {
+	ctx, span := trace.StartSpan(ctx, "C2Client.GetClients")
+	defer span.End()
+
+	client, err := c.c2PbClientFactory.Create()
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	resp, err := client.GetClients(ctx, &c2pb.GetClientsRequest{Offset: offset, Count: count})
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, c := range resp.Clients {
+		names = append(names, c.Name)
+	}
+
+	return names, nil
+}
+
+func (c *c2) GetTopics(ctx context.Context, offset, count int64) ([]string, error) {
+	ctx, span := trace.StartSpan(ctx, "C2Client.GetTopics")
+	defer span.End()
+
+	client, err := c.c2PbClientFactory.Create()
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	resp, err := client.GetTopics(ctx, &c2pb.GetTopicsRequest{Offset: offset, Count: count})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Topics, nil
+}
+
 func (c *c2) SubscribeToEventStream(ctx context.Context) (C2EventStreamClient, error) {
 	ctx, span := trace.StartSpan(ctx, "C2Client.SubscribeToEventStream")
 	defer span.End()
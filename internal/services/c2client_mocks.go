@@ -50,6 +50,36 @@ func (m *MockC2) EXPECT() *MockC2MockRecorder {
 	return m.recorder
 }
 
+// GetClients mocks base method
+func (m *MockC2) GetClients(arg0 context.Context, arg1, arg2 int64) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClients", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClients indicates an expected call of GetClients
+func (mr *MockC2MockRecorder) GetClients(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClients", reflect.TypeOf((*MockC2)(nil).GetClients), arg0, arg1, arg2)
+}
+
+// GetTopics mocks base method
+func (m *MockC2) GetTopics(arg0 context.Context, arg1, arg2 int64) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTopics", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTopics indicates an expected call of GetTopics
+func (mr *MockC2MockRecorder) GetTopics(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTopics", reflect.TypeOf((*MockC2)(nil).GetTopics), arg0, arg1, arg2)
+}
+
 // NewClientKey mocks base method
 func (m *MockC2) NewClientKey(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -78,6 +108,48 @@ func (mr *MockC2MockRecorder) NewTopicKey(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewTopicKey", reflect.TypeOf((*MockC2)(nil).NewTopicKey), arg0, arg1)
 }
 
+// RemoveClient mocks base method
+func (m *MockC2) RemoveClient(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveClient", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveClient indicates an expected call of RemoveClient
+func (mr *MockC2MockRecorder) RemoveClient(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveClient", reflect.TypeOf((*MockC2)(nil).RemoveClient), arg0, arg1)
+}
+
+// RemoveTopic mocks base method
+func (m *MockC2) RemoveTopic(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveTopic", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveTopic indicates an expected call of RemoveTopic
+func (mr *MockC2MockRecorder) RemoveTopic(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTopic", reflect.TypeOf((*MockC2)(nil).RemoveTopic), arg0, arg1)
+}
+
+// RemoveTopicClient mocks base method
+func (m *MockC2) RemoveTopicClient(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveTopicClient", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveTopicClient indicates an expected call of RemoveTopicClient
+func (mr *MockC2MockRecorder) RemoveTopicClient(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTopicClient", reflect.TypeOf((*MockC2)(nil).RemoveTopicClient), arg0, arg1, arg2)
+}
+
 // SubscribeToEventStream mocks base method
 func (m *MockC2) SubscribeToEventStream(arg0 context.Context) (C2EventStreamClient, error) {
 	m.ctrl.T.Helper()
@@ -70,6 +70,104 @@ func TestC2(t *testing.T) {
 		}
 	})
 
+	t.Run("RemoveClient creates expected request", func(t *testing.T) {
+		clientName := "expectedClientName"
+
+		expectedError := errors.New("expected error response")
+		expectedRequest := &c2pb.RemoveClientRequest{Client: &c2pb.Client{Name: clientName}}
+
+		mockClient := pb.NewMockC2PbClient(mockCtrl)
+		mockClient.EXPECT().RemoveClient(gomock.Any(), expectedRequest).Return(nil, expectedError)
+		mockClient.EXPECT().Close()
+
+		mockClientFactory.EXPECT().Create().Return(mockClient, nil)
+
+		err := c2.RemoveClient(ctx, clientName)
+		if err != expectedError {
+			t.Errorf("Expected err to be %v, got %v", expectedError, err)
+		}
+	})
+
+	t.Run("RemoveTopicClient creates expected request", func(t *testing.T) {
+		clientName := "expectedClientName"
+		topicID := "topicID"
+
+		expectedError := errors.New("expected error response")
+		expectedRequest := &c2pb.RemoveTopicClientRequest{Client: &c2pb.Client{Name: clientName}, Topic: topicID}
+
+		mockClient := pb.NewMockC2PbClient(mockCtrl)
+		mockClient.EXPECT().RemoveTopicClient(gomock.Any(), expectedRequest).Return(nil, expectedError)
+		mockClient.EXPECT().Close()
+
+		mockClientFactory.EXPECT().Create().Return(mockClient, nil)
+
+		err := c2.RemoveTopicClient(ctx, clientName, topicID)
+		if err != expectedError {
+			t.Errorf("Expected err to be %v, got %v", expectedError, err)
+		}
+	})
+
+	t.Run("RemoveTopic creates expected request", func(t *testing.T) {
+		topicID := "topicID"
+
+		expectedError := errors.New("expected error response")
+		expectedRequest := &c2pb.RemoveTopicRequest{Topic: topicID}
+
+		mockClient := pb.NewMockC2PbClient(mockCtrl)
+		mockClient.EXPECT().RemoveTopic(gomock.Any(), expectedRequest).Return(nil, expectedError)
+		mockClient.EXPECT().Close()
+
+		mockClientFactory.EXPECT().Create().Return(mockClient, nil)
+
+		err := c2.RemoveTopic(ctx, topicID)
+		if err != expectedError {
+			t.Errorf("Expected err to be %v, got %v", expectedError, err)
+		}
+	})
+
+	t.Run("GetClients creates expected request and returns client names", func(t *testing.T) {
+		expectedRequest := &c2pb.GetClientsRequest{Offset: 10, Count: 2}
+		resp := &c2pb.GetClientsResponse{
+			Clients: []*c2pb.Client{&c2pb.Client{Name: "client1"}, &c2pb.Client{Name: "client2"}},
+		}
+
+		mockClient := pb.NewMockC2PbClient(mockCtrl)
+		mockClient.EXPECT().GetClients(gomock.Any(), expectedRequest).Return(resp, nil)
+		mockClient.EXPECT().Close()
+
+		mockClientFactory.EXPECT().Create().Return(mockClient, nil)
+
+		names, err := c2.GetClients(ctx, 10, 2)
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+
+		expectedNames := []string{"client1", "client2"}
+		if reflect.DeepEqual(names, expectedNames) == false {
+			t.Errorf("Expected names to be %v, got %v", expectedNames, names)
+		}
+	})
+
+	t.Run("GetTopics creates expected request and returns topics", func(t *testing.T) {
+		expectedRequest := &c2pb.GetTopicsRequest{Offset: 10, Count: 2}
+		resp := &c2pb.GetTopicsResponse{Topics: []string{"topic1", "topic2"}}
+
+		mockClient := pb.NewMockC2PbClient(mockCtrl)
+		mockClient.EXPECT().GetTopics(gomock.Any(), expectedRequest).Return(resp, nil)
+		mockClient.EXPECT().Close()
+
+		mockClientFactory.EXPECT().Create().Return(mockClient, nil)
+
+		topics, err := c2.GetTopics(ctx, 10, 2)
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+
+		if reflect.DeepEqual(topics, resp.Topics) == false {
+			t.Errorf("Expected topics to be %v, got %v", resp.Topics, topics)
+		}
+	})
+
 	t.Run("SubscribeToClientStream creates expected request", func(t *testing.T) {
 		mockClient := pb.NewMockC2PbClient(mockCtrl)
 
@@ -0,0 +1,116 @@
+// Copyright 2020 Teserakt AG
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+//go:generate mockgen -copyright_file ../../doc/COPYRIGHT_TEMPLATE.txt -destination=executions_mocks.go -package=services -self_package github.com/teserakt-io/automation-engine/internal/services github.com/teserakt-io/automation-engine/internal/services ExecutionService
+
+import (
+	"context"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"go.opencensus.io/trace"
+
+	"github.com/teserakt-io/automation-engine/internal/models"
+)
+
+// DefaultExecutionsLimit is the number of executions returned when no limit is given
+const DefaultExecutionsLimit = 100
+
+// ExecutionReader defines methods to read rule executions
+type ExecutionReader interface {
+	List(ctx context.Context, ruleID int, since time.Time, limit int) ([]models.Execution, error)
+}
+
+// ExecutionWriter defines methods to write rule executions
+type ExecutionWriter interface {
+	Save(ctx context.Context, execution *models.Execution) error
+	// UpdateOutcome updates the Error and Pending columns of a saved execution outcome
+	UpdateOutcome(ctx context.Context, outcome models.ExecutionOutcome) error
+}
+
+// ExecutionService defines a service for managing rule executions history
+type ExecutionService interface {
+	ExecutionReader
+	ExecutionWriter
+}
+
+type executionService struct {
+	db models.Database
+}
+
+var _ ExecutionService = (*executionService)(nil)
+
+// NewExecutionService creates a new service for handling rule executions
+func NewExecutionService(db models.Database) ExecutionService {
+	return &executionService{
+		db: db,
+	}
+}
+
+// Save records the given execution and its outcomes in database
+func (s *executionService) Save(ctx context.Context, execution *models.Execution) error {
+	_, span := trace.StartSpan(ctx, "ExecutionService.Save")
+	defer span.End()
+
+	if result := s.gorm().Save(execution); result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
+
+// UpdateOutcome updates the Error and Pending columns of given outcome, identified by its ID
+func (s *executionService) UpdateOutcome(ctx context.Context, outcome models.ExecutionOutcome) error {
+	_, span := trace.StartSpan(ctx, "ExecutionService.UpdateOutcome")
+	defer span.End()
+
+	result := s.db.Connection().Model(&models.ExecutionOutcome{ID: outcome.ID}).Updates(map[string]interface{}{
+		"error":   outcome.Error,
+		"pending": outcome.Pending,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
+
+// List retrieves the executions of given rule triggered after since, most recent first.
+// A zero since returns all executions, and limit defaults to DefaultExecutionsLimit when not positive.
+func (s *executionService) List(ctx context.Context, ruleID int, since time.Time, limit int) ([]models.Execution, error) {
+	_, span := trace.StartSpan(ctx, "ExecutionService.List")
+	defer span.End()
+
+	if limit <= 0 {
+		limit = DefaultExecutionsLimit
+	}
+
+	query := s.gorm().Where("rule_id = ?", ruleID)
+	if !since.IsZero() {
+		query = query.Where("triggered_at > ?", since)
+	}
+
+	executions := []models.Execution{}
+	if result := query.Order("triggered_at DESC, id DESC").Limit(limit).Find(&executions); result.Error != nil {
+		return nil, result.Error
+	}
+
+	return executions, nil
+}
+
+func (s *executionService) gorm() *gorm.DB {
+	return s.db.Connection().Set("gorm:auto_preload", true)
+}
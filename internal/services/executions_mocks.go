@@ -0,0 +1,94 @@
+// Copyright 2020 Teserakt AG
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/teserakt-io/automation-engine/internal/services (interfaces: ExecutionService)
+
+// Package services is a generated GoMock package.
+package services
+
+import (
+	context "context"
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/teserakt-io/automation-engine/internal/models"
+	reflect "reflect"
+	time "time"
+)
+
+// MockExecutionService is a mock of ExecutionService interface
+type MockExecutionService struct {
+	ctrl     *gomock.Controller
+	recorder *MockExecutionServiceMockRecorder
+}
+
+// MockExecutionServiceMockRecorder is the mock recorder for MockExecutionService
+type MockExecutionServiceMockRecorder struct {
+	mock *MockExecutionService
+}
+
+// NewMockExecutionService creates a new mock instance
+func NewMockExecutionService(ctrl *gomock.Controller) *MockExecutionService {
+	mock := &MockExecutionService{ctrl: ctrl}
+	mock.recorder = &MockExecutionServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockExecutionService) EXPECT() *MockExecutionServiceMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method
+func (m *MockExecutionService) List(arg0 context.Context, arg1 int, arg2 time.Time, arg3 int) ([]models.Execution, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]models.Execution)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List
+func (mr *MockExecutionServiceMockRecorder) List(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockExecutionService)(nil).List), arg0, arg1, arg2, arg3)
+}
+
+// Save mocks base method
+func (m *MockExecutionService) Save(arg0 context.Context, arg1 *models.Execution) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save
+func (mr *MockExecutionServiceMockRecorder) Save(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockExecutionService)(nil).Save), arg0, arg1)
+}
+
+// UpdateOutcome mocks base method
+func (m *MockExecutionService) UpdateOutcome(arg0 context.Context, arg1 models.ExecutionOutcome) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOutcome", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateOutcome indicates an expected call of UpdateOutcome
+func (mr *MockExecutionServiceMockRecorder) UpdateOutcome(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOutcome", reflect.TypeOf((*MockExecutionService)(nil).UpdateOutcome), arg0, arg1)
+}
@@ -0,0 +1,202 @@
+// Copyright 2020 Teserakt AG
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/teserakt-io/automation-engine/internal/models"
+	"github.com/teserakt-io/automation-engine/internal/pb"
+)
+
+func TestExecutionService(t *testing.T) {
+	testExecutionServiceDatabase(t, sqliteTestDB)
+
+	if os.Getenv("C2AETEST_POSTGRES") == "" {
+		t.Skip("C2AETEST_POSTGRES environment is not set")
+
+		return
+	}
+	testExecutionServiceDatabase(t, postgresTestDB)
+}
+
+func testExecutionServiceDatabase(t *testing.T, getTestDB func(t *testing.T) (models.Database, func())) {
+	ctx := context.Background()
+
+	now := time.Now().UTC().Truncate(time.Second)
+
+	createExecutions := func(t *testing.T, srv ExecutionService) []models.Execution {
+		executions := []models.Execution{
+			models.Execution{
+				RuleID:      1,
+				TriggerID:   1,
+				TriggeredAt: now.Add(-2 * time.Hour),
+				Duration:    time.Second,
+				Outcomes: []models.ExecutionOutcome{
+					models.ExecutionOutcome{ActionType: pb.ActionType_KEY_ROTATION, TargetType: pb.TargetType_CLIENT, Target: "client1"},
+					models.ExecutionOutcome{ActionType: pb.ActionType_KEY_ROTATION, TargetType: pb.TargetType_CLIENT, Target: "client2", Error: "failed"},
+				},
+			},
+			models.Execution{
+				RuleID:      1,
+				TriggerID:   2,
+				TriggeredAt: now.Add(-1 * time.Hour),
+				EventType:   "CLIENT_SUBSCRIBED",
+				EventSource: "client1",
+				EventTarget: "topic1",
+				EventTime:   now.Add(-1 * time.Hour),
+				Error:       "action failed",
+				Outcomes:    []models.ExecutionOutcome{},
+			},
+			models.Execution{
+				RuleID:      2,
+				TriggerID:   3,
+				TriggeredAt: now,
+				Outcomes:    []models.ExecutionOutcome{},
+			},
+		}
+
+		for i := range executions {
+			if err := srv.Save(ctx, &executions[i]); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+		}
+
+		return executions
+	}
+
+	t.Run("List returns the rule executions, most recent first", func(t *testing.T) {
+		db, closeFunc := getTestDB(t)
+		defer closeFunc()
+
+		srv := NewExecutionService(db)
+		executions := createExecutions(t, srv)
+
+		result, err := srv.List(ctx, 1, time.Time{}, 0)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		expected := []models.Execution{executions[1], executions[0]}
+		if len(result) != len(expected) {
+			t.Fatalf("Expected %d executions, got %d", len(expected), len(result))
+		}
+
+		for i := range expected {
+			assertSameExecution(t, expected[i], result[i])
+		}
+	})
+
+	t.Run("List filters executions triggered before since", func(t *testing.T) {
+		db, closeFunc := getTestDB(t)
+		defer closeFunc()
+
+		srv := NewExecutionService(db)
+		executions := createExecutions(t, srv)
+
+		result, err := srv.List(ctx, 1, now.Add(-90*time.Minute), 0)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if len(result) != 1 {
+			t.Fatalf("Expected 1 execution, got %d", len(result))
+		}
+		assertSameExecution(t, executions[1], result[0])
+	})
+
+	t.Run("List returns at most limit executions", func(t *testing.T) {
+		db, closeFunc := getTestDB(t)
+		defer closeFunc()
+
+		srv := NewExecutionService(db)
+		executions := createExecutions(t, srv)
+
+		result, err := srv.List(ctx, 1, time.Time{}, 1)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if len(result) != 1 {
+			t.Fatalf("Expected 1 execution, got %d", len(result))
+		}
+		assertSameExecution(t, executions[1], result[0])
+	})
+
+	t.Run("UpdateOutcome completes a pending outcome of a saved execution", func(t *testing.T) {
+		db, closeFunc := getTestDB(t)
+		defer closeFunc()
+
+		srv := NewExecutionService(db)
+
+		execution := models.Execution{
+			RuleID:      3,
+			TriggeredAt: now,
+			Outcomes: []models.ExecutionOutcome{
+				models.ExecutionOutcome{ActionType: pb.ActionType_KEY_ROTATION, TargetType: pb.TargetType_CLIENT, Target: "client1", Pending: true},
+				models.ExecutionOutcome{ActionType: pb.ActionType_KEY_ROTATION, TargetType: pb.TargetType_CLIENT, Target: "client2", Pending: true},
+			},
+		}
+		if err := srv.Save(ctx, &execution); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		for _, outcome := range execution.Outcomes {
+			if outcome.ID == 0 {
+				t.Fatalf("Expected saved outcomes to have an ID")
+			}
+		}
+
+		failed := execution.Outcomes[1]
+		failed.Pending = false
+		failed.Error = "rotation failed"
+		// Other columns are left untouched
+		failed.Target = "client3"
+		if err := srv.UpdateOutcome(ctx, failed); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		result, err := srv.List(ctx, 3, time.Time{}, 0)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if len(result) != 1 {
+			t.Fatalf("Expected 1 execution, got %d", len(result))
+		}
+
+		execution.Outcomes[1].Pending = false
+		execution.Outcomes[1].Error = "rotation failed"
+		assertSameExecution(t, execution, result[0])
+	})
+}
+
+func assertSameExecution(t *testing.T, expected models.Execution, got models.Execution) {
+	if !expected.TriggeredAt.Equal(got.TriggeredAt) || !expected.EventTime.Equal(got.EventTime) {
+		t.Errorf("Expected execution times to be %v / %v, got %v / %v", expected.TriggeredAt, expected.EventTime, got.TriggeredAt, got.EventTime)
+	}
+
+	// Times are compared above, as they may hold a different location once loaded
+	expected.TriggeredAt, got.TriggeredAt = time.Time{}, time.Time{}
+	expected.EventTime, got.EventTime = time.Time{}, time.Time{}
+
+	if reflect.DeepEqual(expected, got) == false {
+		t.Errorf("Expected execution to be %#v, got %#v", expected, got)
+	}
+}
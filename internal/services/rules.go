@@ -46,6 +46,11 @@ type TargetWriter interface {
 	DeleteTargets(ctx context.Context, targets ...models.Target) error
 }
 
+// ActionWriter defines methods to write Actions
+type ActionWriter interface {
+	DeleteActions(ctx context.Context, actions ...models.Action) error
+}
+
 // RuleReader defines methods available to read rules from database
 type RuleReader interface {
 	All(ctx context.Context) ([]models.Rule, error)
@@ -55,6 +60,12 @@ type RuleReader interface {
 // RuleWriter defines methods available to write rules
 type RuleWriter interface {
 	Save(ctx context.Context, rule *models.Rule) error
+	// SaveExecutionState only saves the rule columns owned by its rule watcher, leaving the
+	// other ones as they are stored, so the rule watcher doesn't revert concurrent rule updates.
+	SaveExecutionState(ctx context.Context, rule models.Rule) error
+	// SaveEnabled only saves whether the rule is enabled and when it was last resumed,
+	// so pausing or resuming a rule doesn't revert its execution state.
+	SaveEnabled(ctx context.Context, rule models.Rule) error
 	Delete(ctx context.Context, rule models.Rule) error
 }
 
@@ -68,6 +79,8 @@ type RuleService interface {
 
 	TriggerReader
 	TriggerWriter
+
+	ActionWriter
 }
 
 type ruleService struct {
@@ -95,6 +108,10 @@ func (s *ruleService) All(ctx context.Context) ([]models.Rule, error) {
 		return nil, result.Error
 	}
 
+	for i := range rules {
+		rules[i].SortActions()
+	}
+
 	return rules, nil
 }
 
@@ -114,6 +131,40 @@ func (s *ruleService) Save(ctx context.Context, rule *models.Rule) error {
 	return nil
 }
 
+// SaveExecutionState updates the LastExecuted, ArmedTriggers, SuppressedCount and LastSuppressed columns of given rule
+func (s *ruleService) SaveExecutionState(ctx context.Context, rule models.Rule) error {
+	_, span := trace.StartSpan(ctx, "RuleService.SaveExecutionState")
+	defer span.End()
+
+	result := s.db.Connection().Model(&models.Rule{ID: rule.ID}).Updates(map[string]interface{}{
+		"last_executed":    rule.LastExecuted,
+		"armed_triggers":   rule.ArmedTriggers,
+		"suppressed_count": rule.SuppressedCount,
+		"last_suppressed":  rule.LastSuppressed,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
+
+// SaveEnabled updates the Enabled and ResumedAt columns of given rule
+func (s *ruleService) SaveEnabled(ctx context.Context, rule models.Rule) error {
+	_, span := trace.StartSpan(ctx, "RuleService.SaveEnabled")
+	defer span.End()
+
+	result := s.db.Connection().Model(&models.Rule{ID: rule.ID}).Updates(map[string]interface{}{
+		"enabled":    rule.Enabled,
+		"resumed_at": rule.ResumedAt,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
+
 // ByID retrieves a rule by its ID
 func (s *ruleService) ByID(ctx context.Context, ruleID int) (models.Rule, error) {
 	_, span := trace.StartSpan(ctx, "RuleService.ByID")
@@ -124,6 +175,8 @@ func (s *ruleService) ByID(ctx context.Context, ruleID int) (models.Rule, error)
 		return r, result.Error
 	}
 
+	r.SortActions()
+
 	return r, nil
 }
 
@@ -203,6 +256,25 @@ func (s *ruleService) DeleteTargets(ctx context.Context, targets ...models.Targe
 	return nil
 }
 
+// DeleteActions will delete all given actions in a single batch
+func (s *ruleService) DeleteActions(ctx context.Context, actions ...models.Action) error {
+	_, span := trace.StartSpan(ctx, "RuleService.DeleteActions")
+	defer span.End()
+
+	var actionIds []int
+	for _, action := range actions {
+		actionIds = append(actionIds, action.ID)
+	}
+
+	if len(actionIds) > 0 {
+		if result := s.gorm().Delete(models.Action{}, "id IN (?)", actionIds); result.Error != nil {
+			return result.Error
+		}
+	}
+
+	return nil
+}
+
 func (s *ruleService) gorm() *gorm.DB {
 	return s.db.Connection().Set("gorm:auto_preload", true)
 }
@@ -93,6 +93,25 @@ func (mr *MockRuleServiceMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRuleService)(nil).Delete), arg0, arg1)
 }
 
+// DeleteActions mocks base method
+func (m *MockRuleService) DeleteActions(arg0 context.Context, arg1 ...models.Action) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteActions", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteActions indicates an expected call of DeleteActions
+func (mr *MockRuleServiceMockRecorder) DeleteActions(arg0 interface{}, arg1 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteActions", reflect.TypeOf((*MockRuleService)(nil).DeleteActions), varargs...)
+}
+
 // DeleteTargets mocks base method
 func (m *MockRuleService) DeleteTargets(arg0 context.Context, arg1 ...models.Target) error {
 	m.ctrl.T.Helper()
@@ -145,6 +164,34 @@ func (mr *MockRuleServiceMockRecorder) Save(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockRuleService)(nil).Save), arg0, arg1)
 }
 
+// SaveEnabled mocks base method
+func (m *MockRuleService) SaveEnabled(arg0 context.Context, arg1 models.Rule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveEnabled", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveEnabled indicates an expected call of SaveEnabled
+func (mr *MockRuleServiceMockRecorder) SaveEnabled(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveEnabled", reflect.TypeOf((*MockRuleService)(nil).SaveEnabled), arg0, arg1)
+}
+
+// SaveExecutionState mocks base method
+func (m *MockRuleService) SaveExecutionState(arg0 context.Context, arg1 models.Rule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveExecutionState", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveExecutionState indicates an expected call of SaveExecutionState
+func (mr *MockRuleServiceMockRecorder) SaveExecutionState(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveExecutionState", reflect.TypeOf((*MockRuleService)(nil).SaveExecutionState), arg0, arg1)
+}
+
 // TargetByID mocks base method
 func (m *MockRuleService) TargetByID(arg0 context.Context, arg1 int) (models.Target, error) {
 	m.ctrl.T.Helper()
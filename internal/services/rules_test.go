@@ -126,11 +126,25 @@ func createRules(t *testing.T, srv RuleService, validator *models.MockValidator)
 				Settings:    []byte("settings1"),
 			},
 		},
+		Actions: []models.Action{},
 	}
 
 	rule2 = models.Rule{
-		ActionType:  pb.ActionType_KEY_ROTATION,
 		Description: "rule2",
+		Actions: []models.Action{
+			models.Action{
+				ID:         1,
+				Position:   0,
+				ActionType: pb.ActionType_KEY_ROTATION,
+				OnFailure:  pb.ActionFailurePolicy_CONTINUE,
+			},
+			models.Action{
+				ID:         2,
+				Position:   1,
+				ActionType: pb.ActionType_WEBHOOK,
+				Settings:   []byte("settings"),
+			},
+		},
 		Targets: []models.Target{
 			models.Target{
 				ID:   2,
@@ -335,6 +349,137 @@ func testRuleServiceDatabase(t *testing.T, getTestDB func(t *testing.T) (models.
 		}
 	})
 
+	t.Run("Save creates enabled rules and persists paused ones", func(t *testing.T) {
+		db, closeFunc := getTestDB(t)
+		defer closeFunc()
+
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		validator := models.NewMockValidator(mockCtrl)
+
+		srv := NewRuleService(db, validator)
+
+		rule1, _ := createRules(t, srv, validator)
+		if !rule1.Enabled {
+			t.Errorf("Expected created rule to be enabled")
+		}
+
+		rule1.Enabled = false
+		validator.EXPECT().ValidateRule(gomock.Any()).Return(nil)
+		if err := srv.Save(ctx, &rule1); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		rule, err := srv.ByID(ctx, rule1.ID)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if rule.Enabled {
+			t.Errorf("Expected rule to be paused")
+		}
+	})
+
+	t.Run("SaveExecutionState only updates the rule execution columns", func(t *testing.T) {
+		db, closeFunc := getTestDB(t)
+		defer closeFunc()
+
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		validator := models.NewMockValidator(mockCtrl)
+
+		srv := NewRuleService(db, validator)
+
+		rule1, _ := createRules(t, srv, validator)
+
+		// The rule watcher holds a copy of the rule, while the rule gets paused and updated
+		watcherRule := rule1
+
+		rule1.Enabled = false
+		rule1.Description = "updated"
+		validator.EXPECT().ValidateRule(gomock.Any()).Return(nil)
+		if err := srv.Save(ctx, &rule1); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		now := time.Now().UTC().Round(time.Second)
+		watcherRule.LastExecuted = now
+		watcherRule.ArmedTriggers = []byte(`{"1":"2020-01-01T00:00:00Z"}`)
+		watcherRule.SuppressedCount = 3
+		watcherRule.LastSuppressed = now
+		if err := srv.SaveExecutionState(ctx, watcherRule); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		rule, err := srv.ByID(ctx, rule1.ID)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if rule.Enabled || rule.Description != "updated" {
+			t.Errorf("Expected rule update to be kept, got enabled %t and description %s", rule.Enabled, rule.Description)
+		}
+
+		if !rule.LastExecuted.Equal(now) || !rule.LastSuppressed.Equal(now) || rule.SuppressedCount != 3 {
+			t.Errorf("Expected rule execution state to be saved, got %#v", rule)
+		}
+
+		if string(rule.ArmedTriggers) != string(watcherRule.ArmedTriggers) {
+			t.Errorf("Expected armed triggers to be %s, got %s", watcherRule.ArmedTriggers, rule.ArmedTriggers)
+		}
+	})
+
+	t.Run("SaveEnabled only updates the rule enabled and resume columns", func(t *testing.T) {
+		db, closeFunc := getTestDB(t)
+		defer closeFunc()
+
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		validator := models.NewMockValidator(mockCtrl)
+
+		srv := NewRuleService(db, validator)
+
+		rule1, _ := createRules(t, srv, validator)
+
+		// The api holds a copy of the rule, while its rule watcher saves its execution state
+		apiRule := rule1
+
+		now := time.Now().UTC().Round(time.Second)
+		rule1.LastExecuted = now
+		rule1.ArmedTriggers = []byte(`{"1":"2020-01-01T00:00:00Z"}`)
+		rule1.SuppressedCount = 3
+		if err := srv.SaveExecutionState(ctx, rule1); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		apiRule.Enabled = false
+		apiRule.ResumedAt = now.Add(time.Minute)
+		if err := srv.SaveEnabled(ctx, apiRule); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		rule, err := srv.ByID(ctx, rule1.ID)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if rule.Enabled || !rule.ResumedAt.Equal(apiRule.ResumedAt) {
+			t.Errorf(
+				"Expected rule to be paused and resumed at %v, got enabled %t and resumed at %v",
+				apiRule.ResumedAt,
+				rule.Enabled,
+				rule.ResumedAt,
+			)
+		}
+
+		if !rule.LastExecuted.Equal(now) || rule.SuppressedCount != 3 || string(rule.ArmedTriggers) != string(rule1.ArmedTriggers) {
+			t.Errorf("Expected rule execution state to be kept, got %#v", rule)
+		}
+	})
+
 	t.Run("Delete removes the rule and dependencies from database", func(t *testing.T) {
 		db, closeFunc := getTestDB(t)
 		defer closeFunc()
@@ -497,6 +642,78 @@ func testRuleServiceDatabase(t *testing.T, getTestDB func(t *testing.T) (models.
 		}
 	})
 
+	t.Run("DeleteActions properly delete given actions", func(t *testing.T) {
+		db, closeFunc := getTestDB(t)
+		defer closeFunc()
+
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		validator := models.NewMockValidator(mockCtrl)
+
+		srv := NewRuleService(db, validator)
+		_, rule2 := createRules(t, srv, validator)
+
+		originalActions := make([]models.Action, len(rule2.Actions))
+		copy(originalActions, rule2.Actions)
+
+		actions := []models.Action{
+			models.Action{ID: 1000, Position: 2},
+			models.Action{ID: 1001, Position: 3},
+		}
+
+		rule2.Actions = append(rule2.Actions, actions...)
+
+		validator.EXPECT().ValidateRule(rule2).Times(1)
+		if err := srv.Save(ctx, &rule2); err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+
+		err := srv.DeleteActions(ctx, actions...)
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+
+		r, err := srv.ByID(ctx, rule2.ID)
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+		if reflect.DeepEqual(originalActions, r.Actions) == false {
+			t.Errorf("Expected Actions to be %#v, got %#v", originalActions, r.Actions)
+		}
+	})
+
+	t.Run("ByID returns actions sorted by position", func(t *testing.T) {
+		db, closeFunc := getTestDB(t)
+		defer closeFunc()
+
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		validator := models.NewMockValidator(mockCtrl)
+
+		srv := NewRuleService(db, validator)
+		_, rule2 := createRules(t, srv, validator)
+
+		// Swap the actions positions
+		rule2.Actions[0].Position, rule2.Actions[1].Position = 1, 0
+
+		validator.EXPECT().ValidateRule(rule2).Times(1)
+		if err := srv.Save(ctx, &rule2); err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+
+		r, err := srv.ByID(ctx, rule2.ID)
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+
+		expectedActions := []models.Action{rule2.Actions[1], rule2.Actions[0]}
+		if reflect.DeepEqual(expectedActions, r.Actions) == false {
+			t.Errorf("Expected Actions to be %#v, got %#v", expectedActions, r.Actions)
+		}
+	})
+
 	t.Run("DeleteTriggers properly delete given triggers", func(t *testing.T) {
 		db, closeFunc := getTestDB(t)
 		defer closeFunc()